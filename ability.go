@@ -0,0 +1,52 @@
+package main
+
+import "fmt"
+
+type Ability struct {
+	Id             int             `json:"id"`
+	Name           string          `json:"name"`
+	Generation     NamedResource   `json:"generation"`
+	Effect_entries []EffectEntry   `json:"effect_entries"`
+	Names          []LocalizedName `json:"names"`
+	Pokemon        []struct {
+		Is_hidden bool          `json:"is_hidden"`
+		Slot      int           `json:"slot"`
+		Pokemon   NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// show what an ability does and which pokemon can have it
+func abilityCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter an ability")
+		return nil
+	}
+
+	var ability Ability
+	err := session.client.GetJSON(session.client.ResourceURL("ability", apiName(params)), &ability)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Ability:", englishName(ability.Names, ability.Name))
+	fmt.Println("Introduced in:", ability.Generation.Name)
+	effect, short := englishEffect(ability.Effect_entries)
+	if effect == "" {
+		effect = short
+	}
+	if effect != "" {
+		fmt.Println("Effect:", effect)
+	}
+
+	fmt.Println("Pokemon with this ability:")
+	for _, pokemon := range ability.Pokemon {
+		if pokemon.Is_hidden {
+			fmt.Println("-", pokemon.Pokemon.Name, "(hidden ability)")
+		} else {
+			fmt.Println("-", pokemon.Pokemon.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// a goal the player works towards, progress comes from game events
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Goal        int
+	// money for unlocking it
+	Reward int
+	// for exploration achievements the goal is every location in this region, looked up when needed
+	Region string
+	// update the progress for an event, the event may not be relevant
+	Track func(state *AchievementState, event GameEvent)
+}
+
+// how far the player is with one achievement, kept in the profile
+type AchievementState struct {
+	Progress int `json:"progress"`
+	// what was already counted, for achievements that count distinct things
+	Seen       []string   `json:"seen,omitempty"`
+	UnlockedAt *time.Time `json:"unlocked_at,omitempty"`
+}
+
+// count something once, no matter how often it happens
+func (state *AchievementState) countOnce(key string) {
+	for _, seen := range state.Seen {
+		if seen == key {
+			return
+		}
+	}
+	state.Seen = append(state.Seen, key)
+	state.Progress = len(state.Seen)
+}
+
+func countCatches(state *AchievementState, event GameEvent) {
+	if event.Kind == "catch" {
+		state.Progress++
+	}
+}
+
+func exploreRegion(region string) func(state *AchievementState, event GameEvent) {
+	return func(state *AchievementState, event GameEvent) {
+		if event.Kind == "explore" && event.Region == region {
+			state.countOnce(event.Location)
+		}
+	}
+}
+
+// every achievement in the order the achievements command lists them
+var achievements = []Achievement{
+	{ID: "first-catch", Name: "First catch", Description: "Catch your first Pokemon", Goal: 1, Reward: 200, Track: countCatches},
+	{ID: "catch-10", Name: "Collector", Description: "Catch 10 Pokemon", Goal: 10, Reward: 1000, Track: countCatches},
+	{ID: "catch-100", Name: "Hoarder", Description: "Catch 100 Pokemon", Goal: 100, Reward: 10000, Track: countCatches},
+	{ID: "shiny", Name: "Sparkles", Description: "Catch a shiny", Goal: 1, Reward: 5000, Track: func(state *AchievementState, event GameEvent) {
+		if event.Kind == "catch" && event.Caught.Shiny {
+			state.Progress++
+		}
+	}},
+	{ID: "explore-kanto", Name: "Kanto explorer", Description: "Explore every Kanto area", Region: "kanto", Reward: 5000, Track: exploreRegion("kanto")},
+	{ID: "explore-johto", Name: "Johto explorer", Description: "Explore every Johto area", Region: "johto", Reward: 5000, Track: exploreRegion("johto")},
+}
+
+// the progress needed to unlock an achievement
+func (client *Client) AchievementGoal(achievement Achievement) (int, error) {
+	if achievement.Region == "" {
+		return achievement.Goal, nil
+	}
+	region, err := client.GetRegion(achievement.Region)
+	if err != nil {
+		return 0, err
+	}
+	return len(region.Locations), nil
+}
+
+// the player's state for an achievement, created the first time it's needed
+func (profile *Profile) Achievement(id string) *AchievementState {
+	if profile.Achievements == nil {
+		profile.Achievements = make(map[string]*AchievementState)
+	}
+	state, ok := profile.Achievements[id]
+	if !ok {
+		state = &AchievementState{}
+		profile.Achievements[id] = state
+	}
+	return state
+}
+
+// move achievements along for an event and announce the ones that unlock,
+// the command that published the event saves the profile
+func (session *Session) trackAchievements(event GameEvent) {
+	for _, achievement := range achievements {
+		state := session.profile.Achievement(achievement.ID)
+		if state.UnlockedAt != nil {
+			continue
+		}
+		before := state.Progress
+		achievement.Track(state, event)
+		if state.Progress == before {
+			continue
+		}
+		goal, err := session.client.AchievementGoal(achievement)
+		// without the goal try again on the next event
+		if err != nil || state.Progress < goal {
+			continue
+		}
+		now := time.Now()
+		state.UnlockedAt = &now
+		fmt.Println("🏆 Achievement unlocked:", achievement.Name, "-", achievement.Description)
+		unlocked := achievement
+		session.events.Publish(GameEvent{Kind: "achievement", Achievement: &unlocked})
+	}
+}
+
+// list every achievement with the player's progress
+func achievementsCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	unlocked := 0
+	for _, achievement := range achievements {
+		state := session.profile.Achievement(achievement.ID)
+		if state.UnlockedAt != nil {
+			unlocked++
+			fmt.Printf("[x] %s - %s (%s)\n", achievement.Name, achievement.Description, state.UnlockedAt.Format(dateLayout))
+			continue
+		}
+		goal, err := session.client.AchievementGoal(achievement)
+		if err != nil {
+			fmt.Printf("[ ] %s - %s (%d so far)\n", achievement.Name, achievement.Description, state.Progress)
+			continue
+		}
+		fmt.Printf("[ ] %s - %s (%d/%d)\n", achievement.Name, achievement.Description, state.Progress, goal)
+	}
+	fmt.Printf("%d of %d unlocked\n", unlocked, len(achievements))
+	return nil
+}
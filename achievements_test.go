@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAchievementsFromEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "kanto", "locations": [{"name": "pallet-town"}, {"name": "viridian-forest"}, {"name": "cerulean-cave"}]}`)
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	session := &Session{client: client, profile: NewProfile(), events: NewEventBus()}
+	session.events.Subscribe(session.trackAchievements)
+
+	for i := 0; i < 10; i++ {
+		session.events.Publish(GameEvent{Kind: "catch", Caught: &CaughtPokemon{Shiny: i == 3}})
+	}
+	for _, id := range []string{"first-catch", "catch-10", "shiny"} {
+		if session.profile.Achievement(id).UnlockedAt == nil {
+			t.Errorf("expected %s to be unlocked", id)
+		}
+	}
+	if state := session.profile.Achievement("catch-100"); state.UnlockedAt != nil || state.Progress != 10 {
+		t.Errorf("expected catch-100 at 10, got %+v", state)
+	}
+
+	// exploring the same place twice counts once
+	for _, location := range []string{"pallet-town", "pallet-town", "cerulean-cave"} {
+		session.events.Publish(GameEvent{Kind: "explore", Location: location, Region: "kanto"})
+	}
+	if state := session.profile.Achievement("explore-kanto"); state.Progress != 2 || state.UnlockedAt != nil {
+		t.Errorf("expected 2 kanto locations and no unlock, got %+v", state)
+	}
+	session.events.Publish(GameEvent{Kind: "explore", Location: "viridian-forest", Region: "kanto"})
+	if session.profile.Achievement("explore-kanto").UnlockedAt == nil {
+		t.Errorf("expected explore-kanto to be unlocked")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// picks what a computer controlled side does each turn
+type BattleAI func(battle *Battle, side int) BattleAction
+
+// the strategies by difficulty: easy picks moves at random, normal goes for the most damage,
+// hard also thinks about types and switches out of bad matchups
+var battleAIs = map[string]BattleAI{
+	"easy":   randomAction,
+	"normal": greedyAction,
+	"hard":   smartAction,
+}
+
+// difficulty names from easiest to hardest
+var difficulties = []string{"easy", "normal", "hard"}
+
+func findBattleAI(difficulty string) (BattleAI, error) {
+	ai, ok := battleAIs[strings.ToLower(difficulty)]
+	if !ok {
+		return nil, fmt.Errorf("unknown difficulty %q, choose one of: %s", difficulty, strings.Join(difficulties, ", "))
+	}
+	return ai, nil
+}
+
+// the damage a move can be expected to do, counting misses and an average damage roll
+func expectedDamage(battle *Battle, attacker, defender *Battler, move *BattleMove) float64 {
+	if move.Power == 0 || (move.MaxPP > 0 && move.PP <= 0) {
+		return 0
+	}
+	damage := float64(calculateDamage(attacker, defender, move, battle.Chart, battle.Weather, battle.Terrain, 92).Damage)
+	if move.Accuracy > 0 {
+		damage *= float64(move.Accuracy) / 100
+	}
+	return damage
+}
+
+// the attacker's most damaging move against the defender and how much it's expected to do, -1 when it has to struggle
+func bestMove(battle *Battle, attacker, defender *Battler) (int, float64) {
+	if !attacker.CanMove() {
+		return -1, expectedDamage(battle, attacker, defender, &struggle)
+	}
+	best, bestDamage := -1, -1.0
+	for i, move := range attacker.Moves {
+		if move.PP <= 0 {
+			continue
+		}
+		if damage := expectedDamage(battle, attacker, defender, move); damage > bestDamage {
+			best, bestDamage = i, damage
+		}
+	}
+	return best, bestDamage
+}
+
+// always use the move that does the most damage
+func greedyAction(battle *Battle, side int) BattleAction {
+	attacker := battle.Sides[side].Current()
+	defender := battle.Sides[1-side].Current()
+	move, _ := bestMove(battle, attacker, defender)
+	if move < 0 {
+		return BattleAction{Kind: "move"}
+	}
+	return BattleAction{Kind: "move", Move: move}
+}
+
+// how good a battler is against a defender, the share of the defender's hp it takes each turn
+// against the share of its own hp the defender takes
+func matchup(battle *Battle, battler, defender *Battler) float64 {
+	_, dealt := bestMove(battle, battler, defender)
+	_, taken := bestMove(battle, defender, battler)
+	return dealt/float64(max1(defender.HP)) - taken/float64(max1(battler.HP))
+}
+
+// go for damage like greedy, but switch to a teammate that matches up much better against the defender
+func smartAction(battle *Battle, side int) BattleAction {
+	team := battle.Sides[side]
+	defender := battle.Sides[1-side].Current()
+	current := matchup(battle, team.Current(), defender)
+	best, bestScore := -1, current
+	for i, battler := range team.Team {
+		if i == team.Active || battler.Fainted() {
+			continue
+		}
+		// switching costs a turn, so only a clearly better matchup is worth it
+		if score := matchup(battle, battler, defender); score > bestScore+0.25 {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 && current < 0 {
+		return BattleAction{Kind: "switch", Switch: best}
+	}
+	return greedyAction(battle, side)
+}
+
+// the ai for a battle, --difficulty beats the configured difficulty
+func battleAIFor(flags map[string][]string, configured string) (BattleAI, error) {
+	if difficulty, ok := flagValue(flags, "difficulty"); ok {
+		return findBattleAI(difficulty)
+	}
+	return findBattleAI(configured)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBattleAIs(t *testing.T) {
+	chart := TypeChart{"water": {"fire": 2, "water": 0.5}, "fire": {"water": 0.5, "grass": 2}}
+	ember := &BattleMove{Name: "ember", Type: "fire", Class: "special", Power: 40, Accuracy: 100, PP: 25, MaxPP: 25}
+	watergun := &BattleMove{Name: "water-gun", Type: "water", Class: "special", Power: 40, Accuracy: 100, PP: 25, MaxPP: 25}
+
+	charmander := testBattler("charmander", 10, 65, tackle(), ember)
+	charmander.Types = []string{"fire"}
+	squirtle := testBattler("squirtle", 10, 43, tackle(), watergun)
+	squirtle.Types = []string{"water"}
+	psyduck := testBattler("psyduck", 10, 55, tackle(), watergun)
+	psyduck.Types = []string{"water"}
+
+	player := &BattleSide{Name: "Ash", Team: []*Battler{squirtle}}
+	opponent := &BattleSide{Name: "Misty", Team: []*Battler{charmander, psyduck}}
+	battle := NewBattle(player, opponent, false, rand.New(rand.NewSource(1)))
+	battle.Chart = chart
+
+	// ember is not very effective on squirtle so tackle does more
+	if action := greedyAction(battle, opponentSide); action.Kind != "move" || action.Move != 0 {
+		t.Errorf("expected greedy to pick tackle, got %+v", action)
+	}
+	if action := greedyAction(battle, playerSide); action.Move != 1 {
+		t.Errorf("expected greedy to pick water gun, got %+v", action)
+	}
+	// charmander is in trouble against squirtle, psyduck isn't
+	action := smartAction(battle, opponentSide)
+	if action.Kind != "switch" || action.Switch != 1 {
+		t.Fatalf("expected hard to switch to psyduck, got %+v", action)
+	}
+
+	battle.Step([2]BattleAction{{Kind: "move", Move: 1}, action})
+	if opponent.Current() != psyduck || charmander.HP != charmander.Stats["hp"] {
+		t.Errorf("expected psyduck to come in and take the hit, got %s with charmander at %d hp", opponent.Current().Name, charmander.HP)
+	}
+	if psyduck.HP == psyduck.Stats["hp"] {
+		t.Errorf("expected psyduck to be hit by water gun")
+	}
+	if action := smartAction(battle, opponentSide); action.Kind != "move" {
+		t.Errorf("expected hard not to switch back out of an even matchup, got %+v", action)
+	}
+	if _, err := findBattleAI("impossible"); err == nil {
+		t.Errorf("expected an unknown difficulty to be an error")
+	}
+}
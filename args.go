@@ -0,0 +1,50 @@
+package main
+
+import "strings"
+
+// split command params into positional arguments and --flags
+// flags listed in boolFlags never take a value, every other flag takes the next param or uses --flag=value
+// a flag can be repeated, every value is kept in order
+func parseFlags(params []string, boolFlags ...string) ([]string, map[string][]string) {
+	positional := []string{}
+	flags := make(map[string][]string)
+
+	for i := 0; i < len(params); i++ {
+		param := params[i]
+		if !strings.HasPrefix(param, "--") || len(param) == 2 {
+			positional = append(positional, param)
+			continue
+		}
+
+		name := strings.TrimPrefix(param, "--")
+		if eq := strings.Index(name, "="); eq >= 0 {
+			flags[name[:eq]] = append(flags[name[:eq]], name[eq+1:])
+			continue
+		}
+
+		isBool := false
+		for _, boolFlag := range boolFlags {
+			if name == boolFlag {
+				isBool = true
+				break
+			}
+		}
+		if isBool || i+1 >= len(params) {
+			flags[name] = append(flags[name], "")
+			continue
+		}
+		flags[name] = append(flags[name], params[i+1])
+		i++
+	}
+
+	return positional, flags
+}
+
+// last value given for a flag, and whether the flag was given at all
+func flagValue(flags map[string][]string, name string) (string, bool) {
+	values, ok := flags[name]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestParseFlags(t *testing.T) {
+	params, flags := parseFlags([]string{"pallet-town", "--details", "--type", "water", "--type=flying"}, "details")
+	if len(params) != 1 || params[0] != "pallet-town" {
+		t.Errorf("expected one positional param, got %v", params)
+	}
+	if _, ok := flags["details"]; !ok {
+		t.Errorf("expected details flag")
+	}
+	if len(flags["type"]) != 2 || flags["type"][0] != "water" || flags["type"][1] != "flying" {
+		t.Errorf("expected both type values, got %v", flags["type"])
+	}
+}
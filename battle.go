@@ -0,0 +1,542 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// a pokemon knows at most this many moves
+const maxMoves = 4
+
+// where a battle is: ongoing until one side has no pokemon standing, the player gets away or catches the wild pokemon
+const (
+	battleOngoing = "ongoing"
+	battleWon     = "won"
+	battleLost    = "lost"
+	battleFled    = "fled"
+	battleCaught  = "caught"
+)
+
+// the sides of a battle, the player is always first
+const (
+	playerSide   = 0
+	opponentSide = 1
+)
+
+// a move as the battle engine uses it
+type BattleMove struct {
+	Name string
+	Type string
+	// "physical", "special" or "status"
+	Class string
+	// 0 for moves that don't deal damage directly
+	Power int
+	// 0 for moves that never miss
+	Accuracy int
+	Priority int
+	PP       int
+	MaxPP    int
+	// the status condition the move causes, "" for none, and the percent chance for damaging moves
+	Condition       string
+	ConditionChance int
+	// stages the move changes by stat, the percent chance for damaging moves and whether they're the user's
+	StatChanges  map[string]int
+	StatChance   int
+	StagesOnUser bool
+}
+
+// used when a pokemon has no move with pp left, it hurts the user too
+var struggle = BattleMove{Name: "struggle", Type: "typeless", Class: "physical", Power: 50}
+
+func newBattleMove(move Move) *BattleMove {
+	battleMove := &BattleMove{
+		Name:     move.Name,
+		Type:     move.Type.Name,
+		Class:    move.Damage_class.Name,
+		Priority: move.Priority,
+		PP:       move.Pp,
+		MaxPP:    move.Pp,
+	}
+	if move.Power != nil {
+		battleMove.Power = *move.Power
+	}
+	if move.Accuracy != nil {
+		battleMove.Accuracy = *move.Accuracy
+	}
+	if battleMove.Condition = move.Condition(); battleMove.Condition != "" {
+		battleMove.ConditionChance = move.Meta.Ailment_chance
+	}
+	if len(move.Stat_changes) > 0 {
+		battleMove.StatChanges = make(map[string]int)
+		for _, change := range move.Stat_changes {
+			battleMove.StatChanges[change.Stat.Name] = change.Change
+		}
+		if battleMove.Power == 0 {
+			battleMove.StagesOnUser = strings.HasPrefix(move.Target.Name, "user")
+		} else if move.Meta != nil {
+			battleMove.StatChance = move.Meta.Stat_chance
+			battleMove.StagesOnUser = move.Meta.Category.Name == "damage+raise"
+		}
+	}
+	return battleMove
+}
+
+// a pokemon taking part in a battle
+type Battler struct {
+	Name  string
+	Level int
+	Types []string
+	// actual stats at the battler's level, "hp" is the max hp
+	Stats map[string]int
+	HP    int
+	Moves []*BattleMove
+	// the player's pokemon this is, nil for wild pokemon, kept out of json since it only means something locally
+	Caught *CaughtPokemon `json:"-"`
+	// effort values and base experience the battler gives whoever defeats it
+	Effort         map[string]int
+	BaseExperience int
+	// the opponents this battler knocked out
+	Defeated []*Battler `json:"-"`
+	// the held item, "" for none or once a berry is eaten
+	Item string
+	// only weather and terrain abilities, and levitate keeping it off the ground, do anything in battle
+	Ability string
+	// "burn", "paralysis", "sleep", "poison", "freeze" or "" for none
+	Condition string
+	// turns left asleep
+	sleepTurns int
+	// stat stages from -6 to +6 by stat, nil until a move changes one
+	Stages map[string]int
+	// the move a choice item locks the battler into until it switches out
+	choiceMove *BattleMove
+}
+
+func (battler *Battler) Fainted() bool {
+	return battler.HP <= 0
+}
+
+// whether any move has pp left, without one the battler struggles
+func (battler *Battler) CanMove() bool {
+	for _, move := range battler.Moves {
+		if move.PP > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// "pikachu lv.12 HP 30/35", with the condition like "PAR" and stages like "[+2 Atk]" if it has them
+func (battler *Battler) Status() string {
+	status := fmt.Sprintf("%s lv.%d HP %d/%d", battler.Name, battler.Level, battler.HP, battler.Stats["hp"])
+	if battler.Condition != "" {
+		status += " " + statusAbbreviations[battler.Condition]
+	}
+	if stages := battler.StagesText(); stages != "" {
+		status += " [" + stages + "]"
+	}
+	return status
+}
+
+// one side of a battle, the pokemon fight one at a time in team order
+type BattleSide struct {
+	// "Ash" or "wild pidgey"
+	Name   string
+	Team   []*Battler
+	Active int
+}
+
+func (side *BattleSide) Current() *Battler {
+	return side.Team[side.Active]
+}
+
+// the first pokemon still standing, false if the side is beaten
+func (side *BattleSide) nextStanding() (int, bool) {
+	for i, battler := range side.Team {
+		if !battler.Fainted() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// what a side does on its turn
+type BattleAction struct {
+	// "move", "switch", "flee" or "catch"
+	Kind string
+	// index into the active battler's moves, ignored when it has to struggle
+	Move int
+	// index into the team of the pokemon to switch to
+	Switch int
+}
+
+// something that happened in a battle, the engine reports everything this way and never prints
+type BattleEvent struct {
+	Turn int `json:"turn"`
+	// "start", "move", "miss", "damage", "effectiveness", "explain", "no-effect", "faint", "heal", "switch", "ability", "weather", "terrain", "status", "stage", "throw", "catch", "flee", "forfeit" or "end"
+	Kind   string `json:"kind"`
+	Actor  string `json:"actor,omitempty"`
+	Target string `json:"target,omitempty"`
+	Move   string `json:"move,omitempty"`
+	Damage int    `json:"damage,omitempty"`
+	// the hp the target has left after damage
+	HP      int    `json:"hp,omitempty"`
+	Message string `json:"message"`
+}
+
+// a battle between the player and an opponent, driven one turn at a time by Step
+type Battle struct {
+	Sides [2]*BattleSide
+	// wild battles can be run from
+	Wild bool
+	// type matchups for the moves in the battle, nil treats every matchup as 1x
+	Chart TypeChart
+	// report the damage math with every hit
+	Explain bool
+	State   string
+	Turn    int
+	// "rain", "sun", "sandstorm" or "" for clear skies, and the turns until it clears
+	Weather      string
+	WeatherTurns int
+	// "electric", "grassy", "misty", "psychic" or "" for none, and the turns until it goes away
+	Terrain      string
+	TerrainTurns int
+	// how many times the player has tried to run, every try makes getting away more likely
+	FleeAttempts int
+	// every event so far
+	Events []BattleEvent
+	// called with each event as it happens, nil to only keep them in Events
+	OnEvent func(BattleEvent)
+	// throws a ball at the wild pokemon when the player tries to catch it, true if it was caught, nil when catching isn't allowed
+	Throw func(*Battler) bool
+	rand  *rand.Rand
+}
+
+// set up a battle, the random source decides hits, ties and damage so a seeded one replays the same battle
+func NewBattle(player, opponent *BattleSide, wild bool, random *rand.Rand) *Battle {
+	return &Battle{Sides: [2]*BattleSide{player, opponent}, Wild: wild, State: battleOngoing, rand: random}
+}
+
+func (battle *Battle) emit(event BattleEvent) {
+	event.Turn = battle.Turn
+	battle.Events = append(battle.Events, event)
+	if battle.OnEvent != nil {
+		battle.OnEvent(event)
+	}
+}
+
+// announce the pokemon that open the battle
+func (battle *Battle) Start() {
+	player, opponent := battle.Sides[playerSide], battle.Sides[opponentSide]
+	if battle.Wild {
+		battle.emit(BattleEvent{Kind: "start", Actor: opponent.Current().Name, Message: fmt.Sprintf("A wild %s appeared!", opponent.Current().Name)})
+	} else {
+		battle.emit(BattleEvent{Kind: "start", Actor: opponent.Name, Message: fmt.Sprintf("%s wants to battle!", opponent.Name)})
+		battle.emit(BattleEvent{Kind: "switch", Actor: opponent.Current().Name, Message: fmt.Sprintf("%s sent out %s", opponent.Name, opponent.Current().Name)})
+	}
+	battle.emit(BattleEvent{Kind: "switch", Actor: player.Current().Name, Message: fmt.Sprintf("Go, %s!", player.Current().Name)})
+	battle.enter(player.Current())
+	battle.enter(opponent.Current())
+}
+
+// whether the battle is over
+func (battle *Battle) Over() bool {
+	return battle.State != battleOngoing
+}
+
+// the move a battler uses for an action, struggle once it's out of pp
+func (battler *Battler) moveFor(action BattleAction) *BattleMove {
+	if !battler.CanMove() {
+		move := struggle
+		return &move
+	}
+	if battler.choiceMove != nil && battler.choiceMove.PP > 0 {
+		return battler.choiceMove
+	}
+	if action.Move < 0 || action.Move >= len(battler.Moves) || battler.Moves[action.Move].PP <= 0 {
+		// an action that can't be used falls back to the first usable move
+		for _, move := range battler.Moves {
+			if move.PP > 0 {
+				return move
+			}
+		}
+	}
+	return battler.Moves[action.Move]
+}
+
+// throw a ball at the wild pokemon, ending the battle if it's caught
+func (battle *Battle) throwBall() bool {
+	target := battle.Sides[opponentSide].Current()
+	player := battle.Sides[playerSide].Name
+	battle.emit(BattleEvent{Kind: "throw", Actor: player, Target: target.Name, Message: fmt.Sprintf("%s threw a ball at %s!", player, target.Name)})
+	if !battle.Throw(target) {
+		battle.emit(BattleEvent{Kind: "throw", Target: target.Name, Message: fmt.Sprintf("%s broke free!", target.Name)})
+		return false
+	}
+	battle.emit(BattleEvent{Kind: "catch", Actor: player, Target: target.Name, Message: fmt.Sprintf("Gotcha! %s was caught!", target.Name)})
+	battle.end(battleCaught)
+	return true
+}
+
+// the chance the player gets away from a wild battle with the next try, certain when their pokemon is at least as fast
+// and better with every try, like in the games
+func (battle *Battle) EscapeChance() float64 {
+	speed, wildSpeed := battle.Sides[playerSide].Current().Speed(), battle.Sides[opponentSide].Current().Speed()
+	if speed >= wildSpeed {
+		return 1
+	}
+	odds := float64(speed*128/wildSpeed+30*(battle.FleeAttempts+1)) / 256
+	if odds > 1 {
+		return 1
+	}
+	return odds
+}
+
+// try to run from a wild battle, true if the player got away
+func (battle *Battle) flee() bool {
+	player := battle.Sides[playerSide].Current().Name
+	escaped := battle.rand.Float64() < battle.EscapeChance()
+	battle.FleeAttempts++
+	if !escaped {
+		battle.emit(BattleEvent{Kind: "flee", Actor: player, Message: "Can't escape!"})
+		return false
+	}
+	battle.emit(BattleEvent{Kind: "flee", Actor: player, Message: "Got away safely!"})
+	battle.end(battleFled)
+	return true
+}
+
+// play one turn with an action for each side, faster pokemon and higher priority moves go first
+func (battle *Battle) Step(actions [2]BattleAction) {
+	if battle.Over() {
+		return
+	}
+	battle.Turn++
+
+	// failing to get away takes the player's turn
+	if actions[playerSide].Kind == "flee" {
+		if !battle.Wild {
+			battle.emit(BattleEvent{Kind: "no-effect", Message: "There's no running from a trainer battle!"})
+			actions[playerSide] = BattleAction{Kind: "move"}
+		} else if battle.flee() {
+			return
+		}
+	}
+	// throwing a ball takes the player's turn, the wild pokemon still gets to move if it breaks free
+	if actions[playerSide].Kind == "catch" {
+		if !battle.Wild || battle.Throw == nil {
+			battle.emit(BattleEvent{Kind: "no-effect", Message: "You can't catch this pokemon!"})
+			actions[playerSide] = BattleAction{Kind: "move"}
+		} else if battle.throwBall() {
+			return
+		}
+	}
+
+	for _, entry := range scheduleTurn(battle.turnEntries(actions), battle.rand.Shuffle) {
+		if entry.Action.Kind == "switch" {
+			battle.switchTo(entry.Side, entry.Action.Switch)
+			continue
+		}
+		// a pokemon that fainted or was replaced this turn doesn't get to act
+		if entry.Battler.Fainted() || entry.Battler != battle.Sides[entry.Side].Current() {
+			continue
+		}
+		battle.useMove(entry.Side, entry.Battler, entry.Move)
+		if battle.Over() {
+			return
+		}
+	}
+	battle.endOfTurn()
+}
+
+// what happens once both sides have moved: the weather, the terrain, status conditions, then held items
+func (battle *Battle) endOfTurn() {
+	battle.weatherEndOfTurn()
+	if battle.Over() {
+		return
+	}
+	battle.terrainEndOfTurn()
+	battle.statusEndOfTurn()
+	if battle.Over() {
+		return
+	}
+	battle.heldItemsEndOfTurn()
+}
+
+// whether the pokemon at index on the side's team can come in
+func (battle *Battle) canSwitch(side int, index int) bool {
+	team := battle.Sides[side]
+	return index >= 0 && index < len(team.Team) && index != team.Active && !team.Team[index].Fainted()
+}
+
+// send in another pokemon from the side's team, false if it can't come in
+func (battle *Battle) switchTo(side int, index int) bool {
+	if !battle.canSwitch(side, index) {
+		return false
+	}
+	team := battle.Sides[side]
+	withdrawn := team.Current()
+	withdrawn.choiceMove = nil
+	withdrawn.Stages = nil
+	team.Active = index
+	message := fmt.Sprintf("Come back, %s! Go, %s!", withdrawn.Name, team.Current().Name)
+	if side != playerSide {
+		message = fmt.Sprintf("%s withdrew %s and sent out %s", team.Name, withdrawn.Name, team.Current().Name)
+	}
+	battle.emit(BattleEvent{Kind: "switch", Actor: team.Current().Name, Message: message})
+	battle.enter(team.Current())
+	return true
+}
+
+// the attacker on side uses move on the other side's active pokemon
+func (battle *Battle) useMove(side int, attacker *Battler, move *BattleMove) {
+	defenderSide := battle.Sides[1-side]
+	defender := defenderSide.Current()
+	if !battle.canAct(attacker) {
+		return
+	}
+	if move.MaxPP > 0 {
+		move.PP--
+	}
+	if isChoiceItem(attacker.Item) && attacker.choiceMove == nil && move.MaxPP > 0 {
+		attacker.choiceMove = move
+	}
+	battle.emit(BattleEvent{Kind: "move", Actor: attacker.Name, Target: defender.Name, Move: move.Name,
+		Message: fmt.Sprintf("%s used %s!", attacker.Name, move.Name)})
+
+	if battle.terrainBlocks(move, defender) {
+		battle.emit(BattleEvent{Kind: "no-effect", Actor: attacker.Name, Target: defender.Name, Move: move.Name,
+			Message: fmt.Sprintf("%s is protected by the psychic terrain!", defender.Name)})
+		return
+	}
+	if move.Accuracy > 0 && battle.rand.Intn(100) >= hitChance(move, attacker, defender) {
+		battle.emit(BattleEvent{Kind: "miss", Actor: attacker.Name, Target: defender.Name, Move: move.Name,
+			Message: fmt.Sprintf("%s's attack missed!", attacker.Name)})
+		return
+	}
+	if weather, ok := weatherMoves[move.Name]; ok {
+		battle.setWeather(weather, attacker.Name)
+		return
+	}
+	if terrain, ok := terrainMoves[move.Name]; ok {
+		battle.setTerrain(terrain, attacker.Name)
+		return
+	}
+	if move.Power == 0 && (move.Condition != "" || len(move.StatChanges) > 0) {
+		battle.moveCondition(move, defender)
+		battle.moveStages(move, attacker, defender)
+		return
+	}
+	if move.Power == 0 {
+		battle.emit(BattleEvent{Kind: "no-effect", Actor: attacker.Name, Move: move.Name, Message: "But nothing happened!"})
+		return
+	}
+
+	calc := calculateDamage(attacker, defender, move, battle.Chart, battle.Weather, battle.Terrain, 85+battle.rand.Intn(16))
+	if battle.Explain {
+		battle.emit(BattleEvent{Kind: "explain", Actor: attacker.Name, Target: defender.Name, Move: move.Name, Message: "  " + calc.Explain()})
+	}
+	if calc.Effectiveness == 0 {
+		battle.emit(BattleEvent{Kind: "no-effect", Actor: attacker.Name, Target: defender.Name, Move: move.Name,
+			Message: fmt.Sprintf("It doesn't affect %s...", defender.Name)})
+		return
+	}
+	battle.hurt(defenderSide, defender, calc.Damage, move.Name)
+	if defender.Fainted() {
+		attacker.Defeated = append(attacker.Defeated, defender)
+	}
+	if calc.Effectiveness > 1 {
+		battle.emit(BattleEvent{Kind: "effectiveness", Target: defender.Name, Move: move.Name, Message: "It's super effective!"})
+	} else if calc.Effectiveness < 1 {
+		battle.emit(BattleEvent{Kind: "effectiveness", Target: defender.Name, Move: move.Name, Message: "It's not very effective..."})
+	}
+	if !defender.Fainted() {
+		battle.moveCondition(move, defender)
+	}
+	if !battle.Over() {
+		battle.moveStages(move, attacker, defender)
+	}
+	if move.Name == struggle.Name && !battle.Over() {
+		battle.hurt(battle.Sides[side], attacker, max1(attacker.Stats["hp"]/4), "recoil")
+	}
+}
+
+// take hp from a battler, sending in the next pokemon or ending the battle if it faints
+func (battle *Battle) hurt(side *BattleSide, battler *Battler, damage int, cause string) {
+	if damage > battler.HP {
+		damage = battler.HP
+	}
+	battler.HP -= damage
+	battle.emit(BattleEvent{Kind: "damage", Target: battler.Name, Move: cause, Damage: damage, HP: battler.HP,
+		Message: fmt.Sprintf("%s took %d damage (%d/%d HP left)", battler.Name, damage, battler.HP, battler.Stats["hp"])})
+	if !battler.Fainted() {
+		battle.eatBerry(battler)
+		return
+	}
+
+	battle.emit(BattleEvent{Kind: "faint", Target: battler.Name, Message: fmt.Sprintf("%s fainted!", battler.Name)})
+	next, ok := side.nextStanding()
+	if !ok {
+		if side == battle.Sides[playerSide] {
+			battle.end(battleLost)
+		} else {
+			battle.end(battleWon)
+		}
+		return
+	}
+	side.Active = next
+	message := fmt.Sprintf("Go, %s!", side.Current().Name)
+	if side != battle.Sides[playerSide] {
+		message = fmt.Sprintf("%s sent out %s", side.Name, side.Current().Name)
+	}
+	battle.emit(BattleEvent{Kind: "switch", Actor: side.Current().Name, Message: message})
+	battle.enter(side.Current())
+}
+
+// a side gives up and the other side wins
+func (battle *Battle) Forfeit(side int) {
+	if battle.Over() {
+		return
+	}
+	name := battle.Sides[side].Name
+	battle.emit(BattleEvent{Kind: "forfeit", Actor: name, Message: fmt.Sprintf("%s forfeited!", name)})
+	if side == playerSide {
+		battle.end(battleLost)
+	} else {
+		battle.end(battleWon)
+	}
+}
+
+func (battle *Battle) end(state string) {
+	battle.State = state
+	messages := map[string]string{
+		battleWon:    "You won the battle!",
+		battleLost:   "You have no pokemon left that can fight...",
+		battleFled:   "The battle is over.",
+		battleCaught: "The battle is over.",
+	}
+	battle.emit(BattleEvent{Kind: "end", Message: messages[state]})
+}
+
+// at least 1, so small pokemon still take recoil
+func max1(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// a wild pokemon picks any move it still has pp for
+func randomAction(battle *Battle, side int) BattleAction {
+	battler := battle.Sides[side].Current()
+	usable := []int{}
+	for i, move := range battler.Moves {
+		if move.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return BattleAction{Kind: "move"}
+	}
+	return BattleAction{Kind: "move", Move: usable[battle.rand.Intn(len(usable))]}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func testBattler(name string, level int, speed int, moves ...*BattleMove) *Battler {
+	stats := map[string]int{"hp": 30, "attack": 20, "defense": 20, "special-attack": 20, "special-defense": 20, "speed": speed}
+	return &Battler{Name: name, Level: level, Stats: stats, HP: stats["hp"], Moves: moves}
+}
+
+func tackle() *BattleMove {
+	return &BattleMove{Name: "tackle", Type: "normal", Class: "physical", Power: 40, Accuracy: 100, PP: 35, MaxPP: 35}
+}
+
+func TestBattleSpeedOrderAndWin(t *testing.T) {
+	fast := testBattler("pikachu", 20, 90, tackle())
+	slow := testBattler("geodude", 20, 20, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{fast}}, &BattleSide{Name: "wild geodude", Team: []*Battler{slow}}, true, rand.New(rand.NewSource(1)))
+
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	moves := []string{}
+	for _, event := range battle.Events {
+		if event.Kind == "move" {
+			moves = append(moves, event.Actor)
+		}
+	}
+	if strings.Join(moves, ",") != "pikachu,geodude" {
+		t.Errorf("expected the faster pokemon to move first, got %v", moves)
+	}
+	if fast.Moves[0].PP != 34 {
+		t.Errorf("expected a pp to be used, got %d", fast.Moves[0].PP)
+	}
+
+	for turns := 0; !battle.Over() && turns < 20; turns++ {
+		battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	}
+	// with equal stats and level the faster pokemon lands the last hit first
+	if battle.State != battleWon || !slow.Fainted() || fast.Fainted() {
+		t.Errorf("expected pikachu to win, got %v with %d and %d hp", battle.State, fast.HP, slow.HP)
+	}
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if last := battle.Events[len(battle.Events)-1]; last.Kind != "end" {
+		t.Errorf("expected nothing to happen after the battle ended, got %+v", last)
+	}
+}
+
+func TestBattleSendsInNextPokemonAndFlees(t *testing.T) {
+	weak := testBattler("magikarp", 5, 80)
+	weak.HP = 1
+	backup := testBattler("pidgey", 5, 10, tackle())
+	wild := testBattler("rattata", 5, 50, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{weak, backup}}, &BattleSide{Name: "wild rattata", Team: []*Battler{wild}}, true, rand.New(rand.NewSource(1)))
+
+	// magikarp has no moves so it struggles, the recoil knocks it out
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if !weak.Fainted() || battle.Sides[playerSide].Current() != backup || battle.Over() {
+		t.Fatalf("expected pidgey to be sent in, got %s", battle.Sides[playerSide].Current().Name)
+	}
+	for _, event := range battle.Events {
+		if event.Actor == "pidgey" && event.Kind == "move" {
+			t.Errorf("expected pidgey not to act on the turn it was sent in")
+		}
+	}
+
+	// pidgey is slower so it might take a few tries, but every try makes it more likely
+	for i := 0; i < 8 && !battle.Over(); i++ {
+		battle.Step([2]BattleAction{{Kind: "flee"}, {Kind: "move"}})
+	}
+	if battle.State != battleFled {
+		t.Errorf("expected to get away, got %v", battle.State)
+	}
+}
+
+func TestEscapeChance(t *testing.T) {
+	slow := testBattler("slowpoke", 10, 15, tackle())
+	wild := testBattler("ponyta", 10, 90, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{slow}}, &BattleSide{Name: "wild ponyta", Team: []*Battler{wild}}, true, rand.New(rand.NewSource(1)))
+	// 15*128/90 is 21, plus 30 for the first try
+	if chance := battle.EscapeChance(); chance != 51.0/256 {
+		t.Errorf("expected a 51/256 chance, got %v", chance)
+	}
+	battle.FleeAttempts = 7
+	if chance := battle.EscapeChance(); chance != 1 {
+		t.Errorf("expected to always get away after 7 tries, got %v", chance)
+	}
+	battle.FleeAttempts = 0
+	// enough hp to last until getting away is certain
+	slow.Stats["hp"], slow.HP = 200, 200
+	for i := 0; i < 8; i++ {
+		battle.Step([2]BattleAction{{Kind: "flee"}, {Kind: "move"}})
+		if battle.Over() {
+			break
+		}
+		// a failed try takes the turn, only ponyta attacks
+		if slow.HP == slow.Stats["hp"] || wild.HP != wild.Stats["hp"] {
+			t.Fatalf("expected ponyta to attack after a failed escape, got %d and %d hp", slow.HP, wild.HP)
+		}
+	}
+	if battle.State != battleFled {
+		t.Errorf("expected to get away eventually, got %s after %d tries", battle.State, battle.FleeAttempts)
+	}
+
+	faster := testBattler("jolteon", 10, 130, tackle())
+	battle = NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{faster}}, &BattleSide{Name: "wild ponyta", Team: []*Battler{wild}}, true, rand.New(rand.NewSource(1)))
+	if battle.EscapeChance() != 1 {
+		t.Errorf("expected a faster pokemon to always get away")
+	}
+	trainer := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{faster}}, &BattleSide{Name: "Gary", Team: []*Battler{testBattler("eevee", 10, 10, tackle())}}, false, rand.New(rand.NewSource(1)))
+	trainer.Step([2]BattleAction{{Kind: "flee"}, {Kind: "move"}})
+	if trainer.Over() || trainer.Events[0].Message != "There's no running from a trainer battle!" {
+		t.Errorf("expected no running from a trainer, got %s and %+v", trainer.State, trainer.Events[0])
+	}
+}
+
+func TestLevelUpMoves(t *testing.T) {
+	var learnset Learnset
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "thunder"}, "version_group_details": [{"level_learned_at": 50, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "growl"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "thunder-shock"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "quick-attack"}, "version_group_details": [{"level_learned_at": 6, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "thunderbolt"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "machine"}}]},
+		{"move": {"name": "tail-whip"}, "version_group_details": [{"level_learned_at": 3, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "double-team"}, "version_group_details": [{"level_learned_at": 15, "move_learn_method": {"name": "level-up"}}, {"level_learned_at": 8, "move_learn_method": {"name": "level-up"}}]}
+	]}`), &learnset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := learnset.LevelUpMoves(10)
+	if strings.Join(moves, ",") != "thunder-shock,tail-whip,quick-attack,double-team" {
+		t.Errorf("expected the last four level-up moves by level 10, got %v", moves)
+	}
+}
+
+func TestCalculateDamage(t *testing.T) {
+	chart := TypeChart{"electric": {"water": 2, "flying": 2, "ground": 0, "grass": 0.5}}
+	pikachu := testBattler("pikachu", 20, 90)
+	pikachu.Types = []string{"electric"}
+	pikachu.Stats["special-attack"] = 55
+	gyarados := testBattler("gyarados", 20, 80)
+	gyarados.Types = []string{"water", "flying"}
+	gyarados.Stats["special-defense"] = 40
+	thunderbolt := &BattleMove{Name: "thunderbolt", Type: "electric", Class: "special", Power: 90}
+
+	calc := calculateDamage(pikachu, gyarados, thunderbolt, chart, "", "", 100)
+	// (10 * 90 * 55 / 40) / 50 + 2 = 26, then 1.5 stab and 4x type
+	if calc.Base != 26 || calc.STAB != 1.5 || calc.Effectiveness != 4 || calc.Damage != 156 {
+		t.Errorf("unexpected damage: %+v", calc)
+	}
+	if explained := calc.Explain(); explained != "((2×20/5+2)×90×55/40)/50+2 = 26, ×1.5 stab, ×4 type, ×100% roll = 156" {
+		t.Errorf("unexpected explanation: %s", explained)
+	}
+
+	calc = calculateDamage(pikachu, gyarados, thunderbolt, chart, "", "", 85)
+	if calc.Damage != 132 {
+		t.Errorf("expected the lowest roll to do 132, got %d", calc.Damage)
+	}
+	gyarados.Types = []string{"ground"}
+	if calc := calculateDamage(pikachu, gyarados, thunderbolt, chart, "", "", 100); calc.Damage != 0 {
+		t.Errorf("expected no damage to a ground type, got %d", calc.Damage)
+	}
+	if effectiveness := TypeChart(nil).Effectiveness("fire", []string{"water"}); effectiveness != 1 {
+		t.Errorf("expected a nil chart to be neutral, got %v", effectiveness)
+	}
+}
+
+func TestBattleCatch(t *testing.T) {
+	pikachu := testBattler("pikachu", 20, 90, tackle())
+	wild := testBattler("abra", 10, 10, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "wild abra", Team: []*Battler{wild}}, true, rand.New(rand.NewSource(1)))
+	throws := []float64{}
+	battle.Throw = func(target *Battler) bool {
+		throws = append(throws, target.CatchAttempt(190, balls["poke"]).HPFraction)
+		return len(throws) == 2
+	}
+
+	battle.Step([2]BattleAction{{Kind: "catch"}, {Kind: "move"}})
+	if battle.Over() || pikachu.HP == pikachu.Stats["hp"] || wild.HP != wild.Stats["hp"] {
+		t.Fatalf("expected abra to break free and attack, got %s with %d and %d hp", battle.State, pikachu.HP, wild.HP)
+	}
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	battle.Step([2]BattleAction{{Kind: "catch"}, {Kind: "move"}})
+	if battle.State != battleCaught || len(throws) != 2 || throws[1] >= 1 {
+		t.Errorf("expected abra to be caught weakened on the second throw, got %s after %v", battle.State, throws)
+	}
+
+	trainer := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "Gary", Team: []*Battler{testBattler("eevee", 10, 10, tackle())}}, false, rand.New(rand.NewSource(1)))
+	trainer.Step([2]BattleAction{{Kind: "catch"}, {Kind: "move"}})
+	if trainer.Events[0].Message != "You can't catch this pokemon!" || trainer.Events[1].Kind != "move" {
+		t.Errorf("expected a trainer's pokemon not to be catchable, got %+v", trainer.Events[:2])
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fetch moves by name for a battler
+func (client *Client) battleMoves(names []string) ([]*BattleMove, error) {
+	moves := []*BattleMove{}
+	for _, name := range names {
+		move, err := client.GetMove(name)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, newBattleMove(move))
+	}
+	return moves, nil
+}
+
+// a wild pokemon at a level, knowing the moves it would have learned by then
+func (client *Client) NewBattler(pokemon Pokemon, level int) (*Battler, error) {
+	learnset, err := client.GetLearnset(pokemon.Name)
+	if err != nil {
+		return nil, err
+	}
+	moves, err := client.battleMoves(learnset.LevelUpMoves(level))
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int)
+	for _, stat := range statOrder {
+		stats[stat] = statAtLevel(stat, pokemon.BaseStat(stat), level)
+	}
+	return &Battler{Name: pokemon.Name, Level: level, Types: pokemon.TypeList(), Stats: stats, HP: stats["hp"], Moves: moves,
+		Effort: pokemon.EffortYield(), BaseExperience: pokemon.Base_experience, Ability: pokemon.Ability()}, nil
+}
+
+// one of the player's pokemon ready to battle, at full health
+func (client *Client) CaughtBattler(caught *CaughtPokemon) (*Battler, error) {
+	battler, err := client.NewBattler(caught.Pokemon, caught.Level)
+	if err != nil {
+		return nil, err
+	}
+	if len(caught.Moves) > 0 {
+		battler.Moves, err = client.battleMoves(caught.Moves)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nature := Nature{}
+	if caught.Nature != "" {
+		nature, err = client.GetNature(caught.Nature)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, stat := range statOrder {
+		battler.Stats[stat] = caught.Stat(stat, nature)
+	}
+	battler.HP = battler.Stats["hp"]
+	battler.Name = caught.DisplayName()
+	battler.Caught = caught
+	battler.Item = caught.Item
+	return battler, nil
+}
+
+// the player's side, made of the party pokemon that can still fight
+func (session *Session) playerSide() (*BattleSide, error) {
+	side := &BattleSide{Name: session.profile.Name}
+	for _, caught := range session.pokedex.PartyMembers() {
+		if caught.Dead {
+			continue
+		}
+		battler, err := session.client.CaughtBattler(caught)
+		if err != nil {
+			return nil, err
+		}
+		side.Team = append(side.Team, battler)
+	}
+	if len(side.Team) == 0 {
+		return nil, fmt.Errorf("you have no pokemon in your party that can battle")
+	}
+	return side, nil
+}
+
+func printBattleEvent(event BattleEvent) {
+	fmt.Println(event.Message)
+}
+
+// ask the player what their pokemon does, running away if input ends
+func (session *Session) chooseAction(battle *Battle) BattleAction {
+	run := ""
+	if battle.Wild {
+		run = fmt.Sprintf("run (%.0f%% chance)", 100*battle.EscapeChance())
+	}
+	for {
+		action := session.chooseMove(battle.Sides[playerSide].Current(), battle.Sides[opponentSide].Current(), battle.FieldStatus(), run, battle.Throw != nil)
+		if action.Kind == "catch" && session.inventory.Balls[session.inventory.Selected] <= 0 {
+			fmt.Println("You have no", session.inventory.Selected, "balls left, pick another with ball [name]")
+			continue
+		}
+		return action
+	}
+}
+
+// ask for a move for player against opponent under the weather and terrain in field, run is what "r" is called, "" when the player can't leave,
+// and throw is whether "c" throws a ball
+// ending the input picks "r" anyway, the battle decides what that means
+func (session *Session) chooseMove(player, opponent *Battler, field string, run string, throw bool) BattleAction {
+	if field != "" {
+		fmt.Printf("\n%s  vs  %s  [%s]\n", player.Status(), opponent.Status(), field)
+	} else {
+		fmt.Printf("\n%s  vs  %s\n", player.Status(), opponent.Status())
+	}
+	if !player.CanMove() {
+		fmt.Println(player.Name, "has no moves left and will struggle")
+	} else if player.choiceMove != nil && player.choiceMove.PP > 0 {
+		fmt.Println(player.Name, "is locked into", player.choiceMove.Name, "by its", player.Item)
+	}
+	for i, move := range player.Moves {
+		fmt.Printf("%d. %s (%s, %s, %d/%d pp)\n", i+1, move.Name, move.Type, movePowerText(move), move.PP, move.MaxPP)
+	}
+	if throw {
+		fmt.Printf("c. throw a %s ball (%d left)\n", session.inventory.Selected, session.inventory.Balls[session.inventory.Selected])
+	}
+	if run != "" {
+		fmt.Println("r.", run)
+	}
+
+	for {
+		answer, ok := session.Prompt(fmt.Sprintf("What will %s do?", player.Name))
+		if !ok {
+			return BattleAction{Kind: "flee"}
+		}
+		answer = strings.ToLower(answer)
+		if answer == "r" || answer == "run" || (run != "" && answer == run) {
+			return BattleAction{Kind: "flee"}
+		}
+		if throw && (answer == "c" || answer == "catch") {
+			return BattleAction{Kind: "catch"}
+		}
+		if !player.CanMove() {
+			return BattleAction{Kind: "move"}
+		}
+		for i, move := range player.Moves {
+			if strconv.Itoa(i+1) == answer || move.Name == apiName(strings.Fields(answer)) {
+				if move.PP <= 0 {
+					break
+				}
+				return BattleAction{Kind: "move", Move: i}
+			}
+		}
+		fmt.Println("Choose a move with pp left by number or name")
+	}
+}
+
+func movePowerText(move *BattleMove) string {
+	if move.Power == 0 {
+		return move.Class
+	}
+	return fmt.Sprintf("%d power", move.Power)
+}
+
+// play a battle to the end, the player choosing their moves and the ai the opponent's
+func (session *Session) runBattle(battle *Battle, ai BattleAI, log io.Writer) error {
+	chart, err := session.client.GetTypeChart(battle.MoveTypes())
+	if err != nil {
+		return err
+	}
+	battle.Chart = chart
+	battle.OnEvent = printBattleEvent
+	if log != nil {
+		logBattle(battle, log)
+	}
+	battle.Start()
+	for !battle.Over() {
+		battle.Step([2]BattleAction{session.chooseAction(battle), ai(battle, opponentSide)})
+	}
+	return nil
+}
+
+// what a finished battle leaves behind: effort values and experience, happiness for winning, nuzlocke deaths,
+// a replay and a line in the history
+func (session *Session) finishBattle(battle *Battle, opponent string) error {
+	session.saveReplay(Replay{Player: battle.Sides[playerSide].Name, Opponent: opponent, Outcome: battle.State, Events: battle.Events})
+	for _, battler := range battle.Sides[playerSide].Team {
+		if battler.Caught == nil {
+			continue
+		}
+		experience := 0
+		for _, defeated := range battler.Defeated {
+			if gained := battler.Caught.GainEVs(defeated.Effort); len(gained) > 0 {
+				fmt.Printf("%s gained %s effort from %s\n", battler.Name, formatEVs(gained), defeated.Name)
+			}
+			experience += battleExperience(defeated, !battle.Wild)
+		}
+		// a berry eaten in battle is gone for good
+		if battler.Item != battler.Caught.Item {
+			battler.Caught.Item = battler.Item
+		}
+		// fainted pokemon get no experience, like in the games
+		if battler.Fainted() {
+			session.Faint(battler.Caught)
+			continue
+		}
+		if battle.State == battleWon {
+			battler.Caught.GainHappiness(1)
+		}
+		if experience > 0 {
+			err := session.gainExperience(battler.Caught, experience)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// running from a wild pokemon is an encounter of its own in the history
+	kind := "battle"
+	if battle.State == battleFled && battle.Wild {
+		kind = "flee"
+	}
+	err := session.Record(HistoryEvent{Kind: kind, Pokemon: opponent, Outcome: battle.State})
+	if err != nil {
+		return err
+	}
+	return session.Save()
+}
+
+// battle [pokemon] [--level n] [--difficulty d] [--explain] [--log file] - fight a wild pokemon with your party
+// battle --host [--port n] or battle --connect host:port - fight another player over the network
+func battleCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "explain", "host")
+	_, explain := flags["explain"]
+	log, err := session.openBattleLog(flags)
+	if err != nil {
+		return err
+	}
+	if log != nil {
+		defer log.Close()
+	}
+	if _, ok := flags["host"]; ok {
+		port, ok := flagValue(flags, "port")
+		if !ok {
+			port = defaultPvPPort
+		}
+		return session.hostBattle(port, explain, log)
+	}
+	if address, ok := flagValue(flags, "connect"); ok {
+		return session.joinBattle(address, log)
+	}
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	client := session.client
+	ai, err := battleAIFor(flags, session.config.Game.WildDifficulty)
+	if err != nil {
+		return err
+	}
+
+	pokemon, err := client.ResolvePokemon(params[0])
+	if err != nil {
+		return err
+	}
+	level := 0
+	value, picked := flagValue(flags, "level")
+	if picked {
+		level, err = strconv.Atoi(value)
+		if err != nil || level < 1 || level > maxLevel {
+			return fmt.Errorf("--level takes a level from 1 to %d", maxLevel)
+		}
+	} else {
+		level = client.RollWildLevel(pokemon)
+	}
+
+	player, err := session.playerSide()
+	if err != nil {
+		return err
+	}
+	wild, err := client.NewBattler(pokemon, level)
+	if err != nil {
+		return err
+	}
+	opponent := &BattleSide{Name: "wild " + pokemon.Name, Team: []*Battler{wild}}
+
+	battle := NewBattle(player, opponent, true, rand.New(rand.NewSource(time.Now().UnixNano())))
+	battle.Explain = explain
+	// nuzlocke catches only happen through encounter, and a pokemon at a level picked with --level is only for practice
+	if !session.profile.Nuzlocke.Active() && !picked {
+		battle.Throw = session.battleThrow(pokemon)
+	}
+	err = session.runBattle(battle, ai, log)
+	if err != nil {
+		return err
+	}
+	return session.finishBattle(battle, pokemon.Name)
+}
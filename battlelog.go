@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// one battle event as a line of the battle log, for tools that analyze or visualize battles
+type BattleLogLine struct {
+	// when the battle started, every event of one battle shares it
+	Battle string `json:"battle"`
+	Turn   int    `json:"turn"`
+	Kind   string `json:"kind"`
+	Actor  string `json:"actor,omitempty"`
+	Target string `json:"target,omitempty"`
+	Move   string `json:"move,omitempty"`
+	Damage int    `json:"damage,omitempty"`
+	// the target's hp after damage or healing, kept even when it's 0
+	HP      *int   `json:"hp,omitempty"`
+	Message string `json:"message"`
+}
+
+func newBattleLogLine(battle string, event BattleEvent) BattleLogLine {
+	line := BattleLogLine{Battle: battle, Turn: event.Turn, Kind: event.Kind, Actor: event.Actor, Target: event.Target,
+		Move: event.Move, Damage: event.Damage, Message: event.Message}
+	if event.Kind == "damage" || event.Kind == "heal" {
+		hp := event.HP
+		line.HP = &hp
+	}
+	return line
+}
+
+// the file battles are logged to, from --log or the config, nil when neither asks for one
+func (session *Session) openBattleLog(flags map[string][]string) (io.WriteCloser, error) {
+	path, ok := flagValue(flags, "log")
+	if !ok {
+		path = session.config.Game.BattleLog
+	}
+	if path == "" {
+		return nil, nil
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// write every event from now on to w as a json line, after whatever the battle already does with them
+func logBattle(battle *Battle, w io.Writer) {
+	encoder := json.NewEncoder(w)
+	started := time.Now().Format(time.RFC3339)
+	previous := battle.OnEvent
+	battle.OnEvent = func(event BattleEvent) {
+		if previous != nil {
+			previous(event)
+		}
+		encoder.Encode(newBattleLogLine(started, event))
+	}
+}
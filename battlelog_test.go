@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestLogBattle(t *testing.T) {
+	pikachu := testBattler("pikachu", 50, 90, tackle())
+	weak := testBattler("rattata", 2, 10, tackle())
+	weak.HP = 1
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "wild rattata", Team: []*Battler{weak}}, true, rand.New(rand.NewSource(1)))
+	printed := 0
+	battle.OnEvent = func(BattleEvent) { printed++ }
+	var log bytes.Buffer
+	logBattle(battle, &log)
+
+	battle.Start()
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	lines := []BattleLogLine{}
+	scanner := bufio.NewScanner(&log)
+	for scanner.Scan() {
+		var line BattleLogLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("expected json lines, got %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != len(battle.Events) || printed != len(battle.Events) {
+		t.Fatalf("expected %d lines and events still handled, got %d and %d", len(battle.Events), len(lines), printed)
+	}
+	for _, line := range lines {
+		if line.Battle != lines[0].Battle {
+			t.Errorf("expected every line to share the battle, got %q and %q", line.Battle, lines[0].Battle)
+		}
+		if line.Kind == "damage" {
+			if line.Turn != 1 || line.Target != "rattata" || line.Move != "tackle" || line.HP == nil || *line.HP != 0 {
+				t.Errorf("expected the knockout hit with 0 hp left, got %+v", line)
+			}
+		} else if line.HP != nil {
+			t.Errorf("expected no hp on %s lines, got %d", line.Kind, *line.HP)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Berry struct {
+	Id                 int           `json:"id"`
+	Name               string        `json:"name"`
+	Growth_time        int           `json:"growth_time"`
+	Max_harvest        int           `json:"max_harvest"`
+	Natural_gift_power int           `json:"natural_gift_power"`
+	Natural_gift_type  NamedResource `json:"natural_gift_type"`
+	Size               int           `json:"size"`
+	Smoothness         int           `json:"smoothness"`
+	Soil_dryness       int           `json:"soil_dryness"`
+	Firmness           NamedResource `json:"firmness"`
+	Item               NamedResource `json:"item"`
+	Flavors            []struct {
+		Potency int           `json:"potency"`
+		Flavor  NamedResource `json:"flavor"`
+	} `json:"flavors"`
+}
+
+// fetch a berry by name ("razz", not "razz-berry") or id
+func (client *Client) GetBerry(name string) (Berry, error) {
+	var berry Berry
+	err := client.GetJSON(client.ResourceURL("berry", name), &berry)
+	return berry, err
+}
+
+// show a berry's growth time, firmness, flavors and natural gift type
+func berryCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a berry")
+		return nil
+	}
+
+	berry, err := session.client.GetBerry(berryName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Berry:", berry.Name)
+	fmt.Printf("Growth time: %d hours per stage\n", berry.Growth_time)
+	fmt.Println("Max harvest:", berry.Max_harvest)
+	fmt.Println("Firmness:", berry.Firmness.Name)
+	fmt.Printf("Size: %d mm\n", berry.Size)
+	fmt.Printf("Natural gift: %s type, %d power\n", berry.Natural_gift_type.Name, berry.Natural_gift_power)
+	fmt.Println("Flavors:")
+	for _, flavor := range berry.Flavors {
+		if flavor.Potency > 0 {
+			fmt.Println("-", flavor.Flavor.Name, ":", flavor.Potency)
+		}
+	}
+	return nil
+}
+
+// berries are named without the "-berry" the item name has, accept both
+func berryName(params []string) string {
+	return strings.TrimSuffix(apiName(params), "-berry")
+}
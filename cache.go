@@ -0,0 +1,370 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// in-memory cache of response bodies, bounded by age, entry count and total bytes
+type Cache struct {
+	entries map[string]*list.Element
+	// most recently used entries are at the front, eviction takes from the back
+	order *list.List
+	mutex sync.Mutex
+	disk  *DiskCache
+
+	// how long an entry stays fresh, by key
+	interval  time.Duration
+	ttlPolicy func(key string) time.Duration
+
+	// 0 means unlimited
+	maxEntries int
+	maxBytes   int
+	size       int
+
+	// lookups since the cache was created
+	hits   int
+	misses int
+}
+
+// a snapshot of the cache for the cache stats command
+type CacheStats struct {
+	Entries int
+	Bytes   int
+	Hits    int
+	Misses  int
+	// creation time of the oldest entry, zero when the cache is empty
+	Oldest time.Time
+}
+
+type cacheEntry struct {
+	key       string
+	createdAt time.Time
+	ttl       time.Duration
+	val       []byte
+
+	// validators from the response, used to revalidate the entry once it expires
+	etag         string
+	lastModified string
+	// expired but kept around because it can be revalidated
+	stale bool
+}
+
+// create and return a new cache
+func NewCache(interval time.Duration) *Cache {
+	cache := Cache{
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		interval: interval,
+	}
+
+	// run the old cache cleaner in a goroutine
+	go cache.Reaploop(interval)
+
+	return &cache
+}
+
+// back the cache with a disk layer, entries are written through to disk and read back on a memory miss
+func (cache *Cache) SetDisk(disk *DiskCache) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.disk = disk
+}
+
+// decide the freshness lifetime of each entry by its key instead of using the reap interval for everything
+func (cache *Cache) SetTTLPolicy(policy func(key string) time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.ttlPolicy = policy
+}
+
+// freshness lifetime for key, caller must hold the lock
+func (cache *Cache) ttl(key string) time.Duration {
+	if cache.ttlPolicy != nil {
+		return cache.ttlPolicy(key)
+	}
+	return cache.interval
+}
+
+// cap the cache at maxEntries entries and maxBytes bytes of values, least recently used entries are evicted first
+// a limit of 0 disables that check
+func (cache *Cache) SetLimits(maxEntries, maxBytes int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.maxEntries = maxEntries
+	cache.maxBytes = maxBytes
+	cache.evict()
+}
+
+// add a new (key, value) pair to the cache
+func (cache *Cache) Add(key string, val []byte) {
+	cache.AddValidated(key, val, "", "")
+}
+
+// add a new (key, value) pair along with the ETag and Last-Modified validators of the response
+func (cache *Cache) AddValidated(key string, val []byte, etag, lastModified string) {
+	cache.mutex.Lock()
+	entry := &cacheEntry{
+		key:          key,
+		createdAt:    time.Now(),
+		ttl:          cache.ttl(key),
+		val:          val,
+		etag:         etag,
+		lastModified: lastModified,
+	}
+	cache.add(entry)
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	// the disk layer is best effort, a failed write just means a re-download next session
+	if disk != nil {
+		disk.AddValidated(key, val, etag, lastModified, entry.ttl)
+	}
+}
+
+// insert or replace an entry and mark it most recently used, caller must hold the lock
+func (cache *Cache) add(entry *cacheEntry) {
+	if elem, ok := cache.entries[entry.key]; ok {
+		cache.remove(elem)
+	}
+	cache.entries[entry.key] = cache.order.PushFront(entry)
+	cache.size += len(entry.val)
+	cache.evict()
+}
+
+// drop an entry, caller must hold the lock
+func (cache *Cache) remove(elem *list.Element) {
+	entry := cache.order.Remove(elem).(*cacheEntry)
+	delete(cache.entries, entry.key)
+	cache.size -= len(entry.val)
+}
+
+// drop least recently used entries until the cache fits its limits, caller must hold the lock
+func (cache *Cache) evict() {
+	for cache.order.Len() > 0 {
+		overEntries := cache.maxEntries > 0 && cache.order.Len() > cache.maxEntries
+		overBytes := cache.maxBytes > 0 && cache.size > cache.maxBytes
+		if !overEntries && !overBytes {
+			return
+		}
+		cache.remove(cache.order.Back())
+	}
+}
+
+// (key, value) = (url to query, response body)
+// returns the value and a boolean indicating if the key was found
+func (cache *Cache) Get(key string) ([]byte, bool) {
+	// use locks to make map access thread safe
+	cache.mutex.Lock()
+	elem, ok := cache.entries[key]
+	if ok {
+		entry := elem.Value.(*cacheEntry)
+		// a stale entry has to be revalidated before it can be used again
+		if entry.stale || time.Since(entry.createdAt) > entry.ttl {
+			cache.misses++
+			cache.mutex.Unlock()
+			return nil, false
+		}
+		cache.order.MoveToFront(elem)
+		cache.hits++
+		cache.mutex.Unlock()
+		return entry.val, true
+	}
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	// fall back to the disk layer and keep the entry in memory for next time
+	if disk != nil {
+		diskEntry, fresh, ok := disk.lookup(key)
+		if ok && fresh {
+			cache.mutex.Lock()
+			cache.add(&cacheEntry{
+				key:          key,
+				createdAt:    diskEntry.CreatedAt,
+				ttl:          cache.ttl(key),
+				val:          diskEntry.Val,
+				etag:         diskEntry.ETag,
+				lastModified: diskEntry.LastModified,
+			})
+			cache.hits++
+			cache.mutex.Unlock()
+			return diskEntry.Val, true
+		}
+	}
+
+	cache.mutex.Lock()
+	cache.misses++
+	cache.mutex.Unlock()
+	return nil, false
+}
+
+// whether key has a fresh entry in memory or on disk, unlike Get it doesn't count as a hit or miss
+func (cache *Cache) Contains(key string) bool {
+	cache.mutex.Lock()
+	elem, ok := cache.entries[key]
+	if ok {
+		entry := elem.Value.(*cacheEntry)
+		fresh := !entry.stale && time.Since(entry.createdAt) <= entry.ttl
+		cache.mutex.Unlock()
+		return fresh
+	}
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	if disk != nil {
+		_, fresh, ok := disk.lookup(key)
+		return ok && fresh
+	}
+	return false
+}
+
+// whatever is cached under key in memory or on disk, fresh or expired, without counting as a hit or miss
+func (cache *Cache) Peek(key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	elem, ok := cache.entries[key]
+	if ok {
+		val := elem.Value.(*cacheEntry).val
+		cache.mutex.Unlock()
+		return val, true
+	}
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	if disk != nil {
+		diskEntry, _, found := disk.lookup(key)
+		if found {
+			return diskEntry.Val, true
+		}
+	}
+	return nil, false
+}
+
+// returns an expired entry that can still be revalidated, along with its ETag and Last-Modified validators
+func (cache *Cache) Stale(key string) (val []byte, etag string, lastModified string, ok bool) {
+	cache.mutex.Lock()
+	elem, found := cache.entries[key]
+	if found {
+		entry := elem.Value.(*cacheEntry)
+		cache.mutex.Unlock()
+		if entry.etag == "" && entry.lastModified == "" {
+			return nil, "", "", false
+		}
+		return entry.val, entry.etag, entry.lastModified, true
+	}
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	if disk != nil {
+		diskEntry, _, found := disk.lookup(key)
+		if found && (diskEntry.ETag != "" || diskEntry.LastModified != "") {
+			return diskEntry.Val, diskEntry.ETag, diskEntry.LastModified, true
+		}
+	}
+	return nil, "", "", false
+}
+
+// the server confirmed a stale entry is unchanged (304), make it fresh again in memory and on disk
+func (cache *Cache) Revalidated(key string) {
+	val, etag, lastModified, ok := cache.Stale(key)
+	if !ok {
+		return
+	}
+	cache.AddValidated(key, val, etag, lastModified)
+}
+
+// drop key from memory and disk, returns whether it was cached anywhere
+func (cache *Cache) Remove(key string) bool {
+	cache.mutex.Lock()
+	elem, found := cache.entries[key]
+	if found {
+		cache.remove(elem)
+	}
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	if disk != nil && disk.Remove(key) {
+		found = true
+	}
+	return found
+}
+
+// drop every entry from memory and disk
+func (cache *Cache) Clear() error {
+	cache.mutex.Lock()
+	cache.entries = make(map[string]*list.Element)
+	cache.order.Init()
+	cache.size = 0
+	disk := cache.disk
+	cache.mutex.Unlock()
+
+	if disk != nil {
+		return disk.Clear()
+	}
+	return nil
+}
+
+// entry count, size, hit/miss counters and oldest entry of the in-memory cache
+func (cache *Cache) Stats() CacheStats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	stats := CacheStats{
+		Entries: cache.order.Len(),
+		Bytes:   cache.size,
+		Hits:    cache.hits,
+		Misses:  cache.misses,
+	}
+	for _, elem := range cache.entries {
+		createdAt := elem.Value.(*cacheEntry).createdAt
+		if stats.Oldest.IsZero() || createdAt.Before(stats.Oldest) {
+			stats.Oldest = createdAt
+		}
+	}
+	return stats
+}
+
+// the disk layer backing the cache, nil if there is none
+func (cache *Cache) Disk() *DiskCache {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.disk
+}
+
+// number of entries and total bytes currently held in memory
+func (cache *Cache) Len() (int, int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len(), cache.size
+}
+
+// called whenever NewCache is called, each time an interval passes, remove all entries in the cache that are older than their ttl
+func (cache *Cache) Reaploop(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		cache.mutex.Lock()
+
+		// list of entries to delete
+		toDelete := []*list.Element{}
+
+		for _, elem := range cache.entries {
+			entry := elem.Value.(*cacheEntry)
+			if time.Since(entry.createdAt) <= entry.ttl {
+				continue
+			}
+			// entries with validators stay around so they can be revalidated cheaply
+			if entry.etag != "" || entry.lastModified != "" {
+				entry.stale = true
+			} else {
+				toDelete = append(toDelete, elem)
+			}
+		}
+
+		for _, elem := range toDelete {
+			cache.remove(elem)
+		}
+
+		cache.mutex.Unlock()
+	}
+}
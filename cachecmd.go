@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// cache [stats|clear|rm] - inspect and invalidate the response cache
+func cacheCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a cache command: stats, clear, rm")
+		return nil
+	}
+
+	switch params[0] {
+	case "stats":
+		return cacheStatsCommand(session)
+	case "clear":
+		err := session.client.cache.Clear()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Cache cleared")
+		return nil
+	case "rm":
+		if len(params) < 2 {
+			fmt.Println("Please enter a url or pokemon")
+			return nil
+		}
+		return cacheRemoveCommand(session, params[1])
+	default:
+		return fmt.Errorf("unknown cache command %q", params[0])
+	}
+}
+
+// show entry count, size, hit/miss ratio and the age of the oldest entry
+func cacheStatsCommand(session *Session) error {
+	cache := session.client.cache
+	stats := cache.Stats()
+
+	fmt.Println("Cache stats:")
+	fmt.Println("Entries:", stats.Entries)
+	fmt.Println("Total bytes:", stats.Bytes)
+	lookups := stats.Hits + stats.Misses
+	if lookups == 0 {
+		fmt.Println("Hits: 0, misses: 0")
+	} else {
+		fmt.Printf("Hits: %d, misses: %d (%.1f%% hit ratio)\n", stats.Hits, stats.Misses, 100*float64(stats.Hits)/float64(lookups))
+	}
+	if stats.Oldest.IsZero() {
+		fmt.Println("Oldest entry: none")
+	} else {
+		fmt.Println("Oldest entry:", time.Since(stats.Oldest).Round(time.Second), "old")
+	}
+
+	disk := cache.Disk()
+	if disk != nil {
+		entries, size, err := disk.Stats()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Disk: %d entries, %d bytes in %s\n", entries, size, disk.dir)
+	}
+	return nil
+}
+
+// drop a url, or everything cached about a pokemon, so the next lookup downloads it again
+func cacheRemoveCommand(session *Session, target string) error {
+	keys := []string{target}
+	if !strings.Contains(target, "://") {
+		keys = []string{
+			session.client.ResourceURL("pokemon", target),
+			session.client.ResourceURL("pokemon-species", target),
+		}
+	}
+
+	removed := 0
+	for _, key := range keys {
+		if session.client.Invalidate(key) {
+			removed++
+		}
+	}
+	if removed == 0 {
+		fmt.Println("Nothing cached for", target)
+		return nil
+	}
+	fmt.Println("Removed", target, "from the cache")
+	return nil
+}
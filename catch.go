@@ -0,0 +1,360 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// how much easier a status condition makes a catch
+var statusCatchBonus = map[string]float64{
+	"sleep":     2.5,
+	"freeze":    2.5,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+// one ball thrown at one pokemon, with everything the games' catch formula looks at
+type CatchAttempt struct {
+	// from the species, from 3 for most legendaries up to 255 for the easiest pokemon
+	CaptureRate int
+	Ball        Ball
+	// current hp over max hp, 1 for a pokemon at full health
+	HPFraction float64
+	// "" for none, otherwise a key of statusCatchBonus
+	Status string
+	// multiplier from a berry fed before throwing, 0 for no berry
+	BerryBonus float64
+	// multiplier from catching the same species in a row, 0 for no chain
+	ChainBonus float64
+	// how many species the player has caught, a fuller pokedex makes critical captures likelier
+	SpeciesCaught int
+}
+
+// the catch rate after hp, ball and status, 255 or more is a sure catch
+func (attempt CatchAttempt) modifiedRate() float64 {
+	hp := attempt.HPFraction
+	if hp <= 0 || hp > 1 {
+		hp = 1
+	}
+	rate := (3 - 2*hp) / 3 * float64(attempt.CaptureRate) * attempt.Ball.Multiplier
+	if bonus, ok := statusCatchBonus[attempt.Status]; ok {
+		rate *= bonus
+	}
+	if attempt.BerryBonus > 0 {
+		rate *= attempt.BerryBonus
+	}
+	if attempt.ChainBonus > 0 {
+		rate *= attempt.ChainBonus
+	}
+	return rate
+}
+
+// a shake succeeds when a random number below 65536 is under this, four shakes and it's caught
+func (attempt CatchAttempt) shakeThreshold() float64 {
+	rate := attempt.modifiedRate()
+	if attempt.Ball.Guaranteed || rate >= 255 {
+		return 65536
+	}
+	if rate <= 0 {
+		return 0
+	}
+	return 1048560 / math.Sqrt(math.Sqrt(16711680/rate))
+}
+
+// chance that the ball holds
+func (attempt CatchAttempt) Probability() float64 {
+	return math.Pow(math.Min(attempt.shakeThreshold()/65536, 1), 4)
+}
+
+// how much the number of species caught scales critical captures, the thresholds from the gen 5 games
+func criticalMultiplier(speciesCaught int) float64 {
+	switch {
+	case speciesCaught > 600:
+		return 2.5
+	case speciesCaught > 450:
+		return 2
+	case speciesCaught > 300:
+		return 1.5
+	case speciesCaught > 150:
+		return 1
+	case speciesCaught > 30:
+		return 0.5
+	}
+	return 0
+}
+
+// chance that a throw is a critical capture, which needs only one shake to hold
+func (attempt CatchAttempt) CriticalChance() float64 {
+	if attempt.Ball.Guaranteed {
+		return 0
+	}
+	rate := math.Min(attempt.modifiedRate(), 255)
+	return math.Floor(rate*criticalMultiplier(attempt.SpeciesCaught)/6) / 256
+}
+
+// throw the ball, returns how many times it shook, whether the pokemon was caught and whether it was a critical capture
+func (attempt CatchAttempt) Throw() (int, bool, bool) {
+	threshold := attempt.shakeThreshold()
+	if rand.Float64() < attempt.CriticalChance() {
+		if float64(rand.Intn(65536)) >= threshold {
+			return 0, false, true
+		}
+		return 1, true, true
+	}
+	for shakes := 0; shakes < 4; shakes++ {
+		if float64(rand.Intn(65536)) >= threshold {
+			return shakes, false, false
+		}
+	}
+	return 4, true, false
+}
+
+// chance a pokemon runs away after breaking out of a ball, pokemon that are hard to catch are also quick to flee
+func fleeChance(captureRate int) float64 {
+	return 0.1 + 0.25*(1-float64(captureRate)/255)
+}
+
+// how the player goes about a catch
+type CatchOptions struct {
+	Ball Ball
+	// "" for no berry, otherwise a key of catchBerries
+	Berry string
+	// levels the pokemon can be met at here, nil to use every place it's found
+	Levels []levelRange
+}
+
+// read --ball and --berry, printing what's wrong and returning false if they can't be used
+func (session *Session) catchOptions(flags map[string][]string) (CatchOptions, bool) {
+	options := CatchOptions{}
+	ballName := session.inventory.Selected
+	if name, ok := flagValue(flags, "ball"); ok {
+		ballName = name
+	}
+	ball, ok := findBall(ballName)
+	if !ok {
+		fmt.Println("Unknown ball, choose one of:", strings.Join(ballNames(), ", "))
+		return options, false
+	}
+	if session.inventory.Balls[ball.Name] <= 0 {
+		fmt.Println("You have no", ball.Name, "balls left")
+		return options, false
+	}
+	options.Ball = ball
+
+	if berry, ok := flagValue(flags, "berry"); ok {
+		berry = berryName([]string{berry})
+		if _, ok := catchBerries[berry]; !ok {
+			fmt.Println("Only razz, silver-pinap and golden-razz berries help with catching")
+			return options, false
+		}
+		if session.inventory.Berries[berry] <= 0 {
+			fmt.Println("You have no", berry, "berries left")
+			return options, false
+		}
+		options.Berry = berry
+	}
+	return options, true
+}
+
+// the odds of a ball thrown at a wild pokemon of species at full hp, with the bonuses from the catch chain and the pokedex
+func (session *Session) catchAttempt(species PokemonSpecies, ball Ball) CatchAttempt {
+	return CatchAttempt{
+		CaptureRate: species.Capture_rate,
+		Ball:        ball,
+		HPFraction:  1,
+		ChainBonus:  session.profile.Chain.CatchBonus(species.Name),
+		// the pokemon being caught isn't counted yet, the games do the same
+		SpeciesCaught: len(session.pokedex.SpeciesCaught()),
+	}
+}
+
+// try to catch a wild pokemon, throwing balls until it's caught, runs away, the balls run out or the player gives up
+func (session *Session) Catch(pokemon Pokemon, options CatchOptions) error {
+	client := session.client
+	ball := options.Ball
+
+	// the catch formula uses the species' capture rate, rarer pokemon have lower ones
+	species, err := client.GetSpecies(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	chain := &session.profile.Chain
+	attempt := session.catchAttempt(species, ball)
+	if length := chain.LengthFor(species.Name); length > 0 {
+		fmt.Printf("Catch chain of %d %s, catches are %.0f%% easier\n", length, species.Name, 100*(attempt.ChainBonus-1))
+	}
+	// one berry lasts the whole encounter
+	if options.Berry != "" {
+		err = session.inventory.UseBerry(options.Berry)
+		if err != nil {
+			return err
+		}
+		attempt.BerryBonus = catchBerries[options.Berry]
+		fmt.Printf("You fed %s a %s berry\n", pokemon.Name, options.Berry)
+	}
+
+	throws := 0
+	caughtIt := false
+	for {
+		// the ball is used up whether the catch works or not
+		record, success, err := session.throwBall(pokemon, species, attempt)
+		if err != nil {
+			fmt.Println("You're out of", ball.Name, "balls,", pokemon.Name, "got away")
+			break
+		}
+		printThrow(pokemon, species, record)
+		throws++
+		if success {
+			level := 0
+			if options.Levels != nil {
+				level = rollLevel(options.Levels)
+			} else {
+				level = client.RollWildLevel(pokemon)
+			}
+			session.keepCatch(pokemon, species, level, throws, session.printCatch)
+			caughtIt = true
+			record.Outcome = "caught"
+			session.recordThrow(record)
+			break
+		}
+
+		fmt.Println(pokemon.Name, "broke free!")
+		record.Outcome = "broke free"
+		fled := rand.Float64() < fleeChance(species.Capture_rate)
+		if fled {
+			record.Outcome = "fled"
+		}
+		session.recordThrow(record)
+		if fled {
+			fmt.Println(pokemon.Name, "fled!")
+			break
+		}
+		if session.inventory.Balls[ball.Name] <= 0 {
+			fmt.Println("You're out of", ball.Name, "balls,", pokemon.Name, "got away")
+			break
+		}
+		if !session.Confirm(fmt.Sprintf("Throw another %s ball? (%d left)", ball.Name, session.inventory.Balls[ball.Name])) {
+			fmt.Println("You left", pokemon.Name, "alone")
+			break
+		}
+	}
+	fmt.Printf("%s balls left: %d\n", ball.Name, session.inventory.Balls[ball.Name])
+	if !caughtIt && chain.Length > 0 {
+		fmt.Println("Your catch chain of", chain.Length, chain.Species, "is broken")
+		chain.Break()
+	}
+
+	return session.Save()
+}
+
+// throw one ball from the bag, the history record it returns is missing the outcome
+// an error means there was no ball to throw
+func (session *Session) throwBall(pokemon Pokemon, species PokemonSpecies, attempt CatchAttempt) (HistoryEvent, bool, error) {
+	ball := attempt.Ball
+	err := session.inventory.UseBall(ball)
+	if err != nil {
+		return HistoryEvent{}, false, err
+	}
+	session.profile.CatchAttempts++
+
+	shakes, success, critical := attempt.Throw()
+	record := HistoryEvent{Kind: "catch", Pokemon: pokemon.Name, Ball: ball.Name, Chance: attempt.Probability(), Shakes: shakes, Critical: critical}
+	return record, success, nil
+}
+
+// tell the player how a throw from throwBall went: the odds, a critical capture and the shakes
+func printThrow(pokemon Pokemon, species PokemonSpecies, record HistoryEvent) {
+	fmt.Printf("Throwing a %s ball at %s (capture rate %d), chance of success %.1f%%\n",
+		record.Ball, pokemon.Name, species.Capture_rate, 100*record.Chance)
+	if record.Critical {
+		fmt.Println("A critical capture!")
+	}
+	for i := 0; i < record.Shakes && i < 3; i++ {
+		fmt.Println("...the ball shakes")
+	}
+}
+
+// tell the player about a pokemon keepCatch just put in the pokedex, and whether it joined the party
+func (session *Session) printCatch(caught *CaughtPokemon, inParty bool) {
+	if caught.Shiny {
+		fmt.Println(shinyMarker, "It's shiny!", shinyMarker)
+	}
+	fmt.Printf("You caught %s (level %d) after %d throws\n", caught.Name, caught.Level, caught.Throws)
+	if !inParty {
+		fmt.Println("Your party is full,", caught.Name, "was sent to the pc")
+	}
+	if count := session.pokedex.Count(caught.Name); count > 1 {
+		fmt.Printf("That's %s #%d, you now have %d of them\n", caught.Name, caught.InstanceID, count)
+	}
+}
+
+// put a pokemon that was just caught in the pokedex, it may turn out shiny
+// announce hears about it before anything else does, the api has nothing to announce to and passes nil
+func (session *Session) keepCatch(pokemon Pokemon, species PokemonSpecies, level int, throws int, announce func(caught *CaughtPokemon, inParty bool)) *CaughtPokemon {
+	chain := &session.profile.Chain
+	caught := &CaughtPokemon{
+		Pokemon:   pokemon,
+		Form:      pokemon.FormName(),
+		Level:     level,
+		Throws:    throws,
+		IVs:       rollIVs(),
+		Happiness: species.StartingHappiness(),
+		CaughtOn:  time.Now().Format(dateLayout),
+	}
+	caught.Shiny = rand.Float64() < session.config.Game.ShinyChance*chain.ShinyBonus(species.Name)
+	inParty := session.pokedex.Add(caught)
+	if announce != nil {
+		announce(caught, inParty)
+	}
+	session.profile.Catches++
+	chain.Caught(species.Name)
+	session.events.Publish(GameEvent{Kind: "catch", Caught: caught})
+	return caught
+}
+
+// a throw of the selected ball from the battle menu at the wild pokemon the player is fighting:
+// its hp and status condition make it easier to catch, and the battler's level is the level it's caught at
+func (session *Session) battleThrow(pokemon Pokemon) func(*Battler) bool {
+	throws := 0
+	return func(target *Battler) bool {
+		ball, ok := findBall(session.inventory.Selected)
+		if !ok {
+			fmt.Println("Choose a ball to throw with ball [name]")
+			return false
+		}
+		species, err := session.client.GetSpecies(pokemon.Species.Name)
+		if err != nil {
+			fmt.Println("Couldn't look up", pokemon.Name+":", err)
+			return false
+		}
+		attempt := target.CatchAttempt(species.Capture_rate, ball)
+		attempt.ChainBonus = session.profile.Chain.CatchBonus(species.Name)
+		attempt.SpeciesCaught = len(session.pokedex.SpeciesCaught())
+		record, success, err := session.throwBall(pokemon, species, attempt)
+		if err != nil {
+			fmt.Println("You have no", ball.Name, "balls left")
+			return false
+		}
+		printThrow(pokemon, species, record)
+		throws++
+		record.Outcome = "broke free"
+		if success {
+			session.keepCatch(pokemon, species, target.Level, throws, session.printCatch)
+			record.Outcome = "caught"
+		}
+		session.recordThrow(record)
+		return success
+	}
+}
+
+// add a throw to the history, a history that can't be written shouldn't cost the player a catch
+func (session *Session) recordThrow(record HistoryEvent) {
+	err := session.Record(record)
+	if err != nil {
+		fmt.Println("Couldn't record the throw in your history:", err)
+	}
+}
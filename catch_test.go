@@ -0,0 +1,79 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCatchProbability(t *testing.T) {
+	// pikachu, capture rate 190
+	cases := []struct {
+		attempt  CatchAttempt
+		expected float64
+	}{
+		// full hp divides the rate by 3, each shake then passes about 71% of the time
+		{CatchAttempt{CaptureRate: 190, Ball: balls["poke"], HPFraction: 1}, 0.2484},
+		{CatchAttempt{CaptureRate: 190, Ball: balls["ultra"], HPFraction: 1}, 0.4967},
+		{CatchAttempt{CaptureRate: 3, Ball: balls["master"], HPFraction: 1}, 1},
+		// at a tenth of its hp an ultra ball makes a capture rate of 190 a sure catch
+		{CatchAttempt{CaptureRate: 190, Ball: balls["ultra"], HPFraction: 0.1}, 1},
+	}
+	for _, c := range cases {
+		if p := c.attempt.Probability(); math.Abs(p-c.expected) > 0.001 {
+			t.Errorf("%+v: expected %.4f, got %.4f", c.attempt, c.expected, p)
+		}
+	}
+
+	asleep := CatchAttempt{CaptureRate: 45, Ball: balls["poke"], HPFraction: 1, Status: "sleep"}
+	awake := CatchAttempt{CaptureRate: 45, Ball: balls["poke"], HPFraction: 1}
+	if asleep.Probability() <= awake.Probability() {
+		t.Errorf("expected a sleeping pokemon to be easier to catch")
+	}
+}
+
+func TestThrowGuaranteed(t *testing.T) {
+	attempt := CatchAttempt{CaptureRate: 3, Ball: balls["master"], HPFraction: 1}
+	for i := 0; i < 10; i++ {
+		if shakes, caught, _ := attempt.Throw(); !caught || shakes != 4 {
+			t.Fatalf("expected a master ball to always catch, got %d shakes", shakes)
+		}
+	}
+}
+
+func TestFleeChance(t *testing.T) {
+	if easy, hard := fleeChance(255), fleeChance(3); easy >= hard {
+		t.Errorf("expected hard to catch pokemon to flee more, got %v and %v", easy, hard)
+	}
+	if chance := fleeChance(255); chance != 0.1 {
+		t.Errorf("expected 0.1 for the easiest pokemon, got %v", chance)
+	}
+}
+
+func TestBerryBonus(t *testing.T) {
+	plain := CatchAttempt{CaptureRate: 45, Ball: balls["great"], HPFraction: 1}
+	razz := plain
+	razz.BerryBonus = catchBerries["razz"]
+	if razz.modifiedRate() != plain.modifiedRate()*1.5 {
+		t.Errorf("expected a razz berry to multiply the rate by 1.5, got %v and %v", plain.modifiedRate(), razz.modifiedRate())
+	}
+}
+
+func TestCriticalChance(t *testing.T) {
+	attempt := CatchAttempt{CaptureRate: 45, Ball: balls["poke"], HPFraction: 1}
+	if chance := attempt.CriticalChance(); chance != 0 {
+		t.Errorf("expected no critical captures with an almost empty pokedex, got %v", chance)
+	}
+	previous := 0.0
+	for _, caught := range []int{31, 151, 301, 451, 601} {
+		attempt.SpeciesCaught = caught
+		chance := attempt.CriticalChance()
+		if chance <= previous {
+			t.Errorf("%d species: expected the chance to grow past %v, got %v", caught, previous, chance)
+		}
+		previous = chance
+	}
+	// 15 * 2.5 / 6 = 6.25, floored
+	if previous != 6.0/256 {
+		t.Errorf("expected 6/256 with a full pokedex, got %v", previous)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+// consecutive catches of one species, the longer it gets the easier catches and shinies become
+type CatchChain struct {
+	Species string `json:"species"`
+	Length  int    `json:"length"`
+}
+
+// longest chain that still adds to the bonuses
+const maxChainBonus = 30
+
+// the chain length that counts toward a catch of species, 0 if the chain is of something else
+func (chain CatchChain) LengthFor(species string) int {
+	if chain.Species != species {
+		return 0
+	}
+	if chain.Length > maxChainBonus {
+		return maxChainBonus
+	}
+	return chain.Length
+}
+
+// catch rate multiplier, up to 2x at a chain of 10
+func (chain CatchChain) CatchBonus(species string) float64 {
+	length := chain.LengthFor(species)
+	if length > 10 {
+		length = 10
+	}
+	return 1 + 0.1*float64(length)
+}
+
+// shiny chance multiplier, up to 16x at a chain of 30
+func (chain CatchChain) ShinyBonus(species string) float64 {
+	return 1 + 0.5*float64(chain.LengthFor(species))
+}
+
+// a catch of species extends the chain, or starts a new one if it's something else
+func (chain *CatchChain) Caught(species string) {
+	if chain.Species != species {
+		chain.Species = species
+		chain.Length = 0
+	}
+	chain.Length++
+}
+
+// a pokemon getting away ends the chain
+func (chain *CatchChain) Break() {
+	chain.Species = ""
+	chain.Length = 0
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestCatchChain(t *testing.T) {
+	chain := CatchChain{}
+	if chain.CatchBonus("pikachu") != 1 || chain.ShinyBonus("pikachu") != 1 {
+		t.Errorf("expected no bonus without a chain")
+	}
+
+	for i := 0; i < 3; i++ {
+		chain.Caught("pikachu")
+	}
+	if chain.Length != 3 {
+		t.Errorf("expected a chain of 3, got %d", chain.Length)
+	}
+	if bonus := chain.CatchBonus("pikachu"); bonus != 1.3 {
+		t.Errorf("expected a 1.3x catch bonus, got %v", bonus)
+	}
+	if bonus := chain.CatchBonus("eevee"); bonus != 1 {
+		t.Errorf("expected no bonus for another species, got %v", bonus)
+	}
+
+	chain.Caught("eevee")
+	if chain.Species != "eevee" || chain.Length != 1 {
+		t.Errorf("expected a new chain of eevee, got %+v", chain)
+	}
+
+	chain.Length = 100
+	if bonus := chain.ShinyBonus("eevee"); bonus != 16 {
+		t.Errorf("expected the shiny bonus to stop at 16x, got %v", bonus)
+	}
+	if bonus := chain.CatchBonus("eevee"); bonus != 2 {
+		t.Errorf("expected the catch bonus to stop at 2x, got %v", bonus)
+	}
+
+	chain.Break()
+	if chain.Length != 0 {
+		t.Errorf("expected a broken chain, got %+v", chain)
+	}
+}
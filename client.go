@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// the public PokeAPI
+const defaultBaseURL = "https://pokeapi.co/api/v2"
+
+// Client talks to the PokeAPI, every request goes through the cache first
+type Client struct {
+	cache      *Cache
+	httpClient *http.Client
+	// every url the client builds starts with this
+	baseURL string
+	// requests for the same url in flight at the same time share one network call
+	flights flightGroup
+	metrics APIMetrics
+}
+
+// create a new api client backed by cache
+func NewClient(cache *Cache) *Client {
+	return &Client{
+		cache:      cache,
+		httpClient: &http.Client{},
+		baseURL:    defaultBaseURL,
+	}
+}
+
+// point the client at another PokeAPI, like a local mirror
+func (client *Client) SetBaseURL(baseURL string) {
+	client.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// url of a single resource, like ResourceURL("pokemon", "pikachu")
+func (client *Client) ResourceURL(resource string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", client.baseURL, resource, neturl.PathEscape(name))
+}
+
+// url of one page of a list endpoint, like ListURL("location-area", 0, 20)
+func (client *Client) ListURL(resource string, offset int, limit int) string {
+	return fmt.Sprintf("%s/%s/?offset=%d&limit=%d", client.baseURL, resource, offset, limit)
+}
+
+// route requests through transport instead of the default one
+func (client *Client) SetTransport(transport http.RoundTripper) {
+	client.httpClient = &http.Client{Transport: transport}
+}
+
+// build the transport for the api client from the http config
+// proxies come from HTTP_PROXY/HTTPS_PROXY/NO_PROXY unless the config names one
+func NewTransport(config HTTPConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if config.Proxy != "" {
+		proxyURL, err := neturl.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", config.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CAFile != "" || config.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		}
+		if config.CAFile != "" {
+			pem, err := os.ReadFile(config.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			roots, err := x509.SystemCertPool()
+			if err != nil {
+				roots = x509.NewCertPool()
+			}
+			if !roots.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %s", config.CAFile)
+			}
+			tlsConfig.RootCAs = roots
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// return the response body for url, from the cache if it's fresh
+// a stale cache entry is revalidated with a conditional GET so an unchanged resource only costs a 304
+func (client *Client) Get(url string) ([]byte, error) {
+	return client.GetContext(context.Background(), url)
+}
+
+// same as Get, but the request is abandoned when ctx is cancelled
+func (client *Client) GetContext(ctx context.Context, url string) ([]byte, error) {
+	// every command asks for the same resource the same way, so it is only ever fetched and cached once
+	url = canonicalURL(url)
+	return client.getCached(ctx, url, endpointName(client.baseURL, url))
+}
+
+// download a file that isn't an api resource, like a sprite or a cry
+// the url is used as is, file hosts care about case and trailing slashes where the api doesn't
+func (client *Client) GetAsset(url string) ([]byte, error) {
+	return client.getCached(context.Background(), url, "assets")
+}
+
+// return the body for url from the cache, or fetch it once no matter how many callers ask at the same time
+func (client *Client) getCached(ctx context.Context, url string, endpoint string) ([]byte, error) {
+	val, ok := client.cache.Get(url)
+	client.metrics.CacheLookup(endpoint, ok)
+	if ok {
+		return val, nil
+	}
+
+	val, err, shared := client.flights.Do(url, func() ([]byte, error) {
+		return client.fetch(ctx, url, endpoint)
+	})
+	// the request we joined belonged to someone who gave up on it (a cancelled prefetch), so make our own
+	if shared && errors.Is(err, context.Canceled) && ctx.Err() == nil {
+		return client.fetch(ctx, url, endpoint)
+	}
+	return val, err
+}
+
+// make the network request for url, revalidating a stale cache entry if there is one
+// endpoint is what the request is counted under in the metrics
+func (client *Client) fetch(ctx context.Context, url string, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// asking explicitly turns off the transport's transparent gzip handling, so decodeBody does it instead
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	staleVal, etag, lastModified, stale := client.cache.Stale(url)
+	if stale {
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	start := time.Now()
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	counter := &countingReader{reader: resp.Body}
+	resp.Body = io.NopCloser(counter)
+	defer func() {
+		client.metrics.Request(endpoint, counter.count, time.Since(start))
+	}()
+
+	if stale && resp.StatusCode == http.StatusNotModified {
+		client.cache.Revalidated(url)
+		return staleVal, nil
+	}
+
+	// anything but a 200 would decode into zero values, so report it instead
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, URL: url}
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	client.cache.AddValidated(url, body, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	for _, alias := range aliasURLs(url, body) {
+		client.cache.AddValidated(alias, body, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	}
+	return body, nil
+}
+
+// whether a fresh response for url is cached, without counting as a lookup
+func (client *Client) Cached(url string) bool {
+	return client.cache.Contains(canonicalURL(url))
+}
+
+// forget everything cached for url, including the id/name alias, returns whether anything was cached
+func (client *Client) Invalidate(url string) bool {
+	url = canonicalURL(url)
+	removed := false
+	if body, ok := client.cache.Peek(url); ok {
+		for _, alias := range aliasURLs(url, body) {
+			if client.cache.Remove(alias) {
+				removed = true
+			}
+		}
+	}
+	if client.cache.Remove(url) {
+		removed = true
+	}
+	return removed
+}
+
+// the form every url is cached under: lower case host and path, a trailing slash like the api's own links,
+// and query parameters in sorted order
+func canonicalURL(rawURL string) string {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.ToLower(parsed.Path)
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	parsed.RawPath = ""
+	parsed.RawQuery = parsed.Query().Encode()
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// a single resource can be asked for by name or by id, return the other form so both share the cache entry
+func aliasURLs(url string, body []byte) []string {
+	if resourceClass(url) != "static" {
+		return nil
+	}
+	var resource struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if json.Unmarshal(body, &resource) != nil || resource.Id == 0 || resource.Name == "" {
+		return nil
+	}
+
+	// swap the last path segment for the id and for the name
+	trimmed := strings.TrimSuffix(url, "/")
+	slash := strings.LastIndex(trimmed, "/")
+	if slash < 0 {
+		return nil
+	}
+	aliases := []string{}
+	for _, key := range []string{strconv.Itoa(resource.Id), resource.Name} {
+		alias := canonicalURL(trimmed[:slash+1] + key)
+		if alias != url {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// fetch url into the cache in the background so a later Get is instant
+func (client *Client) Prefetch(jobs *JobManager, url string) {
+	if url == "" {
+		return
+	}
+	jobs.Go(func(ctx context.Context) {
+		// errors don't matter here, the foreground request will retry and report them
+		client.GetContext(ctx, url)
+	})
+}
+
+// how many pokemon are fetched at once when a command needs details for a whole list
+const detailWorkers = 8
+
+// fetch many pokemon by name using a bounded pool of workers
+// results and errors line up with names
+func (client *Client) GetPokemonBatch(names []string, workers int) ([]Pokemon, []error) {
+	results := make([]Pokemon, len(names))
+	errs := make([]error, len(names))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = client.GetPokemon(names[i])
+			}
+		}()
+	}
+
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, errs
+}
+
+// fetch url and decode the json body into v
+func (client *Client) GetJSON(url string, v interface{}) error {
+	_, err := client.getJSONReport(url, v)
+	return err
+}
+
+// like GetJSON, but also returns what was missing from the response
+func (client *Client) getJSONReport(url string, v interface{}) (DecodeReport, error) {
+	body, err := client.Get(url)
+	if err != nil {
+		return DecodeReport{}, err
+	}
+	return decodeJSON(url, body, v)
+}
+
+// fetch a pokemon by name or id
+// if the api has no base_experience for it (some forms don't), one is estimated from the species' capture rate
+// so catching doesn't turn into a sure thing
+func (client *Client) GetPokemon(name string) (Pokemon, error) {
+	var pokemon Pokemon
+	url := client.ResourceURL("pokemon", name)
+	report, err := client.getJSONReport(url, &pokemon)
+	if err != nil {
+		return pokemon, err
+	}
+
+	if report.IsMissing("base_experience") || pokemon.Base_experience <= 0 {
+		var species PokemonSpecies
+		speciesURL := client.ResourceURL("pokemon-species", pokemon.Name)
+		if pokemon.Species.Url != "" {
+			speciesURL = pokemon.Species.Url
+		}
+		err := client.GetJSON(speciesURL, &species)
+		if err == nil {
+			pokemon.Base_experience = baseExperienceFromCaptureRate(species.Capture_rate)
+		}
+	}
+	return pokemon, nil
+}
+
+// map a capture rate (3 hardest .. 255 easiest) onto the base experience range (~300 .. ~50)
+// so the catch difficulty of a pokemon without base_experience is in line with similar pokemon
+func baseExperienceFromCaptureRate(captureRate int) int {
+	if captureRate < 3 {
+		captureRate = 3
+	}
+	if captureRate > 255 {
+		captureRate = 255
+	}
+	return 300 - (captureRate-3)*250/252
+}
+
+// sort a url into a resource class for cache expiry
+// paginated lists like /location-area/?offset=20 are "listing", single resources like /pokemon/pikachu are "static"
+func resourceClass(rawURL string) string {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "static"
+	}
+	query := parsed.Query()
+	if query.Has("offset") || query.Has("limit") {
+		return "listing"
+	}
+	// /api/v2/<resource>/ with no name or id is the list itself
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) <= 3 {
+		return "listing"
+	}
+	return "static"
+}
+
+// read the whole response body, undoing any gzip or deflate content encoding
+func decodeBody(resp *http.Response) ([]byte, error) {
+	reader, err := decodedReader(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// wrap the response body so reads return the decompressed bytes
+func decodedReader(resp *http.Response) (io.ReadCloser, error) {
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+		return io.NopCloser(resp.Body), nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(resp.Body)
+	case "deflate":
+		// deflate is supposed to be zlib wrapped, but some servers send a raw deflate stream
+		buffered := bufio.NewReader(resp.Body)
+		header, err := buffered.Peek(2)
+		if err == nil && header[0]&0x0f == 8 && (int(header[0])<<8|int(header[1]))%31 == 0 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// stream the "results" array of a list endpoint, calling each for every entry as soon as it's decoded
+// instead of holding the whole decoded list in memory
+func (client *Client) StreamResults(url string, each func(NamedResource) error) error {
+	url = canonicalURL(url)
+	endpoint := endpointName(client.baseURL, url)
+	body, ok := client.cache.Get(url)
+	client.metrics.CacheLookup(endpoint, ok)
+	if ok {
+		return decodeResults(bytes.NewReader(body), each)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	start := time.Now()
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	counter := &countingReader{reader: resp.Body}
+	resp.Body = io.NopCloser(counter)
+	defer func() {
+		client.metrics.Request(endpoint, counter.count, time.Since(start))
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, URL: url}
+	}
+
+	reader, err := decodedReader(resp)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// keep a copy of the raw body as it streams past so the list still ends up cached
+	var raw bytes.Buffer
+	tee := io.TeeReader(reader, &raw)
+	err = decodeResults(tee, each)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, tee)
+	if err != nil {
+		return err
+	}
+	client.cache.AddValidated(url, raw.Bytes(), resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	return nil
+}
+
+// walk a list response token by token, decoding one entry of "results" at a time
+func decodeResults(r io.Reader, each func(NamedResource) error) error {
+	decoder := json.NewDecoder(r)
+	err := expectDelim(decoder, '{')
+	if err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := token.(string); key != "results" {
+			var skip json.RawMessage
+			err = decoder.Decode(&skip)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = expectDelim(decoder, '[')
+		if err != nil {
+			return err
+		}
+		for decoder.More() {
+			var resource NamedResource
+			err = decoder.Decode(&resource)
+			if err != nil {
+				return err
+			}
+			err = each(resource)
+			if err != nil {
+				return err
+			}
+		}
+		err = expectDelim(decoder, ']')
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v in list response, got %v", delim, token)
+	}
+	return nil
+}
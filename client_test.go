@@ -0,0 +1,306 @@
+package main
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConditionalRevalidation(t *testing.T) {
+	requests := 0
+	conditional := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("testdata"))
+	}))
+	defer server.Close()
+
+	const interval = 5 * time.Millisecond
+	client := NewClient(NewCache(interval))
+
+	body, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// let the reaper mark the entry stale
+	time.Sleep(interval * 3)
+
+	body, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "testdata" {
+		t.Errorf("expected cached body, got %q", body)
+	}
+	if requests != 2 || conditional != 1 {
+		t.Errorf("expected 2 requests with 1 conditional, got %v and %v", requests, conditional)
+	}
+}
+
+func TestCompressedResponses(t *testing.T) {
+	cases := []struct {
+		encoding string
+		compress func(io.Writer) io.WriteCloser
+	}{
+		{
+			encoding: "gzip",
+			compress: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		},
+		{
+			encoding: "deflate",
+			compress: func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.encoding, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), c.encoding) {
+					t.Errorf("expected %s in Accept-Encoding, got %q", c.encoding, r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Encoding", c.encoding)
+				writer := c.compress(w)
+				writer.Write([]byte("testdata"))
+				writer.Close()
+			}))
+			defer server.Close()
+
+			client := NewClient(NewCache(time.Minute))
+			body, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != "testdata" {
+				t.Errorf("expected decoded body, got %q", body)
+			}
+		})
+	}
+}
+
+func TestTransportTrustsCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("testdata"))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := os.WriteFile(caFile, certPEM, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := NewTransport(HTTPConfig{CAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(NewCache(time.Minute))
+	client.SetTransport(transport)
+
+	body, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "testdata" {
+		t.Errorf("expected body, got %q", body)
+	}
+}
+
+func TestStreamResults(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"count": 2, "next": null, "results": [{"name": "a", "url": "u1"}, {"name": "b", "url": "u2"}], "extra": {"nested": [1, 2]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	for i := 0; i < 2; i++ {
+		names := []string{}
+		err := client.StreamResults(server.URL, func(resource NamedResource) error {
+			names = append(names, resource.Name)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(names, ",") != "a,b" {
+			t.Errorf("expected a,b got %v", names)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second stream to come from the cache, got %v requests", requests)
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	var pokemon Pokemon
+	err := client.GetJSON(server.URL+"/api/v2/pokemon/picachu", &pokemon)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err.Error() != "no pokemon named 'picachu'" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if _, ok := client.cache.Get(server.URL + "/api/v2/pokemon/picachu"); ok {
+		t.Errorf("expected the 404 to not be cached")
+	}
+}
+
+func TestConcurrentRequestsShareOneFetch(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte("testdata"))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, err := client.Get(server.URL)
+			if err != nil || string(body) != "testdata" {
+				t.Errorf("unexpected result %q %v", body, err)
+			}
+		}()
+	}
+
+	// give every goroutine time to join the flight before the server answers
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("expected 1 request, got %v", requests)
+	}
+}
+
+func TestGetPokemonFromMirror(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/api/v2/pokemon/pikachu":
+			w.Write([]byte(`{"id": 25, "name": "pikachu", "base_experience": null, "species": {"name": "pikachu", "url": ""}}`))
+		case "/api/v2/pokemon-species/pikachu":
+			w.Write([]byte(`{"id": 25, "name": "pikachu", "capture_rate": 190}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL + "/api/v2/")
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// missing base experience falls back to one estimated from the capture rate
+	if pokemon.Base_experience != baseExperienceFromCaptureRate(190) {
+		t.Errorf("expected estimated base experience, got %v", pokemon.Base_experience)
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	cases := map[string]string{
+		"https://PokeAPI.co/api/v2/pokemon/Pikachu":                   "https://pokeapi.co/api/v2/pokemon/pikachu/",
+		"https://pokeapi.co/api/v2/location-area?limit=20&offset=40":  "https://pokeapi.co/api/v2/location-area/?limit=20&offset=40",
+		"https://pokeapi.co/api/v2/location-area/?offset=40&limit=20": "https://pokeapi.co/api/v2/location-area/?limit=20&offset=40",
+	}
+	for raw, expected := range cases {
+		if canonical := canonicalURL(raw); canonical != expected {
+			t.Errorf("canonicalURL(%q) = %q, expected %q", raw, canonical, expected)
+		}
+	}
+}
+
+func TestNameAndIdShareCacheEntry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL + "/api/v2")
+	client.Get(client.ResourceURL("pokemon", "pikachu"))
+	client.Get(client.ResourceURL("pokemon", "25"))
+
+	if requests != 1 {
+		t.Errorf("expected the id lookup to hit the cache, got %v requests", requests)
+	}
+}
+
+func TestInvalidateRemovesAliases(t *testing.T) {
+	cache := NewCache(time.Minute)
+	// entries expire straight away, the alias has to be found all the same
+	cache.SetTTLPolicy(func(key string) time.Duration { return -time.Second })
+	client := NewClient(cache)
+	byName := canonicalURL(client.ResourceURL("pokemon", "pikachu"))
+	byID := canonicalURL(client.ResourceURL("pokemon", "25"))
+	body := []byte(`{"id": 25, "name": "pikachu"}`)
+	cache.Add(byName, body)
+	cache.Add(byID, body)
+
+	if !client.Invalidate(byName) {
+		t.Fatalf("expected pikachu to be removed")
+	}
+	if _, ok := cache.Peek(byID); ok {
+		t.Errorf("expected the id alias to be removed with the name")
+	}
+	stats := cache.Stats()
+	if stats.Entries != 0 || stats.Hits != 0 || stats.Misses != 0 {
+		t.Errorf("expected an empty cache with no lookups counted, got %+v", stats)
+	}
+}
+
+func TestMetricsPerEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "canalave-city-area"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL + "/api/v2")
+	client.Get(client.ResourceURL("location-area", "canalave-city-area"))
+	client.Get(client.ResourceURL("location-area", "canalave-city-area"))
+
+	snapshot := client.metrics.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].Endpoint != "location-area" {
+		t.Fatalf("expected one location-area endpoint, got %+v", snapshot)
+	}
+	endpoint := snapshot[0]
+	if endpoint.Requests != 1 || endpoint.CacheHits != 1 || endpoint.CacheMisses != 1 || endpoint.Bytes == 0 {
+		t.Errorf("unexpected counters %+v", endpoint)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// the stats compare shows, in the order the games list them
+var statOrder = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// format two values, marking the higher one with a *
+func markWinner(a, b int) (string, string) {
+	left, right := fmt.Sprint(a), fmt.Sprint(b)
+	if a > b {
+		left += "*"
+	} else if b > a {
+		right += "*"
+	}
+	return left, right
+}
+
+// the pokemon's types joined like "water/flying"
+func (pokemon Pokemon) TypeNames() string {
+	return strings.Join(pokemon.TypeList(), "/")
+}
+
+// the pokemon's type names in slot order
+func (pokemon Pokemon) TypeList() []string {
+	types := []string{}
+	for _, pokemonType := range pokemon.Types {
+		types = append(types, pokemonType.Type.Name)
+	}
+	return types
+}
+
+// show two pokemon side by side, the higher value of every stat is marked with a *
+func compareCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter two pokemon to compare")
+		return nil
+	}
+
+	names := []string{strings.ToLower(params[0]), strings.ToLower(params[1])}
+	pokemon, errs := session.client.GetPokemonBatch(names, 2)
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	a, b := pokemon[0], pokemon[1]
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(label string, left, right string) {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", label, left, right)
+	}
+	row("", a.Name, b.Name)
+	row("types", a.TypeNames(), b.TypeNames())
+	// height is in decimetres and weight in hectograms
+	left, right := markWinner(a.Height, b.Height)
+	row("height (dm)", left, right)
+	left, right = markWinner(a.Weight, b.Weight)
+	row("weight (hg)", left, right)
+	left, right = markWinner(a.Base_experience, b.Base_experience)
+	row("base exp", left, right)
+	totalA, totalB := 0, 0
+	for _, stat := range statOrder {
+		left, right := markWinner(a.BaseStat(stat), b.BaseStat(stat))
+		row(stat, left, right)
+		totalA += a.BaseStat(stat)
+		totalB += b.BaseStat(stat)
+	}
+	left, right = markWinner(totalA, totalB)
+	row("total", left, right)
+	return table.Flush()
+}
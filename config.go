@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// user settings, read from ~/.pokedex/config.json
+// anything missing from the file keeps its default
+type Config struct {
+	API     APIConfig     `json:"api"`
+	Cache   CacheConfig   `json:"cache"`
+	HTTP    HTTPConfig    `json:"http"`
+	GraphQL GraphQLConfig `json:"graphql"`
+	Map     MapSettings   `json:"map"`
+	Game    GameConfig    `json:"game"`
+	// urls told about catches, shinies and pokedex milestones
+	Webhooks []WebhookConfig `json:"webhooks"`
+}
+
+type CacheConfig struct {
+	// how long responses stay fresh, per resource class (see resourceClass)
+	TTL        map[string]Duration `json:"ttl"`
+	MaxEntries int                 `json:"max_entries"`
+	MaxBytes   int                 `json:"max_bytes"`
+	// fetch every location area in the background when the REPL starts
+	WarmOnStartup bool `json:"warm_on_startup"`
+	// time between warm-up requests
+	WarmInterval Duration `json:"warm_interval"`
+}
+
+// which PokeAPI to talk to
+type APIConfig struct {
+	// e.g. http://localhost:8000/api/v2 for a self-hosted mirror, the -base-url flag overrides it
+	BaseURL string `json:"base_url"`
+}
+
+// how the api client reaches the network
+type HTTPConfig struct {
+	// proxy url, overrides HTTP_PROXY/HTTPS_PROXY when set
+	Proxy string `json:"proxy"`
+	// pem bundle trusted in addition to the system roots, for tls interception proxies
+	CAFile string `json:"ca_file"`
+	// turn off certificate checks entirely, only for when nothing else works
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+}
+
+// defaults for the map and mapb commands
+type MapSettings struct {
+	// location areas per page, map --limit overrides it
+	PageSize int `json:"page_size"`
+}
+
+// odds and rules of catching and battling
+type GameConfig struct {
+	// chance that a caught pokemon is shiny, 1/4096 like the modern games
+	ShinyChance float64 `json:"shiny_chance"`
+	// how well wild pokemon and gym leaders battle, "easy", "normal" or "hard", battle and gym --difficulty override them
+	WildDifficulty string `json:"wild_difficulty"`
+	GymDifficulty  string `json:"gym_difficulty"`
+	// a file every battle's events are added to as json lines, "" for none, --log overrides it
+	BattleLog string `json:"battle_log"`
+}
+
+// search-style commands can ask the GraphQL endpoint instead of making dozens of REST calls
+type GraphQLConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+}
+
+// a time.Duration written as "5m" or "72h" in json
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return fmt.Errorf("durations must be strings like \"5m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// the settings used when there is no config file
+func DefaultConfig() *Config {
+	return &Config{
+		API: APIConfig{
+			BaseURL: defaultBaseURL,
+		},
+		Cache: CacheConfig{
+			TTL: map[string]Duration{
+				// pages of location areas and other lists
+				"listing": Duration(5 * time.Minute),
+				// pokemon, species, moves and the like barely ever change
+				"static": Duration(72 * time.Hour),
+			},
+			MaxEntries:   1000,
+			MaxBytes:     32 << 20,
+			WarmInterval: Duration(defaultWarmInterval),
+		},
+		GraphQL: GraphQLConfig{
+			Enabled: false,
+			URL:     defaultGraphQLURL,
+		},
+		Map: MapSettings{
+			PageSize: 20,
+		},
+		Game: GameConfig{
+			ShinyChance:    1.0 / 4096,
+			WildDifficulty: "easy",
+			GymDifficulty:  "normal",
+		},
+	}
+}
+
+// returns the directory the pokedex keeps its files in, ~/.pokedex unless POKEDEX_HOME is set
+func pokedexDir() string {
+	if dir := os.Getenv("POKEDEX_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".pokedex"
+	}
+	return filepath.Join(home, ".pokedex")
+}
+
+// open ~/.pokedex/pokedex.log for appending
+func openLogFile() (*os.File, error) {
+	err := os.MkdirAll(pokedexDir(), 0o755)
+	if err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(pokedexDir(), "pokedex.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
+
+// path of the config file
+func configPath() string {
+	return filepath.Join(pokedexDir(), "config.json")
+}
+
+// read the config file at path on top of the defaults, a missing file just means defaults
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return DefaultConfig(), fmt.Errorf("reading %s: %w", path, err)
+	}
+	// a page of nothing would never get anywhere, so a page size that isn't positive keeps the default
+	if config.Map.PageSize <= 0 {
+		config.Map.PageSize = DefaultConfig().Map.PageSize
+	}
+	return config, nil
+}
+
+// how long a cached response for url stays fresh
+func (config *Config) CacheTTL(url string) time.Duration {
+	ttl, ok := config.Cache.TTL[resourceClass(url)]
+	if !ok {
+		return time.Duration(config.Cache.TTL["static"])
+	}
+	return time.Duration(ttl)
+}
+
+// the shortest ttl of any class, used as the cache's reap interval
+func (config *Config) MinCacheTTL() time.Duration {
+	shortest := time.Duration(0)
+	for _, ttl := range config.Cache.TTL {
+		if shortest == 0 || time.Duration(ttl) < shortest {
+			shortest = time.Duration(ttl)
+		}
+	}
+	if shortest <= 0 {
+		return 5 * time.Minute
+	}
+	return shortest
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigOverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(path, []byte(`{"cache": {"ttl": {"listing": "30s"}}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl := config.CacheTTL("https://pokeapi.co/api/v2/location-area/?offset=0&limit=20"); ttl != 30*time.Second {
+		t.Errorf("expected listing ttl from the file, got %v", ttl)
+	}
+	if ttl := config.CacheTTL("https://pokeapi.co/api/v2/pokemon/pikachu"); ttl != 72*time.Hour {
+		t.Errorf("expected default static ttl, got %v", ttl)
+	}
+	if config.MinCacheTTL() != 30*time.Second {
+		t.Errorf("expected the shortest ttl to be 30s, got %v", config.MinCacheTTL())
+	}
+}
+
+func TestLoadConfigShinyChance(t *testing.T) {
+	config, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Game.ShinyChance != 1.0/4096 {
+		t.Errorf("expected the default shiny chance, got %v", config.Game.ShinyChance)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	err = os.WriteFile(path, []byte(`{"game": {"shiny_chance": 0.5}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err = LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Game.ShinyChance != 0.5 {
+		t.Errorf("expected shiny chance from the file, got %v", config.Game.ShinyChance)
+	}
+}
+
+func TestLoadConfigPageSize(t *testing.T) {
+	for text, expected := range map[string]int{`{"map": {"page_size": 50}}`: 50, `{"map": {"page_size": 0}}`: 20, `{"map": {"page_size": -5}}`: 20} {
+		path := filepath.Join(t.TempDir(), "config.json")
+		err := os.WriteFile(path, []byte(text), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Map.PageSize != expected {
+			t.Errorf("%s: expected a page size of %d, got %d", text, expected, config.Map.PageSize)
+		}
+	}
+}
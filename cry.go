@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// audio players that can play ogg files, tried in order, with the arguments that keep them quiet
+var cryPlayers = [][]string{
+	{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"},
+	{"mpv", "--no-video", "--really-quiet"},
+	{"paplay"},
+	{"ogg123", "-q"},
+	{"afplay"},
+}
+
+// the first player installed on this machine, nil if there is none
+func findCryPlayer() []string {
+	for _, player := range cryPlayers {
+		if _, err := exec.LookPath(player[0]); err == nil {
+			return player
+		}
+	}
+	return nil
+}
+
+// cry [pokemon] [--legacy] - play a pokemon's cry, or print where to find it
+func cryCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "legacy")
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	pokemon, err := session.client.GetPokemon(strings.ToLower(params[0]))
+	if err != nil {
+		return err
+	}
+	url := pokemon.Cries.Latest
+	if _, ok := flags["legacy"]; ok {
+		url = pokemon.Cries.Legacy
+	}
+	if url == "" {
+		fmt.Println("No cry available for", pokemon.Name)
+		return nil
+	}
+
+	player := findCryPlayer()
+	if player == nil {
+		fmt.Println("No audio player found, listen here:", url)
+		return nil
+	}
+
+	// the bytes are cached like everything else, players want a file
+	audio, err := session.client.GetAsset(url)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(pokedexDir(), "cries")
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, filepath.Base(url))
+	err = os.WriteFile(path, audio, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(pokemon.Name, "cries!")
+	cmd := exec.Command(player[0], append(player[1:], path)...)
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		fmt.Println("Couldn't play the cry, listen here:", url)
+		return nil
+	}
+	return err
+}
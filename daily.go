@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// what the first session of a day hands out
+type DailyReward struct {
+	// days in a row played, counting today
+	Streak  int
+	Balls   map[string]int
+	Berries map[string]int
+	Money   int
+}
+
+// how many streak days keep adding to the reward
+const maxStreakBonus = 7
+
+// days are compared as local calendar dates
+const dateLayout = "2006-01-02"
+
+// the reward for the nth day in a row, longer streaks give more
+func dailyReward(streak int) DailyReward {
+	bonusDays := streak
+	if bonusDays > maxStreakBonus {
+		bonusDays = maxStreakBonus
+	}
+	reward := DailyReward{
+		Streak:  streak,
+		Balls:   map[string]int{"poke": 5},
+		Berries: map[string]int{"razz": 1},
+		Money:   100 + 50*(bonusDays-1),
+	}
+	if streak%3 == 0 {
+		reward.Balls["great"] = 2
+	}
+	if streak%maxStreakBonus == 0 {
+		reward.Balls["ultra"] = 1
+		reward.Berries["golden-razz"] = 1
+	}
+	return reward
+}
+
+// mark today as played, with the day's reward if it's the first session today
+func (profile *Profile) ClaimDaily(now time.Time) (DailyReward, bool) {
+	today := now.Format(dateLayout)
+	if profile.LastPlayed == today {
+		return DailyReward{}, false
+	}
+	if profile.LastPlayed == now.AddDate(0, 0, -1).Format(dateLayout) {
+		profile.Streak++
+	} else {
+		profile.Streak = 1
+	}
+	profile.LastPlayed = today
+	return dailyReward(profile.Streak), true
+}
+
+// put a reward in the bag
+func (inventory *Inventory) AddReward(reward DailyReward) {
+	for name, count := range reward.Balls {
+		inventory.AddBalls(balls[name], count)
+	}
+	for name, count := range reward.Berries {
+		inventory.AddBerries(name, count)
+	}
+	inventory.Money += reward.Money
+}
+
+// give out today's reward when the REPL starts, once a day
+func (session *Session) claimDaily(now time.Time) error {
+	reward, ok := session.profile.ClaimDaily(now)
+	if !ok {
+		return nil
+	}
+	session.inventory.AddReward(reward)
+
+	if reward.Streak > 1 {
+		fmt.Printf("Welcome back! Day %d in a row, here's your daily reward:\n", reward.Streak)
+	} else {
+		fmt.Println("Welcome! Here's your daily reward:")
+	}
+	for _, name := range ballNames() {
+		if count := reward.Balls[name]; count > 0 {
+			fmt.Printf("- %s ball x%d\n", name, count)
+		}
+	}
+	berries := []string{}
+	for name := range reward.Berries {
+		berries = append(berries, name)
+	}
+	sort.Strings(berries)
+	for _, name := range berries {
+		fmt.Printf("- %s berry x%d\n", name, reward.Berries[name])
+	}
+	fmt.Printf("- $%d\n", reward.Money)
+	return session.Save()
+}
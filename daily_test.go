@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimDaily(t *testing.T) {
+	profile := NewProfile()
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+
+	reward, ok := profile.ClaimDaily(day)
+	if !ok || reward.Streak != 1 || reward.Money != 100 {
+		t.Errorf("expected a first day reward, got %+v (%v)", reward, ok)
+	}
+	if _, ok := profile.ClaimDaily(day.Add(10 * time.Hour)); ok {
+		t.Errorf("expected no second reward on the same day")
+	}
+
+	for i := 1; i < 7; i++ {
+		reward, ok = profile.ClaimDaily(day.AddDate(0, 0, i))
+	}
+	if !ok || reward.Streak != 7 || reward.Balls["ultra"] != 1 || reward.Money != 400 {
+		t.Errorf("expected a week streak reward, got %+v", reward)
+	}
+	reward, _ = profile.ClaimDaily(day.AddDate(0, 0, 7))
+	if reward.Money != 400 {
+		t.Errorf("expected the money bonus to stop growing, got %d", reward.Money)
+	}
+
+	// a missed day starts over
+	reward, _ = profile.ClaimDaily(day.AddDate(0, 0, 9))
+	if reward.Streak != 1 {
+		t.Errorf("expected the streak to reset, got %d", reward.Streak)
+	}
+}
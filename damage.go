@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// damage multipliers by attacking type and then defending type, pairs that aren't there are 1x
+type TypeChart map[string]map[string]float64
+
+// the chart rows for attacking types, from each type's damage relations
+func (client *Client) GetTypeChart(types []string) (TypeChart, error) {
+	chart := make(TypeChart)
+	for _, name := range types {
+		if _, ok := chart[name]; ok || name == struggle.Type {
+			continue
+		}
+		typeInfo, err := client.GetType(name)
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]float64)
+		relations := typeInfo.Damage_relations
+		for multiplier, defending := range map[float64][]NamedResource{
+			2:   relations.Double_damage_to,
+			0.5: relations.Half_damage_to,
+			0:   relations.No_damage_to,
+		} {
+			for _, t := range defending {
+				row[t.Name] = multiplier
+			}
+		}
+		chart[name] = row
+	}
+	return chart, nil
+}
+
+// the multiplier for a move of a type against a pokemon's types, a nil chart treats everything as 1x
+func (chart TypeChart) Effectiveness(attacking string, defending []string) float64 {
+	effectiveness := 1.0
+	for _, t := range defending {
+		if multiplier, ok := chart[attacking][t]; ok {
+			effectiveness *= multiplier
+		}
+	}
+	return effectiveness
+}
+
+// every move type either side could use, what the type chart needs rows for
+func (battle *Battle) MoveTypes() []string {
+	types := []string{}
+	for _, side := range battle.Sides {
+		for _, battler := range side.Team {
+			for _, move := range battler.Moves {
+				types = append(types, move.Type)
+			}
+		}
+	}
+	return types
+}
+
+// the numbers behind one hit, kept so the explain mode can show them
+type DamageCalc struct {
+	Level   int
+	Power   int
+	Attack  int
+	Defense int
+	// damage from level, power and stats before any multipliers
+	Base int
+	// 1.5 when the move shares a type with its user
+	STAB float64
+	// 1.5 or 0.5 for water and fire moves in rain or sun
+	Weather float64
+	// 1.3 for moves of the terrain's type, 0.5 for the ones it weakens
+	Terrain       float64
+	Effectiveness float64
+	// a percentage from 85 to 100
+	Random int
+	Damage int
+}
+
+// the games' damage formula: level and stats scale the move's power, then stab, type and a random roll multiply it
+func calculateDamage(attacker, defender *Battler, move *BattleMove, chart TypeChart, weather, terrain string, random int) DamageCalc {
+	calc := DamageCalc{Level: attacker.Level, Power: move.Power, STAB: 1, Weather: weatherMultiplier(weather, move.Type),
+		Terrain: terrainMultiplier(terrain, move, attacker, defender), Random: random}
+	calc.Attack, calc.Defense = attacker.attackStat(move.Class), defender.defenseStat(move.Class)
+	if calc.Defense < 1 {
+		calc.Defense = 1
+	}
+	calc.Base = (2*calc.Level/5+2)*calc.Power*calc.Attack/calc.Defense/50 + 2
+	for _, t := range attacker.Types {
+		if t == move.Type {
+			calc.STAB = 1.5
+		}
+	}
+	calc.Effectiveness = chart.Effectiveness(move.Type, defender.Types)
+	calc.Damage = int(float64(calc.Base) * calc.STAB * calc.Weather * calc.Terrain * calc.Effectiveness * float64(calc.Random) / 100)
+	if calc.Damage < 1 && calc.Effectiveness > 0 {
+		calc.Damage = 1
+	}
+	return calc
+}
+
+// the math as one line, like "((2×20/5+2)×40×55/40)/50+2 = 13, ×1.5 stab, ×2 type, ×93% roll = 36"
+func (calc DamageCalc) Explain() string {
+	parts := []string{fmt.Sprintf("((2×%d/5+2)×%d×%d/%d)/50+2 = %d", calc.Level, calc.Power, calc.Attack, calc.Defense, calc.Base)}
+	if calc.STAB != 1 {
+		parts = append(parts, fmt.Sprintf("×%g stab", calc.STAB))
+	}
+	if calc.Weather != 1 {
+		parts = append(parts, fmt.Sprintf("×%g weather", calc.Weather))
+	}
+	if calc.Terrain != 1 {
+		parts = append(parts, fmt.Sprintf("×%g terrain", calc.Terrain))
+	}
+	if calc.Effectiveness != 1 {
+		parts = append(parts, fmt.Sprintf("×%g type", calc.Effectiveness))
+	}
+	parts = append(parts, fmt.Sprintf("×%d%% roll", calc.Random))
+	return fmt.Sprintf("%s = %d", strings.Join(parts, ", "), calc.Damage)
+}
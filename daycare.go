@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// every explore is this many steps for the daycare and the eggs being carried
+const stepsPerExplore = 500
+
+// the daycare finds an egg after the parents spend this many steps together
+const eggSteps = 1000
+
+// an egg cycle from the api, a species' hatch counter is how many cycles its eggs take
+const stepsPerCycle = 257
+
+// how many ivs a baby gets from its parents, the rest are random
+const inheritedIVs = 3
+
+// the daycare and the eggs the player is carrying, the parents are the pokemon marked as at the daycare
+type Daycare struct {
+	// steps since the parents were left or the last egg was found
+	Steps int    `json:"steps"`
+	Eggs  []*Egg `json:"eggs"`
+}
+
+// an egg that hatches after enough steps
+type Egg struct {
+	Species string         `json:"species"`
+	IVs     map[string]int `json:"ivs"`
+	// steps left until it hatches
+	Steps int `json:"steps"`
+}
+
+// the pokemon left at the daycare
+func (pokedex *Pokedex) AtDaycare() []*CaughtPokemon {
+	parents := []*CaughtPokemon{}
+	for _, caught := range pokedex.Pokemon {
+		if caught.Daycare {
+			parents = append(parents, caught)
+		}
+	}
+	return parents
+}
+
+func (species PokemonSpecies) inEggGroup(group string) bool {
+	for _, eggGroup := range species.Egg_groups {
+		if eggGroup.Name == group {
+			return true
+		}
+	}
+	return false
+}
+
+// why two species can't breed, nil if they can
+// the game doesn't keep track of gender so any two compatible pokemon make a pair
+func canBreed(a, b PokemonSpecies) error {
+	for _, species := range []PokemonSpecies{a, b} {
+		if species.inEggGroup("no-eggs") {
+			return fmt.Errorf("%s can't breed", species.Name)
+		}
+	}
+	aDitto, bDitto := a.inEggGroup("ditto"), b.inEggGroup("ditto")
+	if aDitto && bDitto {
+		return fmt.Errorf("two ditto can't breed")
+	}
+	if aDitto || bDitto {
+		return nil
+	}
+	// genderless pokemon only breed with ditto
+	for _, species := range []PokemonSpecies{a, b} {
+		if species.Gender_rate == -1 {
+			return fmt.Errorf("%s can only breed with ditto", species.Name)
+		}
+	}
+	for _, group := range a.Egg_groups {
+		if b.inEggGroup(group.Name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s and %s have no egg group in common", a.Name, b.Name)
+}
+
+// a baby's ivs, a few stats from one parent or the other and the rest random
+func inheritIVs(a, b map[string]int, random *rand.Rand) map[string]int {
+	ivs := make(map[string]int)
+	for _, stat := range statOrder {
+		ivs[stat] = random.Intn(maxIV + 1)
+	}
+	for _, i := range random.Perm(len(statOrder))[:inheritedIVs] {
+		stat := statOrder[i]
+		if random.Intn(2) == 0 {
+			ivs[stat] = a[stat]
+		} else {
+			ivs[stat] = b[stat]
+		}
+	}
+	return ivs
+}
+
+// the egg two parents have, the base species of the one that isn't a ditto
+func (session *Session) findEgg(a, b *CaughtPokemon) (*Egg, error) {
+	client := session.client
+	species, err := client.GetSpecies(a.SpeciesName())
+	if err != nil {
+		return nil, err
+	}
+	if species.inEggGroup("ditto") {
+		species, err = client.GetSpecies(b.SpeciesName())
+		if err != nil {
+			return nil, err
+		}
+	}
+	chain, err := client.GetEvolutionChain(species)
+	if err != nil {
+		return nil, err
+	}
+	base, err := client.GetSpecies(chain.Chain.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	ivs := inheritIVs(a.IVs, b.IVs, rand.New(rand.NewSource(rand.Int63())))
+	return &Egg{Species: base.Name, IVs: ivs, Steps: base.Hatch_counter * stepsPerCycle}, nil
+}
+
+// hatch an egg into a level 1 pokemon
+func (session *Session) hatch(egg *Egg) error {
+	client := session.client
+	pokemon, err := client.ResolvePokemon(egg.Species)
+	if err != nil {
+		return err
+	}
+	species, err := client.GetSpecies(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	caught := &CaughtPokemon{Pokemon: pokemon, Level: 1, IVs: egg.IVs, Happiness: species.StartingHappiness(),
+		CaughtOn: time.Now().Format(dateLayout)}
+	fmt.Println("Oh? Your egg hatched into", pokemon.Name+"!")
+	if !session.pokedex.Add(caught) {
+		fmt.Println("Your party is full,", pokemon.Name, "was sent to the pc")
+	}
+	session.events.Publish(GameEvent{Kind: "hatch", Caught: caught})
+	return session.Record(HistoryEvent{Kind: "hatch", Pokemon: pokemon.Name})
+}
+
+// walking around hatches eggs, and the daycare finds a new one now and then
+func (session *Session) trackDaycare(event GameEvent) {
+	if event.Kind != "explore" {
+		return
+	}
+	daycare := &session.profile.Daycare
+	carrying := []*Egg{}
+	for _, egg := range daycare.Eggs {
+		egg.Steps -= stepsPerExplore
+		if egg.Steps > 0 {
+			carrying = append(carrying, egg)
+			continue
+		}
+		if err := session.hatch(egg); err != nil {
+			fmt.Println("Couldn't hatch your", egg.Species, "egg:", err)
+			carrying = append(carrying, egg)
+		}
+	}
+	daycare.Eggs = carrying
+
+	parents := session.pokedex.AtDaycare()
+	if len(parents) != 2 {
+		return
+	}
+	daycare.Steps += stepsPerExplore
+	if daycare.Steps < eggSteps {
+		return
+	}
+	egg, err := session.findEgg(parents[0], parents[1])
+	if err != nil {
+		fmt.Println("Couldn't check the daycare for an egg:", err)
+		return
+	}
+	daycare.Steps = 0
+	daycare.Eggs = append(daycare.Eggs, egg)
+	fmt.Println("The daycare found an egg! You're carrying it now, keep exploring to hatch it")
+}
+
+func printDaycare(daycare Daycare, pokedex *Pokedex) {
+	parents := pokedex.AtDaycare()
+	if len(parents) == 0 {
+		fmt.Println("The daycare is empty, leave two pokemon with daycare leave [pokemon] [pokemon]")
+	} else {
+		names := []string{}
+		for _, caught := range parents {
+			names = append(names, caughtSummary(caught))
+		}
+		fmt.Println("At the daycare:", strings.Join(names, " and "))
+		if len(parents) == 2 {
+			fmt.Printf("%d of %d steps until the next egg\n", daycare.Steps, eggSteps)
+		}
+	}
+	for _, egg := range daycare.Eggs {
+		fmt.Printf("You're carrying an egg, %d steps until it hatches\n", egg.Steps)
+	}
+}
+
+// daycare [leave [pokemon] [pokemon]|collect] - breed two compatible pokemon into eggs that hatch as you explore
+func daycareCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	pokedex := session.pokedex
+	daycare := &session.profile.Daycare
+	if len(params) == 0 {
+		printDaycare(*daycare, pokedex)
+		return nil
+	}
+
+	switch params[0] {
+	case "leave":
+		if len(params) < 3 {
+			fmt.Println("Please enter two pokemon")
+			return nil
+		}
+		if len(pokedex.AtDaycare()) > 0 {
+			return fmt.Errorf("the daycare already has pokemon, collect them first")
+		}
+		a, err := pokedex.Find(params[1])
+		if err != nil {
+			return err
+		}
+		b, err := pokedex.Find(params[2])
+		if err != nil {
+			return err
+		}
+		if a == b {
+			return fmt.Errorf("please enter two different pokemon")
+		}
+		for _, caught := range []*CaughtPokemon{a, b} {
+			if caught.Dead {
+				return fmt.Errorf("%s fainted in your nuzlocke run and can't come back", caught.DisplayName())
+			}
+		}
+		aSpecies, err := session.client.GetSpecies(a.SpeciesName())
+		if err != nil {
+			return err
+		}
+		bSpecies, err := session.client.GetSpecies(b.SpeciesName())
+		if err != nil {
+			return err
+		}
+		err = canBreed(aSpecies, bSpecies)
+		if err != nil {
+			return err
+		}
+		for _, caught := range []*CaughtPokemon{a, b} {
+			pokedex.leaveParty(caught)
+			caught.Daycare = true
+		}
+		daycare.Steps = 0
+		fmt.Printf("You left %s and %s at the daycare, keep exploring and they may find an egg\n", a.DisplayName(), b.DisplayName())
+	case "collect":
+		parents := pokedex.AtDaycare()
+		if len(parents) == 0 {
+			fmt.Println("The daycare is empty")
+			return nil
+		}
+		for _, caught := range parents {
+			caught.Daycare = false
+			fmt.Println(caught.DisplayName(), "is back in your pc")
+		}
+		daycare.Steps = 0
+	default:
+		fmt.Println("Usage: daycare [leave [pokemon] [pokemon]|collect]")
+		return nil
+	}
+	return session.Save()
+}
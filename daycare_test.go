@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func testSpecies(name string, genderRate int, groups ...string) PokemonSpecies {
+	species := PokemonSpecies{Name: name, Gender_rate: genderRate}
+	for _, group := range groups {
+		species.Egg_groups = append(species.Egg_groups, NamedResource{Name: group})
+	}
+	return species
+}
+
+func TestCanBreed(t *testing.T) {
+	pikachu := testSpecies("pikachu", 4, "ground", "fairy")
+	clefairy := testSpecies("clefairy", 6, "fairy")
+	charmander := testSpecies("charmander", 1, "monster", "dragon")
+	magnemite := testSpecies("magnemite", -1, "mineral")
+	ditto := testSpecies("ditto", -1, "ditto")
+	mewtwo := testSpecies("mewtwo", -1, "no-eggs")
+
+	cases := []struct {
+		a, b PokemonSpecies
+		ok   bool
+	}{
+		{pikachu, clefairy, true},
+		{pikachu, charmander, false},
+		{magnemite, ditto, true},
+		{magnemite, testSpecies("geodude", 0, "mineral"), false},
+		{charmander, ditto, true},
+		{ditto, ditto, false},
+		{mewtwo, ditto, false},
+	}
+	for _, c := range cases {
+		if err := canBreed(c.a, c.b); (err == nil) != c.ok {
+			t.Errorf("%s and %s: expected ok %v, got %v", c.a.Name, c.b.Name, c.ok, err)
+		}
+	}
+}
+
+func TestInheritIVs(t *testing.T) {
+	perfect := map[string]int{}
+	for _, stat := range statOrder {
+		perfect[stat] = maxIV
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		ivs := inheritIVs(perfect, perfect, random)
+		inherited := 0
+		for _, stat := range statOrder {
+			if ivs[stat] < 0 || ivs[stat] > maxIV {
+				t.Fatalf("%s iv %d out of range", stat, ivs[stat])
+			}
+			if ivs[stat] == maxIV {
+				inherited++
+			}
+		}
+		if inherited < inheritedIVs {
+			t.Errorf("expected at least %d ivs from the parents, got %v", inheritedIVs, ivs)
+		}
+	}
+}
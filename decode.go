@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// what didn't line up between a response and the struct it was decoded into
+type DecodeReport struct {
+	// fields the struct expects that were absent or null
+	Missing []string
+	// fields that had a different type than expected and were left at their zero value
+	Mismatched []string
+}
+
+func (report DecodeReport) IsMissing(field string) bool {
+	for _, missing := range report.Missing {
+		if missing == field {
+			return true
+		}
+	}
+	return false
+}
+
+// schema drift is logged once per type and field, not on every request
+var driftSeen sync.Map
+
+// decode data into v without failing on drift in the api's schema
+// a field with the wrong type is skipped instead of failing the whole decode, and expected top level fields
+// that are missing or null are reported, both get logged so schema changes are noticed
+// fields in the response that v doesn't model are fine, the structs only model what the commands use
+func decodeJSON(url string, data []byte, v interface{}) (DecodeReport, error) {
+	var report DecodeReport
+
+	err := json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		// Unmarshal keeps going after a type error, so everything else is filled in
+		report.Mismatched = append(report.Mismatched, typeErr.Field)
+	} else if err != nil {
+		return report, err
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) == nil {
+		for _, name := range jsonFieldNames(v) {
+			raw, ok := fields[name]
+			if !ok || string(raw) == "null" {
+				report.Missing = append(report.Missing, name)
+			}
+		}
+	}
+
+	typeName := reflect.TypeOf(v).String()
+	for _, field := range report.Missing {
+		logDrift(typeName, field, "missing or null in "+url)
+	}
+	for _, field := range report.Mismatched {
+		logDrift(typeName, field, "has an unexpected type in "+url)
+	}
+	return report, nil
+}
+
+func logDrift(typeName, field, problem string) {
+	_, seen := driftSeen.LoadOrStore(typeName+"."+field, true)
+	if !seen {
+		log.Printf("schema drift: %s field %q %s", typeName, field, problem)
+	}
+}
+
+// json names of the top level fields of the struct v points to
+func jsonFieldNames(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestDecodeJSONToleratesDrift(t *testing.T) {
+	var pokemon Pokemon
+	data := []byte(`{"id": 25, "name": "pikachu", "base_experience": null, "height": "four", "weight": 60}`)
+
+	report, err := decodeJSON("test", data, &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.Weight != 60 {
+		t.Errorf("expected the well formed fields to decode, got %+v", pokemon)
+	}
+	if !report.IsMissing("base_experience") {
+		t.Errorf("expected base_experience to be reported missing, got %+v", report)
+	}
+	if len(report.Mismatched) != 1 || report.Mismatched[0] != "height" {
+		t.Errorf("expected height to be reported mismatched, got %+v", report)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DiskCache persists cache entries as files so they survive between sessions
+type DiskCache struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// what gets written to disk for every key
+type diskEntry struct {
+	Key       string
+	CreatedAt time.Time
+	Val       []byte
+
+	ETag         string
+	LastModified string
+	// 0 for entries written before per-entry ttls, those use the cache's maxAge
+	TTL time.Duration
+}
+
+// create a disk cache rooted at dir, entries older than maxAge are treated as missing
+func NewDiskCache(dir string, maxAge time.Duration) (*DiskCache, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, maxAge: maxAge}, nil
+}
+
+// keys are urls, so hash them to get a safe file name
+func (disk *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(disk.dir, hex.EncodeToString(sum[:]))
+}
+
+// write the (key, value) pair to disk
+func (disk *DiskCache) Add(key string, val []byte) error {
+	return disk.AddValidated(key, val, "", "", 0)
+}
+
+// write the (key, value) pair to disk along with the response validators
+// a ttl of 0 means the entry expires after the cache's maxAge
+func (disk *DiskCache) AddValidated(key string, val []byte, etag, lastModified string, ttl time.Duration) error {
+	return disk.write(key, diskEntry{
+		Key:          key,
+		CreatedAt:    time.Now(),
+		Val:          val,
+		ETag:         etag,
+		LastModified: lastModified,
+		TTL:          ttl,
+	})
+}
+
+// write to a temp file first and rename it so a crash never leaves a half written entry
+func (disk *DiskCache) write(key string, entry diskEntry) error {
+	tmp, err := os.CreateTemp(disk.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	err = gob.NewEncoder(tmp).Encode(entry)
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), disk.path(key))
+}
+
+// read a fresh entry from disk
+func (disk *DiskCache) Get(key string) ([]byte, bool) {
+	entry, fresh, ok := disk.lookup(key)
+	if !ok || !fresh {
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+// read an entry from disk and report whether it is still fresh
+// unreadable entries and expired ones without validators are removed and reported as missing
+func (disk *DiskCache) lookup(key string) (diskEntry, bool, bool) {
+	var entry diskEntry
+	path := disk.path(key)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return entry, false, false
+	}
+	err = gob.NewDecoder(file).Decode(&entry)
+	file.Close()
+
+	// a corrupt file or a hash collision is as good as a miss
+	if err != nil {
+		os.Remove(path)
+		return diskEntry{}, false, false
+	}
+	if entry.Key != key {
+		return diskEntry{}, false, false
+	}
+	maxAge := disk.maxAge
+	if entry.TTL > 0 {
+		maxAge = entry.TTL
+	}
+	if time.Since(entry.CreatedAt) > maxAge {
+		if entry.ETag == "" && entry.LastModified == "" {
+			os.Remove(path)
+			return diskEntry{}, false, false
+		}
+		return entry, false, true
+	}
+	return entry, true, true
+}
+
+// delete the entry for key, returns whether there was one
+func (disk *DiskCache) Remove(key string) bool {
+	return os.Remove(disk.path(key)) == nil
+}
+
+// delete every entry
+func (disk *DiskCache) Clear() error {
+	files, err := os.ReadDir(disk.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err := os.Remove(filepath.Join(disk.dir, file.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// number of entries and total bytes stored on disk
+func (disk *DiskCache) Stats() (int, int64, error) {
+	files, err := os.ReadDir(disk.dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	entries := 0
+	size := int64(0)
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		entries++
+		size += info.Size()
+	}
+	return entries, size, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiskCachePersists(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDiskCache(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disk.Add("https://example.com", []byte("testdata"))
+
+	// a fresh cache pointed at the same directory acts like a new session
+	cache := NewCache(time.Minute)
+	reopened, err := NewDiskCache(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.SetDisk(reopened)
+
+	val, ok := cache.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value")
+		return
+	}
+}
+
+func TestDiskCacheExpires(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disk.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := disk.Get("https://example.com")
+	if ok {
+		t.Errorf("expected to not find key")
+		return
+	}
+}
+
+func TestCacheRemoveBothLayers(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache(time.Minute)
+	cache.SetDisk(disk)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	if !cache.Remove("https://example.com") {
+		t.Errorf("expected key to be removed")
+	}
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected to not find key")
+	}
+	if _, ok := disk.Get("https://example.com"); ok {
+		t.Errorf("expected key to be gone from disk")
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "fmt"
+
+type EggGroup struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	Names           []LocalizedName `json:"names"`
+	Pokemon_species []NamedResource `json:"pokemon_species"`
+}
+
+// fetch an egg group by name, like "monster" or "water1"
+func (client *Client) GetEggGroup(name string) (EggGroup, error) {
+	var eggGroup EggGroup
+	err := client.GetJSON(client.ResourceURL("egg-group", name), &eggGroup)
+	return eggGroup, err
+}
+
+// list every pokemon in an egg group, pokemon can only breed with pokemon in a shared group
+func eggGroupCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter an egg group")
+		return nil
+	}
+
+	eggGroup, err := session.client.GetEggGroup(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Egg group:", englishName(eggGroup.Names, eggGroup.Name))
+	fmt.Printf("Pokemon (%d):\n", len(eggGroup.Pokemon_species))
+	printSpeciesList(eggGroup.Pokemon_species)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// the level ranges the pokemon is met at in a version, or in every version if version is ""
+func (encounter PokemonEncounter) LevelRanges(version string) []levelRange {
+	ranges := []levelRange{}
+	for _, details := range encounter.VersionDetails {
+		if version != "" && details.Version.Name != version {
+			continue
+		}
+		for _, detail := range details.Encounter_details {
+			ranges = append(ranges, levelRange{Min: detail.Min_level, Max: detail.Max_level})
+		}
+	}
+	return ranges
+}
+
+// pick an encounter with odds proportional to its encounter rate, roll is a number in [0, total rate)
+func pickEncounter(encounters []PokemonEncounter, version string, roll func(int) int) (PokemonEncounter, bool) {
+	total := 0
+	for _, encounter := range encounters {
+		total += encounter.Rate(version)
+	}
+	if total == 0 {
+		return PokemonEncounter{}, false
+	}
+	n := roll(total)
+	for _, encounter := range encounters {
+		n -= encounter.Rate(version)
+		if n < 0 {
+			return encounter, true
+		}
+	}
+	return encounters[len(encounters)-1], true
+}
+
+// encounter [location] [--version game] [--ball ball] [--berry berry] - meet a wild pokemon and try to catch it
+func encounterCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	if len(params) == 0 {
+		fmt.Println("Please enter a location")
+		return nil
+	}
+	client := session.client
+
+	options, ok := session.catchOptions(flags)
+	if !ok {
+		return nil
+	}
+
+	var area ExploreRequest
+	err := client.GetJSON(client.ResourceURL("location-area", params[0]), &area)
+	if err != nil {
+		return err
+	}
+	version, _ := flagValue(flags, "version")
+	version = strings.ToLower(version)
+	encounters := []PokemonEncounter{}
+	for _, encounter := range area.Pokemon_encounters {
+		if version == "" || encounter.InVersion(version) {
+			encounters = append(encounters, encounter)
+		}
+	}
+
+	encounter, ok := pickEncounter(encounters, version, rand.Intn)
+	if !ok {
+		fmt.Println("No wild pokemon around here")
+		return nil
+	}
+	pokemon, err := client.GetPokemon(encounter.Pokemon.Name)
+	if err != nil {
+		return err
+	}
+	options.Levels = encounter.LevelRanges(version)
+
+	fmt.Printf("A wild %s appeared in %s!\n", pokemon.Name, area.Name)
+	if nuzlocke := &session.profile.Nuzlocke; nuzlocke.Active() {
+		err = nuzlocke.Encounter(area.Name, &CaughtPokemon{Pokemon: pokemon})
+		if err != nil {
+			return err
+		}
+	}
+	if count := session.pokedex.Count(pokemon.Name); count > 0 {
+		fmt.Println("You already have", count, "of them")
+	}
+	return session.Catch(pokemon, options)
+}
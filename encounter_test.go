@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPickEncounterWeighted(t *testing.T) {
+	var encounters []PokemonEncounter
+	err := json.Unmarshal([]byte(`[
+		{"pokemon": {"name": "pidgey"}, "version_details": [{"max_chance": 70, "version": {"name": "red"}, "encounter_details": [{"min_level": 2, "max_level": 5}]}]},
+		{"pokemon": {"name": "pikachu"}, "version_details": [{"max_chance": 5, "version": {"name": "red"}, "encounter_details": [{"min_level": 3, "max_level": 5}]}]},
+		{"pokemon": {"name": "caterpie"}, "version_details": [{"max_chance": 25, "version": {"name": "blue"}, "encounter_details": [{"min_level": 3, "max_level": 5}]}]}
+	]`), &encounters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rolls 0-69 land on pidgey, 70-74 on pikachu and 75-99 on caterpie
+	for roll, expected := range map[int]string{0: "pidgey", 69: "pidgey", 70: "pikachu", 74: "pikachu", 75: "caterpie", 99: "caterpie"} {
+		encounter, ok := pickEncounter(encounters, "", func(total int) int {
+			if total != 100 {
+				t.Fatalf("expected a total rate of 100, got %d", total)
+			}
+			return roll
+		})
+		if !ok || encounter.Pokemon.Name != expected {
+			t.Errorf("roll %d: expected %v, got %v", roll, expected, encounter.Pokemon.Name)
+		}
+	}
+
+	// caterpie isn't in red, so its rate doesn't count
+	encounter, ok := pickEncounter(encounters, "red", func(total int) int {
+		if total != 75 {
+			t.Fatalf("expected a total rate of 75 in red, got %d", total)
+		}
+		return 74
+	})
+	if !ok || encounter.Pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %v", encounter.Pokemon.Name)
+	}
+
+	if ranges := encounters[0].LevelRanges("red"); len(ranges) != 1 || ranges[0] != (levelRange{Min: 2, Max: 5}) {
+		t.Errorf("unexpected level ranges %v", ranges)
+	}
+	if _, ok := pickEncounter(nil, "", func(int) int { return 0 }); ok {
+		t.Errorf("expected no encounter in an empty area")
+	}
+}
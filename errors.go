@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+// the requested resource doesn't exist, usually a misspelled name
+var ErrNotFound = errors.New("not found")
+
+// the api answered with something other than 200 OK
+type APIError struct {
+	StatusCode int
+	Status     string
+	URL        string
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		resource, name := resourceName(e.URL)
+		if name != "" {
+			return fmt.Sprintf("no %s named '%s'", resource, name)
+		}
+		return fmt.Sprintf("nothing found at %s", e.URL)
+	case e.StatusCode == http.StatusTooManyRequests:
+		return "the pokeapi is rate limiting requests, try again in a minute"
+	case e.StatusCode >= 500:
+		return fmt.Sprintf("the pokeapi is having trouble (%s), try again later", e.Status)
+	default:
+		return fmt.Sprintf("the pokeapi returned %s for %s", e.Status, e.URL)
+	}
+}
+
+// lets errors.Is(err, ErrNotFound) match a 404
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// pull a readable resource kind and name out of an api url
+// https://pokeapi.co/api/v2/location-area/canalave-city-area -> "location area", "canalave-city-area"
+func resourceName(rawURL string) (string, string) {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 {
+		return "", ""
+	}
+	resource := strings.ReplaceAll(segments[len(segments)-2], "-", " ")
+	return resource, segments[len(segments)-1]
+}
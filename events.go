@@ -0,0 +1,37 @@
+package main
+
+// something that happened in the game that other parts of it may want to react to
+type GameEvent struct {
+	// "catch", "evolve", "hatch", "trade", "explore" or "achievement"
+	Kind string
+	// the pokemon caught, for catches, or the pokemon that evolved, hatched or was traded for
+	Caught *CaughtPokemon
+	// the location and region explored, for explores
+	Location string
+	Region   string
+	// the achievement unlocked, for achievements
+	Achievement *Achievement
+}
+
+// hands every published event to every subscriber, in the order they subscribed
+type EventBus struct {
+	subscribers []func(GameEvent)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+func (bus *EventBus) Subscribe(subscriber func(GameEvent)) {
+	bus.subscribers = append(bus.subscribers, subscriber)
+}
+
+// deliver an event, a nil bus drops it so sessions built without one still work
+func (bus *EventBus) Publish(event GameEvent) {
+	if bus == nil {
+		return
+	}
+	for _, subscriber := range bus.subscribers {
+		subscriber(event)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type EvolutionChain struct {
+	Id    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// one species in an evolution chain and what it evolves into
+type ChainLink struct {
+	Species           NamedResource     `json:"species"`
+	Evolution_details []EvolutionDetail `json:"evolution_details"`
+	Evolves_to        []ChainLink       `json:"evolves_to"`
+}
+
+// the conditions for evolving into a species, most fields are unset for any one evolution
+type EvolutionDetail struct {
+	Trigger                 NamedResource  `json:"trigger"`
+	Min_level               *int           `json:"min_level"`
+	Item                    *NamedResource `json:"item"`
+	Held_item               *NamedResource `json:"held_item"`
+	Known_move              *NamedResource `json:"known_move"`
+	Known_move_type         *NamedResource `json:"known_move_type"`
+	Location                *NamedResource `json:"location"`
+	Trade_species           *NamedResource `json:"trade_species"`
+	Min_happiness           *int           `json:"min_happiness"`
+	Min_affection           *int           `json:"min_affection"`
+	Min_beauty              *int           `json:"min_beauty"`
+	Gender                  *int           `json:"gender"`
+	Time_of_day             string         `json:"time_of_day"`
+	Needs_overworld_rain    bool           `json:"needs_overworld_rain"`
+	Turn_upside_down        bool           `json:"turn_upside_down"`
+	Relative_physical_stats *int           `json:"relative_physical_stats"`
+}
+
+// fetch the evolution chain a species belongs to
+func (client *Client) GetEvolutionChain(species PokemonSpecies) (EvolutionChain, error) {
+	var chain EvolutionChain
+	if species.Evolution_chain.Url == "" {
+		return chain, fmt.Errorf("%s has no evolution chain", species.Name)
+	}
+	err := client.GetJSON(species.Evolution_chain.Url, &chain)
+	return chain, err
+}
+
+// human readable conditions, like "level 16" or "use fire-stone, day"
+func (detail EvolutionDetail) String() string {
+	conditions := []string{}
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.Min_level != nil {
+			conditions = append(conditions, fmt.Sprintf("level %d", *detail.Min_level))
+		} else {
+			conditions = append(conditions, "level up")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			conditions = append(conditions, "use "+detail.Item.Name)
+		} else {
+			conditions = append(conditions, "use an item")
+		}
+	case "trade":
+		if detail.Trade_species != nil {
+			conditions = append(conditions, "trade for "+detail.Trade_species.Name)
+		} else {
+			conditions = append(conditions, "trade")
+		}
+	default:
+		conditions = append(conditions, strings.ReplaceAll(detail.Trigger.Name, "-", " "))
+	}
+
+	if detail.Held_item != nil {
+		conditions = append(conditions, "holding "+detail.Held_item.Name)
+	}
+	if detail.Min_happiness != nil {
+		conditions = append(conditions, fmt.Sprintf("happiness %d", *detail.Min_happiness))
+	}
+	if detail.Min_affection != nil {
+		conditions = append(conditions, fmt.Sprintf("affection %d", *detail.Min_affection))
+	}
+	if detail.Min_beauty != nil {
+		conditions = append(conditions, fmt.Sprintf("beauty %d", *detail.Min_beauty))
+	}
+	if detail.Known_move != nil {
+		conditions = append(conditions, "knowing "+detail.Known_move.Name)
+	}
+	if detail.Known_move_type != nil {
+		conditions = append(conditions, "knowing a "+detail.Known_move_type.Name+" move")
+	}
+	if detail.Location != nil {
+		conditions = append(conditions, "at "+detail.Location.Name)
+	}
+	if detail.Time_of_day != "" {
+		conditions = append(conditions, detail.Time_of_day)
+	}
+	if detail.Gender != nil {
+		if *detail.Gender == 1 {
+			conditions = append(conditions, "female")
+		} else {
+			conditions = append(conditions, "male")
+		}
+	}
+	if detail.Relative_physical_stats != nil {
+		switch *detail.Relative_physical_stats {
+		case 1:
+			conditions = append(conditions, "attack > defense")
+		case -1:
+			conditions = append(conditions, "attack < defense")
+		default:
+			conditions = append(conditions, "attack = defense")
+		}
+	}
+	if detail.Needs_overworld_rain {
+		conditions = append(conditions, "while raining")
+	}
+	if detail.Turn_upside_down {
+		conditions = append(conditions, "console upside down")
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// print the chain as a tree, each evolution with the conditions for it
+func printChainLink(link ChainLink, prefix string, last bool, root bool) {
+	line := link.Species.Name
+	conditions := []string{}
+	for _, detail := range link.Evolution_details {
+		conditions = append(conditions, detail.String())
+	}
+	if len(conditions) > 0 {
+		line += " (" + strings.Join(conditions, " or ") + ")"
+	}
+
+	childPrefix := prefix
+	if root {
+		fmt.Println(line)
+	} else if last {
+		fmt.Println(prefix + "└─ " + line)
+		childPrefix += "   "
+	} else {
+		fmt.Println(prefix + "├─ " + line)
+		childPrefix += "│  "
+	}
+
+	for i, next := range link.Evolves_to {
+		printChainLink(next, childPrefix, i == len(link.Evolves_to)-1, false)
+	}
+}
+
+// show the whole evolution chain of a pokemon as a tree
+func evolutionCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	species, err := session.client.GetSpeciesOf(apiName(params))
+	if err != nil {
+		return err
+	}
+	chain, err := session.client.GetEvolutionChain(species)
+	if err != nil {
+		return err
+	}
+
+	if len(chain.Chain.Evolves_to) == 0 {
+		fmt.Println(species.Name, "does not evolve")
+		return nil
+	}
+	printChainLink(chain.Chain, "", true, true)
+	return nil
+}
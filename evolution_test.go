@@ -0,0 +1,26 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEvolutionDetailString(t *testing.T) {
+	cases := map[string]string{
+		`{"trigger": {"name": "level-up"}, "min_level": 16}`:                               "level 16",
+		`{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}`:             "use thunder-stone",
+		`{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}`:              "trade, holding metal-coat",
+		`{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}`:    "level up, happiness 160, day",
+		`{"trigger": {"name": "level-up"}, "min_level": 20, "relative_physical_stats": 1}`: "level 20, attack > defense",
+	}
+	for raw, expected := range cases {
+		var detail EvolutionDetail
+		err := json.Unmarshal([]byte(raw), &detail)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detail.String() != expected {
+			t.Errorf("expected %q, got %q", expected, detail.String())
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// the stones the shop sells for evolving pokemon, by item name
+var evolutionStones = []string{
+	"fire-stone", "water-stone", "thunder-stone", "leaf-stone", "moon-stone",
+	"sun-stone", "shiny-stone", "dusk-stone", "dawn-stone", "ice-stone",
+}
+
+// happiness goes from 0 to 255, pokemon of species that don't say start at 70
+const (
+	defaultHappiness = 70
+	maxHappiness     = 255
+)
+
+// the happiness a pokemon of the species starts with
+func (species PokemonSpecies) StartingHappiness() int {
+	if species.Base_happiness == nil {
+		return defaultHappiness
+	}
+	return *species.Base_happiness
+}
+
+// make a pokemon happier, up to the most it can be
+func (caught *CaughtPokemon) GainHappiness(amount int) {
+	caught.Happiness += amount
+	if caught.Happiness > maxHappiness {
+		caught.Happiness = maxHappiness
+	}
+}
+
+// the link for a species somewhere in the chain, false if it isn't in it
+func (link ChainLink) Find(species string) (ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.Evolves_to {
+		if found, ok := next.Find(species); ok {
+			return found, true
+		}
+	}
+	return ChainLink{}, false
+}
+
+// "day" from 6 in the morning until 6 in the evening, "night" the rest of the time
+func timeOfDay(now time.Time) string {
+	if hour := now.Hour(); hour >= 6 && hour < 18 {
+		return "day"
+	}
+	return "night"
+}
+
+// what keeps a pokemon from evolving this way right now, "" if nothing does
+// moves are the ones it knows and items what's in the bag
+func evolutionBlocker(detail EvolutionDetail, caught *CaughtPokemon, moves []string, items map[string]int, now time.Time) string {
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.Min_level != nil && caught.Level < *detail.Min_level {
+			return fmt.Sprintf("needs to reach level %d", *detail.Min_level)
+		}
+	case "use-item":
+		if detail.Item == nil {
+			return "needs an item"
+		}
+		if items[detail.Item.Name] <= 0 {
+			return "needs a " + detail.Item.Name
+		}
+	case "trade":
+		return "evolves when traded"
+	default:
+		return "evolves by " + strings.ReplaceAll(detail.Trigger.Name, "-", " ") + ", which can't be done here"
+	}
+
+	if detail.Min_happiness != nil && caught.Happiness < *detail.Min_happiness {
+		return fmt.Sprintf("needs happiness %d, it has %d", *detail.Min_happiness, caught.Happiness)
+	}
+	if detail.Known_move != nil && !knowsMove(moves, detail.Known_move.Name) {
+		return "needs to know " + detail.Known_move.Name
+	}
+	if detail.Time_of_day != "" && detail.Time_of_day != timeOfDay(now) {
+		return "only evolves at " + detail.Time_of_day
+	}
+	// the rest depend on things the game doesn't keep track of
+	unsupported := detail.Held_item != nil || detail.Known_move_type != nil || detail.Location != nil ||
+		detail.Trade_species != nil || detail.Min_affection != nil || detail.Min_beauty != nil || detail.Gender != nil ||
+		detail.Needs_overworld_rain || detail.Turn_upside_down || detail.Relative_physical_stats != nil
+	if unsupported {
+		return "needs " + detail.String() + ", which can't be done here"
+	}
+	return ""
+}
+
+// one species a caught pokemon can evolve into and whether it can right now
+type EvolutionOption struct {
+	Into   string
+	Detail EvolutionDetail
+	// "" if the pokemon can evolve this way now
+	Blocker string
+}
+
+// every way a caught pokemon can evolve, the first way that works for each species
+func (session *Session) evolutionOptions(caught *CaughtPokemon, now time.Time) ([]EvolutionOption, error) {
+	client := session.client
+	species, err := client.GetSpecies(caught.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := client.GetEvolutionChain(species)
+	if err != nil {
+		return nil, err
+	}
+	link, ok := chain.Chain.Find(species.Name)
+	if !ok {
+		return nil, fmt.Errorf("%s isn't in its own evolution chain", species.Name)
+	}
+	moves, err := client.Moveset(caught)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []EvolutionOption{}
+	for _, next := range link.Evolves_to {
+		option := EvolutionOption{Into: next.Species.Name}
+		for i, detail := range next.Evolution_details {
+			blocker := evolutionBlocker(detail, caught, moves, session.inventory.Items, now)
+			if i == 0 || blocker == "" {
+				option.Detail, option.Blocker = detail, blocker
+			}
+			if blocker == "" {
+				break
+			}
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// turn a caught pokemon into another species, it keeps its id, nickname, ivs, evs, level and moves
+func (session *Session) evolveInto(caught *CaughtPokemon, species string) error {
+	pokemon, err := session.client.ResolvePokemon(species)
+	if err != nil {
+		return err
+	}
+	from := caught.DisplayName()
+	before := caught.Name
+	caught.Pokemon = pokemon
+	caught.Form = pokemon.FormName()
+	fmt.Printf("Congratulations! %s evolved into %s!\n", from, pokemon.Name)
+	session.events.Publish(GameEvent{Kind: "evolve", Caught: caught})
+	return session.Record(HistoryEvent{Kind: "evolve", Pokemon: before, Outcome: pokemon.Name})
+}
+
+// evolve [pokemon] [species] - evolve a caught pokemon that meets the requirements
+func evolveCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	options, err := session.evolutionOptions(caught, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(options) == 0 {
+		fmt.Println(caught.Species.Name, "does not evolve")
+		return nil
+	}
+
+	into := ""
+	if len(params) > 1 {
+		into = apiName(params[1:])
+		found := false
+		for _, option := range options {
+			found = found || option.Into == into
+		}
+		if !found {
+			return fmt.Errorf("%s doesn't evolve into %s", caught.Species.Name, into)
+		}
+	}
+	ready := []EvolutionOption{}
+	for _, option := range options {
+		if into != "" && option.Into != into {
+			continue
+		}
+		if option.Blocker == "" {
+			ready = append(ready, option)
+		} else {
+			fmt.Printf("%s can't evolve into %s yet, it %s\n", caught.DisplayName(), option.Into, option.Blocker)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	if len(ready) > 1 {
+		names := []string{}
+		for _, option := range ready {
+			names = append(names, option.Into)
+		}
+		fmt.Println(caught.DisplayName(), "can evolve into", strings.Join(names, ", ")+", choose one with evolve", params[0], "[species]")
+		return nil
+	}
+
+	option := ready[0]
+	if !session.Confirm(fmt.Sprintf("Evolve %s into %s (%s)?", caught.DisplayName(), option.Into, option.Detail)) {
+		fmt.Println(caught.DisplayName(), "did not evolve")
+		return nil
+	}
+	if option.Detail.Trigger.Name == "use-item" {
+		err = session.inventory.UseItem(option.Detail.Item.Name)
+		if err != nil {
+			return err
+		}
+	}
+	err = session.evolveInto(caught, option.Into)
+	if err != nil {
+		return err
+	}
+	return session.Save()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEvolutionBlocker(t *testing.T) {
+	var chain EvolutionChain
+	err := json.Unmarshal([]byte(`{"chain": {"species": {"name": "eevee"}, "evolves_to": [
+		{"species": {"name": "vaporeon"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}]},
+		{"species": {"name": "espeon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}]},
+		{"species": {"name": "sylveon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}}]}
+	]}}`), &chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, ok := chain.Chain.Find("eevee")
+	if !ok || len(link.Evolves_to) != 3 {
+		t.Fatalf("expected eevee with 3 evolutions, got %v", link)
+	}
+	if _, ok := chain.Chain.Find("pikachu"); ok {
+		t.Errorf("expected pikachu not to be in eevee's chain")
+	}
+	stone := link.Evolves_to[0].Evolution_details[0]
+	happy := link.Evolves_to[1].Evolution_details[0]
+	fairy := link.Evolves_to[2].Evolution_details[0]
+
+	eevee := &CaughtPokemon{Pokemon: Pokemon{Name: "eevee"}, Level: 20, Happiness: 200}
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if blocker := evolutionBlocker(stone, eevee, nil, nil, noon); blocker != "needs a water-stone" {
+		t.Errorf("expected a missing stone, got %q", blocker)
+	}
+	if blocker := evolutionBlocker(stone, eevee, nil, map[string]int{"water-stone": 1}, noon); blocker != "" {
+		t.Errorf("expected the stone to work, got %q", blocker)
+	}
+	if blocker := evolutionBlocker(happy, eevee, nil, nil, noon); blocker != "" {
+		t.Errorf("expected a happy eevee to evolve by day, got %q", blocker)
+	}
+	if blocker := evolutionBlocker(happy, eevee, nil, nil, midnight); blocker != "only evolves at day" {
+		t.Errorf("expected espeon to need daytime, got %q", blocker)
+	}
+	eevee.Happiness = defaultHappiness
+	if blocker := evolutionBlocker(happy, eevee, nil, nil, noon); blocker == "" {
+		t.Errorf("expected an unhappy eevee not to evolve")
+	}
+	if blocker := evolutionBlocker(fairy, eevee, nil, nil, noon); blocker == "" {
+		t.Errorf("expected move type evolutions to be unsupported")
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// how much experience each level takes for the pokemon with a growth rate
+type GrowthRate struct {
+	Name   string `json:"name"`
+	Levels []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+// fetch a growth rate, like "medium-slow"
+func (client *Client) GetGrowthRate(name string) (GrowthRate, error) {
+	var rate GrowthRate
+	err := client.GetJSON(client.ResourceURL("growth-rate", name), &rate)
+	return rate, err
+}
+
+// the total experience a pokemon needs to reach a level
+func (rate GrowthRate) ExperienceAt(level int) int {
+	for _, threshold := range rate.Levels {
+		if threshold.Level == level {
+			return threshold.Experience
+		}
+	}
+	return 0
+}
+
+// the level a pokemon with this much total experience is at
+func (rate GrowthRate) LevelFor(experience int) int {
+	level := 1
+	for _, threshold := range rate.Levels {
+		if experience >= threshold.Experience && threshold.Level > level {
+			level = threshold.Level
+		}
+	}
+	return level
+}
+
+// the experience for knocking out a pokemon, from the games before gen 5: base experience × level / 7,
+// half as much again when it belongs to a trainer
+func battleExperience(defeated *Battler, trainer bool) int {
+	experience := defeated.BaseExperience * defeated.Level / 7
+	if trainer {
+		experience = experience * 3 / 2
+	}
+	return max1(experience)
+}
+
+// the moves learned by leveling up to exactly level
+func (learnset Learnset) LearnedAt(level int) []string {
+	moves := []string{}
+	for _, move := range learnset.Moves {
+		if at, ok := move.LevelLearned(); ok && at == level {
+			moves = append(moves, move.Move.Name)
+		}
+	}
+	sort.Strings(moves)
+	return moves
+}
+
+// give a caught pokemon experience, leveling it up as far as the experience goes and offering the moves it learns on the way
+func (session *Session) gainExperience(caught *CaughtPokemon, experience int) error {
+	client := session.client
+	species, err := client.GetSpecies(caught.SpeciesName())
+	if err != nil {
+		return err
+	}
+	rate, err := client.GetGrowthRate(species.Growth_rate.Name)
+	if err != nil {
+		return err
+	}
+	// pokemon from before experience was counted start at the bottom of their level
+	if start := rate.ExperienceAt(caught.Level); caught.Experience < start {
+		caught.Experience = start
+	}
+	if caught.Level >= maxLevel {
+		return nil
+	}
+	caught.Experience += experience
+	fmt.Printf("%s gained %d experience\n", caught.DisplayName(), experience)
+
+	level := rate.LevelFor(caught.Experience)
+	if level <= caught.Level {
+		return nil
+	}
+	learnset, err := client.GetLearnset(caught.Name)
+	if err != nil {
+		return err
+	}
+	// the moves it knew as a wild pokemon are its own from now on, so new ones are learned on top of them
+	moves, err := client.Moveset(caught)
+	if err != nil {
+		return err
+	}
+	caught.Moves = moves
+	for caught.Level < level && caught.Level < maxLevel {
+		caught.Level++
+		fmt.Printf("%s grew to level %d!\n", caught.DisplayName(), caught.Level)
+		for _, move := range learnset.LearnedAt(caught.Level) {
+			if knowsMove(caught.Moves, move) {
+				continue
+			}
+			_, err = session.teachMove(caught, move)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBattleExperience(t *testing.T) {
+	defeated := testBattler("geodude", 14, 20)
+	defeated.BaseExperience = 60
+	if experience := battleExperience(defeated, false); experience != 120 {
+		t.Errorf("expected 120 experience, got %d", experience)
+	}
+	if experience := battleExperience(defeated, true); experience != 180 {
+		t.Errorf("expected 180 experience from a trainer's pokemon, got %d", experience)
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/pokemon-species/pikachu":
+			fmt.Fprint(w, `{"name": "pikachu", "growth_rate": {"name": "medium"}}`)
+		case "/growth-rate/medium":
+			// medium fast is level cubed
+			levels := []string{}
+			for level := 1; level <= 10; level++ {
+				levels = append(levels, fmt.Sprintf(`{"level": %d, "experience": %d}`, level, level*level*level))
+			}
+			fmt.Fprintf(w, `{"name": "medium", "levels": [%s]}`, strings.Join(levels, ","))
+		case "/pokemon/pikachu":
+			fmt.Fprint(w, `{"name": "pikachu", "moves": [
+				{"move": {"name": "thunder-shock"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}]},
+				{"move": {"name": "quick-attack"}, "version_group_details": [{"level_learned_at": 7, "move_learn_method": {"name": "level-up"}}]}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	session := &Session{client: client, input: bufio.NewScanner(strings.NewReader(""))}
+	caught := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu", Species: NamedResource{Name: "pikachu"}}, Level: 5}
+	// 5³ to start with and 8³ = 512 is level 8
+	if err := session.gainExperience(caught, 400); err != nil {
+		t.Fatal(err)
+	}
+	if caught.Level != 8 || caught.Experience != 525 {
+		t.Errorf("expected level 8 with 525 experience, got level %d with %d", caught.Level, caught.Experience)
+	}
+	if strings.Join(caught.Moves, ",") != "thunder-shock,quick-attack" {
+		t.Errorf("expected quick attack to be learned at level 7, got %v", caught.Moves)
+	}
+}
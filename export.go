@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the ways the pokedex can be exported, by --format
+var exporters = map[string]func(session *Session, w io.Writer) error{
+	"csv":      (*Session).exportCSV,
+	"html":     (*Session).exportHTML,
+	"json":     (*Session).exportJSON,
+	"markdown": (*Session).exportMarkdown,
+}
+
+// the caught pokemon's actual stats by stat, from its level, ivs, evs and nature
+func (session *Session) caughtStats(caught *CaughtPokemon) (map[string]int, error) {
+	nature := Nature{}
+	if caught.Nature != "" {
+		var err error
+		nature, err = session.client.GetNature(caught.Nature)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stats := make(map[string]int)
+	for _, stat := range statOrder {
+		stats[stat] = caught.Stat(stat, nature)
+	}
+	return stats, nil
+}
+
+// one row per caught pokemon, in the order they joined the pokedex
+func (session *Session) exportCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{"id", "pokedex_number", "name", "nickname", "form", "types", "level"}, statOrder...)
+	err := writer.Write(append(header, "shiny", "caught_on"))
+	if err != nil {
+		return err
+	}
+	for _, caught := range session.pokedex.Pokemon {
+		stats, err := session.caughtStats(caught)
+		if err != nil {
+			return err
+		}
+		row := []string{strconv.Itoa(caught.InstanceID), strconv.Itoa(caught.Id), caught.Name, caught.Nickname, caught.Form,
+			caught.TypeNames(), strconv.Itoa(caught.Level)}
+		for _, stat := range statOrder {
+			row = append(row, strconv.Itoa(stats[stat]))
+		}
+		err = writer.Write(append(row, strconv.FormatBool(caught.Shiny), caught.CaughtOn))
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// the whole save, pokedex, bag, profile and teams, with its version so import can migrate it
+func (session *Session) exportJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(session.saveData())
+}
+
+// import [file] - replace your save with one written by export --format json, older versions are migrated
+func importCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a file exported with export --format json")
+		return nil
+	}
+	data, err := os.ReadFile(params[0])
+	if err != nil {
+		return err
+	}
+	save, err := decodeSave(data)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", params[0], err)
+	}
+	if !session.Confirm(fmt.Sprintf("Replace your pokedex, bag and profile with %s's save of %d pokemon?", save.Profile.Name, len(save.Pokedex.Pokemon))) {
+		return nil
+	}
+	session.pokedex = save.Pokedex
+	session.inventory = save.Inventory
+	session.profile = save.Profile
+	session.teams = save.Teams
+	fmt.Printf("Imported %s's save\n", save.Profile.Name)
+	return session.Save()
+}
+
+// export --format f [file] - write the pokedex out for other tools, to the screen without a file
+func exportCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	format, ok := flagValue(flags, "format")
+	if !ok {
+		format = "csv"
+	}
+	export, ok := exporters[strings.ToLower(format)]
+	if !ok {
+		formats := []string{}
+		for name := range exporters {
+			formats = append(formats, name)
+		}
+		sort.Strings(formats)
+		return fmt.Errorf("unknown format %q, choose one of: %s", format, strings.Join(formats, ", "))
+	}
+	if len(params) == 0 {
+		return export(session, os.Stdout)
+	}
+
+	// write to a temp file next to the target and rename it, so a failed export leaves an earlier one alone
+	tmp, err := os.CreateTemp(filepath.Dir(params[0]), ".export-*")
+	if err != nil {
+		return err
+	}
+	err = export(session, tmp)
+	if err == nil {
+		err = tmp.Chmod(0o644)
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), params[0])
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	fmt.Printf("Exported %d pokemon to %s\n", len(session.pokedex.Pokemon), params[0])
+	return nil
+}
+
+// "Sparky (pikachu)", the species after a nickname
+func caughtLabel(caught *CaughtPokemon) string {
+	if caught.Nickname != "" {
+		return fmt.Sprintf("%s (%s)", caught.Nickname, caught.Name)
+	}
+	return caught.Name
+}
+
+// text for a markdown table cell, a | would end the cell early
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// a collection report for player: overall completion, highlights, then a table of caught pokemon per generation
+func markdownReport(player string, pokedex *Pokedex, generations []Generation) string {
+	var report strings.Builder
+	caught := pokedex.SpeciesCaught()
+	fmt.Fprintf(&report, "# %s's Pokedex\n\n", markdownCell(player))
+	completions := generationCompletion(generations, caught)
+	total := Completion{Name: "Overall"}
+	for _, completion := range completions {
+		total.Caught += completion.Caught
+		total.Total += completion.Total
+	}
+	fmt.Fprintf(&report, "%d pokemon caught, %d species of %d (%.0f%% complete)\n\n", len(pokedex.Pokemon), len(caught), total.Total, total.Percent())
+
+	// the generation each species was introduced in
+	generationOf := make(map[string]int)
+	for i, generation := range generations {
+		for _, species := range generation.Pokemon_species {
+			generationOf[species.Name] = i
+		}
+	}
+	byGeneration := make([][]*CaughtPokemon, len(generations))
+	other := []*CaughtPokemon{}
+	for _, c := range pokedex.Pokemon {
+		i, ok := generationOf[c.SpeciesName()]
+		if !ok {
+			other = append(other, c)
+			continue
+		}
+		byGeneration[i] = append(byGeneration[i], c)
+	}
+
+	report.WriteString("## Highlights\n\n")
+	highlights := markdownHighlights(pokedex, regionCompletion(generations, caught))
+	if len(highlights) == 0 {
+		report.WriteString("Nothing caught yet\n")
+	}
+	for _, highlight := range highlights {
+		fmt.Fprintf(&report, "- %s\n", highlight)
+	}
+
+	for i, generation := range generations {
+		completion := completions[i]
+		fmt.Fprintf(&report, "\n## %s (%s)\n\n%d/%d species, %.0f%%\n", completion.Name, regionTitle(generation.Main_region.Name),
+			completion.Caught, completion.Total, completion.Percent())
+		writeMarkdownTable(&report, byGeneration[i])
+	}
+	if len(other) > 0 {
+		report.WriteString("\n## Other\n")
+		writeMarkdownTable(&report, other)
+	}
+	return report.String()
+}
+
+// lines worth calling out: shinies, the highest level, favorites, the latest catch and completed regions
+func markdownHighlights(pokedex *Pokedex, regions []Completion) []string {
+	highlights := []string{}
+	shinies, favorites := []string{}, []string{}
+	var highest, latest *CaughtPokemon
+	for _, c := range pokedex.Pokemon {
+		if c.Shiny {
+			shinies = append(shinies, caughtLabel(c))
+		}
+		if c.Favorite {
+			favorites = append(favorites, caughtLabel(c))
+		}
+		if highest == nil || c.Level > highest.Level {
+			highest = c
+		}
+		// dates sort as text, and later pokemon win ties
+		if c.CaughtOn != "" && (latest == nil || c.CaughtOn >= latest.CaughtOn) {
+			latest = c
+		}
+	}
+	if len(shinies) > 0 {
+		highlights = append(highlights, fmt.Sprintf("%s %d shiny: %s", shinyMarker, len(shinies), markdownCell(strings.Join(shinies, ", "))))
+	}
+	if highest != nil {
+		highlights = append(highlights, fmt.Sprintf("Highest level: %s at level %d", markdownCell(caughtLabel(highest)), highest.Level))
+	}
+	if len(favorites) > 0 {
+		highlights = append(highlights, "Favorites: "+markdownCell(strings.Join(favorites, ", ")))
+	}
+	if latest != nil {
+		highlights = append(highlights, fmt.Sprintf("Latest catch: %s on %s", markdownCell(caughtLabel(latest)), latest.CaughtOn))
+	}
+	for _, region := range regions {
+		if region.Complete() {
+			highlights = append(highlights, fmt.Sprintf("Completed the %s pokedex", region.Name))
+		}
+	}
+	return highlights
+}
+
+func writeMarkdownTable(report *strings.Builder, pokemon []*CaughtPokemon) {
+	if len(pokemon) == 0 {
+		report.WriteString("\nNone caught yet\n")
+		return
+	}
+	sorted := append([]*CaughtPokemon{}, pokemon...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Id < sorted[j].Id
+	})
+	report.WriteString("\n| # | Pokemon | Types | Level | Shiny | Caught |\n|---|---|---|---|---|---|\n")
+	for _, c := range sorted {
+		shiny := ""
+		if c.Shiny {
+			shiny = shinyMarker
+		}
+		fmt.Fprintf(report, "| %d | %s | %s | %d | %s | %s |\n", c.Id, markdownCell(caughtLabel(c)), c.TypeNames(), c.Level, shiny, c.CaughtOn)
+	}
+}
+
+// the markdown report, generations come from the api
+func (session *Session) exportMarkdown(w io.Writer) error {
+	generations, err := session.client.GetGenerations()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, markdownReport(session.profile.Name, session.pokedex, generations))
+	return err
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportCSV(t *testing.T) {
+	pokedex := NewPokedex()
+	pikachu := &CaughtPokemon{Nickname: "Sparky, Jr.", Level: 12, Shiny: true, CaughtOn: "2024-03-01", IVs: map[string]int{"speed": 31}}
+	err := json.Unmarshal([]byte(`{"id": 25, "name": "pikachu", "types": [{"type": {"name": "electric"}}],
+		"stats": [{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 90, "stat": {"name": "speed"}}]}`), &pikachu.Pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pokedex.Add(pikachu)
+	pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 16, Name: "pidgey"}, Level: 3})
+	session := &Session{pokedex: pokedex}
+
+	var out bytes.Buffer
+	err = session.exportCSV(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || len(rows[0]) != 15 || rows[0][0] != "id" || rows[0][14] != "caught_on" {
+		t.Fatalf("unexpected csv %v", rows)
+	}
+	want := []string{"1", "25", "pikachu", "Sparky, Jr.", "", "electric", "12", strconv.Itoa(pikachu.Stat("hp", Nature{}))}
+	for i, value := range want {
+		if rows[1][i] != value {
+			t.Errorf("expected %s to be %q, got %q", rows[0][i], value, rows[1][i])
+		}
+	}
+	if rows[1][12] != strconv.Itoa(pikachu.Stat("speed", Nature{})) || rows[1][13] != "true" || rows[1][14] != "2024-03-01" {
+		t.Errorf("unexpected row %v", rows[1])
+	}
+	if rows[2][2] != "pidgey" || rows[2][13] != "false" || rows[2][14] != "" {
+		t.Errorf("unexpected row %v", rows[2])
+	}
+}
+
+func TestMarkdownReport(t *testing.T) {
+	generations := []Generation{
+		{Name: "generation-i", Names: []LocalizedName{{Name: "Generation I", Language: NamedResource{Name: "en"}}}, Main_region: NamedResource{Name: "kanto"},
+			Pokemon_species: []NamedResource{{Name: "pikachu"}, {Name: "pidgey"}}},
+		{Name: "generation-ii", Names: []LocalizedName{{Name: "Generation II", Language: NamedResource{Name: "en"}}}, Main_region: NamedResource{Name: "johto"},
+			Pokemon_species: []NamedResource{{Name: "chikorita"}}},
+	}
+	pokedex := NewPokedex()
+	pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 25, Name: "pikachu", Species: NamedResource{Name: "pikachu"}}, Nickname: "Volt|Bolt", Level: 30, Shiny: true, CaughtOn: "2024-03-02"})
+	pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 16, Name: "pidgey", Species: NamedResource{Name: "pidgey"}}, Level: 4, Favorite: true, CaughtOn: "2024-03-05"})
+
+	report := markdownReport("Ash", pokedex, generations)
+	for _, want := range []string{
+		"# Ash's Pokedex\n",
+		"2 pokemon caught, 2 species of 3 (67% complete)",
+		"- ✨ 1 shiny: Volt\\|Bolt (pikachu)\n",
+		"- Highest level: Volt\\|Bolt (pikachu) at level 30\n",
+		"- Favorites: pidgey\n",
+		"- Latest catch: pidgey on 2024-03-05\n",
+		"- Completed the Kanto pokedex\n",
+		"## Generation I (Kanto)\n\n2/2 species, 100%\n",
+		"| 16 | pidgey |  | 4 |  | 2024-03-05 |\n| 25 | Volt\\|Bolt (pikachu) |",
+		"## Generation II (Johto)\n\n0/1 species, 0%\n\nNone caught yet\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected the report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	var sprite bytes.Buffer
+	if err := png.Encode(&sprite, img); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/shiny/25.png":
+			w.Write(sprite.Bytes())
+		case "/default/1.png":
+			w.Write([]byte(`<script>alert("not a sprite")</script>`))
+		case "/default/4.png":
+			http.NotFound(w, r)
+		default:
+			t.Errorf("expected the shiny sprite, got %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	pokedex := NewPokedex()
+	pikachu := &CaughtPokemon{Nickname: "<Volt>", Level: 12, Shiny: true, CaughtOn: "2024-03-01"}
+	err := json.Unmarshal([]byte(`{"id": 25, "name": "pikachu", "types": [{"type": {"name": "electric"}}],
+		"stats": [{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 90, "stat": {"name": "speed"}}]}`), &pikachu.Pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pikachu.Sprites.Front_default = server.URL + "/default/25.png"
+	pikachu.Sprites.Front_shiny = server.URL + "/shiny/25.png"
+	pokedex.Add(pikachu)
+	// sprites that aren't images or can't be fetched leave their cards without one
+	for id, name := range map[int]string{1: "bulbasaur", 4: "charmander"} {
+		caught := &CaughtPokemon{Level: 5}
+		caught.Id, caught.Name = id, name
+		caught.Sprites.Front_default = fmt.Sprintf("%s/default/%d.png", server.URL, id)
+		pokedex.Add(caught)
+	}
+	session := &Session{pokedex: pokedex, profile: &Profile{Name: "Ash"}, client: NewClient(NewCache(time.Minute))}
+
+	var out bytes.Buffer
+	err = session.exportHTML(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := out.String()
+	for _, want := range []string{
+		"<title>Ash's Pokedex</title>",
+		`<img src="data:image/png;base64,` + base64.StdEncoding.EncodeToString(sprite.Bytes()) + `" alt="pikachu">`,
+		"#1 &lt;Volt&gt; (pikachu) ✨",
+		"electric, level 12, caught 2024-03-01",
+		// hp is the highest stat so its bar is full
+		`<span class="name">HP</span><span class="value">` + strconv.Itoa(pikachu.Stat("hp", Nature{})) + `</span><div class="bar"><div class="fill" style="width: 100%">`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected the page to contain %q, got:\n%s", want, page)
+		}
+	}
+	if strings.Count(page, "<img") != 1 || strings.Contains(page, "not a sprite") {
+		t.Errorf("expected only pikachu to have a sprite, got:\n%s", page)
+	}
+	if !strings.Contains(page, "bulbasaur") || !strings.Contains(page, "charmander") {
+		t.Errorf("expected cards for the pokemon without sprites, got:\n%s", page)
+	}
+}
+
+func TestExportKeepsFileOnError(t *testing.T) {
+	exporters["broken"] = func(session *Session, w io.Writer) error {
+		fmt.Fprintln(w, "half an export")
+		return errors.New("the api is down")
+	}
+	defer delete(exporters, "broken")
+	session := &Session{pokedex: NewPokedex()}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pokedex.csv")
+	err := os.WriteFile(path, []byte("last week's export\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := exportCommand(session, []string{"--format", "broken", path}); err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "last week's export\n" {
+		t.Errorf("expected the earlier export to be left alone, got %q", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the temp file to be removed, got %d files", len(entries))
+	}
+
+	if err := exportCommand(session, []string{"--format", "csv", path}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "id,") {
+		t.Errorf("expected the csv to replace the earlier export, got %q", data)
+	}
+}
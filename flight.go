@@ -0,0 +1,42 @@
+package main
+
+import "sync"
+
+// coalesces concurrent calls for the same key into one, like x/sync/singleflight but only for response bodies
+type flightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	wg  sync.WaitGroup
+	val []byte
+	err error
+}
+
+// run fn for key unless a call for key is already running, in which case wait for it and share its result
+// shared reports whether the result came from another caller's call
+func (group *flightGroup) Do(key string, fn func() ([]byte, error)) (val []byte, err error, shared bool) {
+	group.mutex.Lock()
+	if group.calls == nil {
+		group.calls = make(map[string]*flightCall)
+	}
+	if call, ok := group.calls[key]; ok {
+		group.mutex.Unlock()
+		call.wg.Wait()
+		return call.val, call.err, true
+	}
+	call := &flightCall{}
+	call.wg.Add(1)
+	group.calls[key] = call
+	group.mutex.Unlock()
+
+	call.val, call.err = fn()
+	call.wg.Done()
+
+	group.mutex.Lock()
+	delete(group.calls, key)
+	group.mutex.Unlock()
+
+	return call.val, call.err, false
+}
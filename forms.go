@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// fetch a pokemon by pokemon or species name, a species like "deoxys" becomes its default form "deoxys-normal"
+func (client *Client) ResolvePokemon(name string) (Pokemon, error) {
+	pokemon, err := client.GetPokemon(name)
+	if !errors.Is(err, ErrNotFound) {
+		return pokemon, err
+	}
+	species, speciesErr := client.GetSpecies(name)
+	if speciesErr != nil {
+		return pokemon, err
+	}
+	return client.GetPokemon(species.DefaultPokemon())
+}
+
+// fetch a species by species or form name, "raichu-alola" gives raichu
+func (client *Client) GetSpeciesOf(name string) (PokemonSpecies, error) {
+	species, err := client.GetSpecies(name)
+	if !errors.Is(err, ErrNotFound) {
+		return species, err
+	}
+	pokemon, pokemonErr := client.GetPokemon(name)
+	if pokemonErr != nil {
+		return species, err
+	}
+	return client.GetSpecies(pokemon.Species.Name)
+}
+
+// the form part of a pokemon's name, like "alola" for raichu-alola or "mega-x" for charizard-mega-x
+// empty for the default form of a species
+func (pokemon Pokemon) FormName() string {
+	if pokemon.Is_default || !strings.HasPrefix(pokemon.Name, pokemon.Species.Name+"-") {
+		return ""
+	}
+	return strings.TrimPrefix(pokemon.Name, pokemon.Species.Name+"-")
+}
+
+// every pokemon a species can appear as, the default first
+func (species PokemonSpecies) Forms() []string {
+	forms := []string{}
+	for _, variety := range species.Varieties {
+		if variety.Is_default {
+			forms = append([]string{variety.Pokemon.Name}, forms...)
+		} else {
+			forms = append(forms, variety.Pokemon.Name)
+		}
+	}
+	return forms
+}
+
+// list the regional variants, megas, gigantamax and other forms of a species
+func formsCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	species, err := session.client.GetSpeciesOf(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	forms := species.Forms()
+	fmt.Printf("Forms of %s (%d):\n", englishName(species.Names, species.Name), len(forms))
+	for i, form := range forms {
+		if i == 0 {
+			fmt.Println("-", form, "(default)")
+		} else {
+			fmt.Println("-", form)
+		}
+	}
+	return nil
+}
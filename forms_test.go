@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPokemonFormName(t *testing.T) {
+	cases := map[string]string{
+		`{"name": "raichu", "is_default": true, "species": {"name": "raichu"}}`:               "",
+		`{"name": "raichu-alola", "is_default": false, "species": {"name": "raichu"}}`:        "alola",
+		`{"name": "charizard-mega-x", "is_default": false, "species": {"name": "charizard"}}`: "mega-x",
+		`{"name": "deoxys-normal", "is_default": true, "species": {"name": "deoxys"}}`:        "",
+	}
+	for raw, expected := range cases {
+		var pokemon Pokemon
+		err := json.Unmarshal([]byte(raw), &pokemon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if form := pokemon.FormName(); form != expected {
+			t.Errorf("%v: expected form %q, got %q", pokemon.Name, expected, form)
+		}
+	}
+}
+
+func TestResolvePokemonUsesDefaultForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/pokemon-species/deoxys":
+			w.Write([]byte(`{"id": 386, "name": "deoxys", "varieties": [
+				{"is_default": false, "pokemon": {"name": "deoxys-attack"}},
+				{"is_default": true, "pokemon": {"name": "deoxys-normal"}}
+			]}`))
+		case "/pokemon/deoxys-normal":
+			w.Write([]byte(`{"id": 386, "name": "deoxys-normal", "base_experience": 270, "is_default": true, "species": {"name": "deoxys"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+	pokemon, err := client.ResolvePokemon("deoxys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.Name != "deoxys-normal" {
+		t.Errorf("expected deoxys-normal, got %v", pokemon.Name)
+	}
+
+	species, err := client.GetSpeciesOf("deoxys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forms := strings.Join(species.Forms(), ","); forms != "deoxys-normal,deoxys-attack" {
+		t.Errorf("expected the default form first, got %v", forms)
+	}
+
+	_, err = client.ResolvePokemon("missingno")
+	if err == nil {
+		t.Errorf("expected an error for an unknown pokemon")
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// the public PokeAPI GraphQL endpoint
+const defaultGraphQLURL = "https://beta.pokeapi.co/graphql/v1beta"
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// run a GraphQL query against url and decode its data into out
+// responses are cached like any other request, keyed by a hash of the query and variables
+func (client *Client) GraphQL(url string, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	key := url + "?query=" + hex.EncodeToString(sum[:])
+
+	data, ok := client.cache.Get(key)
+	client.metrics.CacheLookup("graphql", ok)
+	if !ok {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+		start := time.Now()
+		resp, err := client.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		counter := &countingReader{reader: resp.Body}
+		resp.Body = io.NopCloser(counter)
+		defer func() {
+			client.metrics.Request("graphql", counter.count, time.Since(start))
+		}()
+		if resp.StatusCode != http.StatusOK {
+			return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, URL: url}
+		}
+		data, err = decodeBody(resp)
+		if err != nil {
+			return err
+		}
+	}
+
+	var response graphQLResponse
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		messages := []string{}
+		for _, e := range response.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+	}
+	if !ok {
+		client.cache.Add(key, data)
+	}
+	return json.Unmarshal(response.Data, out)
+}
+
+// a comparison against one base stat, like speed >= 100
+type StatFilter struct {
+	Stat  string
+	Op    string
+	Value int
+}
+
+// what a search-style command wants to find, every condition must hold
+type PokemonQuery struct {
+	Types        []string
+	Stats        []StatFilter
+	NameContains string
+}
+
+// the fields a search needs about each matching pokemon
+type PokemonSummary struct {
+	Id    int
+	Name  string
+	Types []string
+	Stats map[string]int
+}
+
+const pokemonSearchQuery = `query search($where: pokemon_v2_pokemon_bool_exp) {
+  pokemon_v2_pokemon(where: $where, order_by: {id: asc}) {
+    id
+    name
+    pokemon_v2_pokemontypes { pokemon_v2_type { name } }
+    pokemon_v2_pokemonstats { base_stat pokemon_v2_stat { name } }
+  }
+}`
+
+// hasura comparison operators for the ones users type
+var graphQLOps = map[string]string{
+	">=": "_gte",
+	">":  "_gt",
+	"<=": "_lte",
+	"<":  "_lt",
+	"=":  "_eq",
+}
+
+// find every pokemon matching query with a single GraphQL request instead of one REST call per pokemon
+func (client *Client) SearchPokemonGraphQL(url string, query PokemonQuery) ([]PokemonSummary, error) {
+	conditions := []interface{}{}
+	for _, typeName := range query.Types {
+		conditions = append(conditions, map[string]interface{}{
+			"pokemon_v2_pokemontypes": map[string]interface{}{
+				"pokemon_v2_type": map[string]interface{}{"name": map[string]interface{}{"_eq": typeName}},
+			},
+		})
+	}
+	for _, filter := range query.Stats {
+		op, ok := graphQLOps[filter.Op]
+		if !ok {
+			return nil, fmt.Errorf("unknown comparison %q", filter.Op)
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"pokemon_v2_pokemonstats": map[string]interface{}{
+				"base_stat":       map[string]interface{}{op: filter.Value},
+				"pokemon_v2_stat": map[string]interface{}{"name": map[string]interface{}{"_eq": filter.Stat}},
+			},
+		})
+	}
+	if query.NameContains != "" {
+		conditions = append(conditions, map[string]interface{}{
+			"name": map[string]interface{}{"_ilike": "%" + query.NameContains + "%"},
+		})
+	}
+
+	var data struct {
+		Pokemon []struct {
+			Id    int    `json:"id"`
+			Name  string `json:"name"`
+			Types []struct {
+				Type struct {
+					Name string `json:"name"`
+				} `json:"pokemon_v2_type"`
+			} `json:"pokemon_v2_pokemontypes"`
+			Stats []struct {
+				BaseStat int `json:"base_stat"`
+				Stat     struct {
+					Name string `json:"name"`
+				} `json:"pokemon_v2_stat"`
+			} `json:"pokemon_v2_pokemonstats"`
+		} `json:"pokemon_v2_pokemon"`
+	}
+	variables := map[string]interface{}{
+		"where": map[string]interface{}{"_and": conditions},
+	}
+	err := client.GraphQL(url, pokemonSearchQuery, variables, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []PokemonSummary{}
+	for _, pokemon := range data.Pokemon {
+		summary := PokemonSummary{
+			Id:    pokemon.Id,
+			Name:  pokemon.Name,
+			Stats: make(map[string]int),
+		}
+		for _, pokemonType := range pokemon.Types {
+			summary.Types = append(summary.Types, pokemonType.Type.Name)
+		}
+		for _, pokemonStat := range pokemon.Stats {
+			summary.Stats[pokemonStat.Stat.Name] = pokemonStat.BaseStat
+		}
+		results = append(results, summary)
+	}
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchPokemonGraphQL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request graphQLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		where, _ := json.Marshal(request.Variables["where"])
+		if !strings.Contains(string(where), `"_gte":100`) || !strings.Contains(string(where), `"_eq":"water"`) {
+			t.Errorf("expected type and stat conditions, got %s", where)
+		}
+		w.Write([]byte(`{"data": {"pokemon_v2_pokemon": [{"id": 121, "name": "starmie",
+			"pokemon_v2_pokemontypes": [{"pokemon_v2_type": {"name": "water"}}, {"pokemon_v2_type": {"name": "psychic"}}],
+			"pokemon_v2_pokemonstats": [{"base_stat": 115, "pokemon_v2_stat": {"name": "speed"}}]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	results, err := client.SearchPokemonGraphQL(server.URL, PokemonQuery{
+		Types: []string{"water"},
+		Stats: []StatFilter{{Stat: "speed", Op: ">=", Value: 100}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Name != "starmie" || results[0].Stats["speed"] != 115 {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors": [{"message": "field not found"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	var out interface{}
+	err := client.GraphQL(server.URL, "{ nope }", nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "field not found") {
+		t.Errorf("expected graphql error, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Warren-Wang-OG/pokedexcli/pokedexpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the grpc code for each status the json api answers errors with, anything else is internal
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest: codes.InvalidArgument,
+	http.StatusNotFound:   codes.NotFound,
+	http.StatusConflict:   codes.FailedPrecondition,
+}
+
+// the pokedex grpc service, answered with the same calls as the json api
+type grpcPokedex struct {
+	pokedexpb.UnimplementedPokedexServer
+	api *APIServer
+}
+
+// a grpc server with the pokedex service on it
+func newGRPCServer(api *APIServer) *grpc.Server {
+	server := grpc.NewServer()
+	pokedexpb.RegisterPokedexServer(server, &grpcPokedex{api: api})
+	return server
+}
+
+// an error from one of the api's calls as a grpc status
+func grpcError(err error) error {
+	code, ok := grpcCodes[apiStatus(err)]
+	if !ok {
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
+
+func pbPokemon(pokemon APIPokemon) *pokedexpb.Pokemon {
+	return &pokedexpb.Pokemon{Id: int32(pokemon.ID), Number: int32(pokemon.Number), Name: pokemon.Name, Nickname: pokemon.Nickname,
+		Form: pokemon.Form, Types: pokemon.Types, Level: int32(pokemon.Level), Shiny: pokemon.Shiny, Favorite: pokemon.Favorite,
+		Party: pokemon.Party, Item: pokemon.Item, CaughtOn: pokemon.CaughtOn}
+}
+
+// stats, ivs or evs by stat in the width protobuf uses
+func pbStats(stats map[string]int) map[string]int32 {
+	converted := make(map[string]int32)
+	for stat, value := range stats {
+		converted[stat] = int32(value)
+	}
+	return converted
+}
+
+func (service *grpcPokedex) Catch(ctx context.Context, request *pokedexpb.CatchRequest) (*pokedexpb.CatchResult, error) {
+	if request.Pokemon == "" {
+		return nil, status.Error(codes.InvalidArgument, "name a pokemon to catch")
+	}
+	service.api.mutex.Lock()
+	defer service.api.mutex.Unlock()
+	result, err := service.api.throw(APICatchRequest{Pokemon: request.Pokemon, Ball: request.Ball})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	reply := &pokedexpb.CatchResult{Pokemon: result.Pokemon, Ball: result.Ball, Chance: result.Chance, Shakes: int32(result.Shakes),
+		Critical: result.Critical, Outcome: result.Outcome, BallsLeft: int32(result.BallsLeft)}
+	if result.Caught != nil {
+		reply.Caught = pbPokemon(*result.Caught)
+	}
+	return reply, nil
+}
+
+func (service *grpcPokedex) Inspect(ctx context.Context, request *pokedexpb.InspectRequest) (*pokedexpb.PokemonDetails, error) {
+	service.api.mutex.Lock()
+	defer service.api.mutex.Unlock()
+	details, err := service.api.details(request.Pokemon)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pokedexpb.PokemonDetails{Pokemon: pbPokemon(details.APIPokemon), Nature: details.Nature, Experience: int32(details.Experience),
+		Happiness: int32(details.Happiness), Stats: pbStats(details.Stats), Ivs: pbStats(details.IVs), Evs: pbStats(details.EVs),
+		Moves: details.Moves, Sprite: details.Sprite}, nil
+}
+
+func (service *grpcPokedex) ListCaught(ctx context.Context, request *pokedexpb.ListCaughtRequest) (*pokedexpb.ListCaughtResponse, error) {
+	service.api.mutex.Lock()
+	defer service.api.mutex.Unlock()
+	reply := &pokedexpb.ListCaughtResponse{}
+	for _, pokemon := range service.api.caughtList() {
+		reply.Pokemon = append(reply.Pokemon, pbPokemon(pokemon))
+	}
+	return reply, nil
+}
+
+func (service *grpcPokedex) Explore(ctx context.Context, request *pokedexpb.ExploreRequest) (*pokedexpb.Area, error) {
+	service.api.mutex.Lock()
+	defer service.api.mutex.Unlock()
+	area, err := service.api.area(request.Area, request.Version)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	reply := &pokedexpb.Area{Area: area.Area, Version: area.Version}
+	for _, encounter := range area.Pokemon {
+		reply.Pokemon = append(reply.Pokemon, &pokedexpb.Encounter{Name: encounter.Name, Rate: int32(encounter.Rate)})
+	}
+	return reply, nil
+}
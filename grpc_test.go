@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Warren-Wang-OG/pokedexcli/pokedexpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCPokedex(t *testing.T) {
+	api := testAPIServer(t)
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer(api)
+	go server.Serve(listener)
+	defer server.Stop()
+	conn, err := grpc.NewClient("passthrough:///pokedex", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pokedexpb.NewPokedexClient(conn)
+	ctx := context.Background()
+
+	list, err := client.ListCaught(ctx, &pokedexpb.ListCaughtRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Pokemon) != 1 || list.Pokemon[0].Nickname != "Sparky" || !list.Pokemon[0].Party {
+		t.Errorf("unexpected pokedex %v", list.Pokemon)
+	}
+	details, err := client.Inspect(ctx, &pokedexpb.InspectRequest{Pokemon: "sparky"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Pokemon.Id != 1 || details.Pokemon.Level != 12 || len(details.Moves) != 1 || details.Stats["hp"] == 0 {
+		t.Errorf("unexpected details %v", details)
+	}
+	_, err = client.Inspect(ctx, &pokedexpb.InspectRequest{Pokemon: "mew"})
+	if status.Code(err) != codes.NotFound || status.Convert(err).Message() != "you have not caught mew" {
+		t.Errorf("expected mew not to be found, got %v", err)
+	}
+
+	area, err := client.Explore(ctx, &pokedexpb.ExploreRequest{Area: "viridian-forest-area", Version: "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(area.Pokemon) != 1 || area.Pokemon[0].Name != "caterpie" || area.Pokemon[0].Rate != 40 {
+		t.Errorf("unexpected area %v", area)
+	}
+	if _, err := client.Explore(ctx, &pokedexpb.ExploreRequest{Area: "nowhere"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected an unknown area not to be found, got %v", err)
+	}
+
+	result, err := client.Catch(ctx, &pokedexpb.CatchRequest{Pokemon: "mew", Ball: "master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Outcome != "caught" || result.Caught == nil || result.Caught.Id != 2 || result.BallsLeft != 0 || api.session.pokedex.Len() != 2 {
+		t.Errorf("unexpected catch %v", result)
+	}
+	if _, err := client.Catch(ctx, &pokedexpb.CatchRequest{Pokemon: "mew", Ball: "master"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected no master balls left, got %v", err)
+	}
+	if _, err := client.Catch(ctx, &pokedexpb.CatchRequest{Ball: "poke"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a catch without a pokemon to be turned down, got %v", err)
+	}
+
+	// the thin client's commands go through the same service
+	remote := &RemotePokedex{address: "bufconn", conn: conn, client: client}
+	if err := remotePokedexCommand(remote, []string{}); err != nil {
+		t.Error(err)
+	}
+	if err := remoteInspectCommand(remote, []string{"#2"}); err != nil {
+		t.Error(err)
+	}
+}
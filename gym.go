@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// a pokemon on a gym leader's team
+type GymPokemon struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// a gym and the team its leader fights with, from the games
+type GymLeader struct {
+	City   string
+	Region string
+	Leader string
+	Badge  string
+	Roster []GymPokemon
+}
+
+// every gym in the order the games have you take them on
+var gymLeaders = []GymLeader{
+	{City: "pewter-city", Region: "kanto", Leader: "Brock", Badge: "Boulder Badge", Roster: []GymPokemon{{"geodude", 12}, {"onix", 14}}},
+	{City: "cerulean-city", Region: "kanto", Leader: "Misty", Badge: "Cascade Badge", Roster: []GymPokemon{{"staryu", 18}, {"starmie", 21}}},
+	{City: "vermilion-city", Region: "kanto", Leader: "Lt. Surge", Badge: "Thunder Badge", Roster: []GymPokemon{{"voltorb", 21}, {"pikachu", 18}, {"raichu", 24}}},
+	{City: "celadon-city", Region: "kanto", Leader: "Erika", Badge: "Rainbow Badge", Roster: []GymPokemon{{"victreebel", 29}, {"tangela", 24}, {"vileplume", 29}}},
+	{City: "fuchsia-city", Region: "kanto", Leader: "Koga", Badge: "Soul Badge", Roster: []GymPokemon{{"koffing", 37}, {"muk", 39}, {"koffing", 37}, {"weezing", 43}}},
+	{City: "saffron-city", Region: "kanto", Leader: "Sabrina", Badge: "Marsh Badge", Roster: []GymPokemon{{"kadabra", 38}, {"mr-mime", 37}, {"venomoth", 38}, {"alakazam", 43}}},
+	{City: "cinnabar-island", Region: "kanto", Leader: "Blaine", Badge: "Volcano Badge", Roster: []GymPokemon{{"growlithe", 42}, {"ponyta", 40}, {"rapidash", 42}, {"arcanine", 47}}},
+	{City: "viridian-city", Region: "kanto", Leader: "Giovanni", Badge: "Earth Badge", Roster: []GymPokemon{{"rhyhorn", 45}, {"dugtrio", 42}, {"nidoqueen", 44}, {"nidoking", 45}, {"rhydon", 50}}},
+	{City: "violet-city", Region: "johto", Leader: "Falkner", Badge: "Zephyr Badge", Roster: []GymPokemon{{"pidgey", 9}, {"pidgeotto", 13}}},
+	{City: "azalea-town", Region: "johto", Leader: "Bugsy", Badge: "Hive Badge", Roster: []GymPokemon{{"metapod", 15}, {"kakuna", 15}, {"scyther", 17}}},
+	{City: "goldenrod-city", Region: "johto", Leader: "Whitney", Badge: "Plain Badge", Roster: []GymPokemon{{"clefairy", 17}, {"miltank", 19}}},
+	{City: "ecruteak-city", Region: "johto", Leader: "Morty", Badge: "Fog Badge", Roster: []GymPokemon{{"gastly", 21}, {"haunter", 21}, {"haunter", 23}, {"gengar", 25}}},
+	{City: "cianwood-city", Region: "johto", Leader: "Chuck", Badge: "Storm Badge", Roster: []GymPokemon{{"primeape", 27}, {"poliwrath", 30}}},
+	{City: "olivine-city", Region: "johto", Leader: "Jasmine", Badge: "Mineral Badge", Roster: []GymPokemon{{"magnemite", 30}, {"magnemite", 30}, {"steelix", 35}}},
+	{City: "mahogany-town", Region: "johto", Leader: "Pryce", Badge: "Glacier Badge", Roster: []GymPokemon{{"seel", 30}, {"dewgong", 32}, {"piloswine", 34}}},
+	{City: "blackthorn-city", Region: "johto", Leader: "Clair", Badge: "Rising Badge", Roster: []GymPokemon{{"dragonair", 37}, {"dragonair", 37}, {"dragonair", 37}, {"kingdra", 40}}},
+}
+
+// a gym by city, "pewter" works as well as "pewter-city"
+func findGym(city string) (GymLeader, bool) {
+	for _, gym := range gymLeaders {
+		if gym.City == city || strings.SplitN(gym.City, "-", 2)[0] == city {
+			return gym, true
+		}
+	}
+	return GymLeader{}, false
+}
+
+// prize money for beating a leader, the games pay more the stronger their team
+func (gym GymLeader) Prize() int {
+	highest := 0
+	for _, pokemon := range gym.Roster {
+		if pokemon.Level > highest {
+			highest = pokemon.Level
+		}
+	}
+	return 100 * highest
+}
+
+// the leader's side of the battle
+func (client *Client) gymSide(gym GymLeader) (*BattleSide, error) {
+	return client.rosterSide("Leader "+gym.Leader, gym.Roster)
+}
+
+// a trainer's side of the battle, made from their roster
+func (client *Client) rosterSide(name string, roster []GymPokemon) (*BattleSide, error) {
+	side := &BattleSide{Name: name}
+	for _, member := range roster {
+		pokemon, err := client.ResolvePokemon(member.Name)
+		if err != nil {
+			return nil, err
+		}
+		battler, err := client.NewBattler(pokemon, member.Level)
+		if err != nil {
+			return nil, err
+		}
+		side.Team = append(side.Team, battler)
+	}
+	return side, nil
+}
+
+// whether the player has a badge
+func (profile *Profile) HasBadge(badge string) bool {
+	for _, b := range profile.Badges {
+		if b == badge {
+			return true
+		}
+	}
+	return false
+}
+
+// gym [city] [--difficulty d] [--explain] [--log file] - list the gyms and your badges, or challenge a gym leader
+func gymCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "explain")
+	profile := session.profile
+
+	if len(params) == 0 {
+		region := ""
+		for _, gym := range gymLeaders {
+			if gym.Region != region {
+				region = gym.Region
+				fmt.Println(regionTitle(region) + ":")
+			}
+			marker := " "
+			if profile.HasBadge(gym.Badge) {
+				marker = "x"
+			}
+			fmt.Printf("[%s] %s - %s, %s\n", marker, gym.City, gym.Leader, gym.Badge)
+		}
+		return nil
+	}
+
+	gym, ok := findGym(apiName(params))
+	if !ok {
+		return fmt.Errorf("there's no gym in %s, gym lists them all", strings.Join(params, " "))
+	}
+	ai, err := battleAIFor(flags, session.config.Game.GymDifficulty)
+	if err != nil {
+		return err
+	}
+	log, err := session.openBattleLog(flags)
+	if err != nil {
+		return err
+	}
+	if log != nil {
+		defer log.Close()
+	}
+	player, err := session.playerSide()
+	if err != nil {
+		return err
+	}
+	leader, err := session.client.gymSide(gym)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Welcome to the %s gym!\n", gym.City)
+	battle := NewBattle(player, leader, false, rand.New(rand.NewSource(time.Now().UnixNano())))
+	_, battle.Explain = flags["explain"]
+	err = session.runBattle(battle, ai, log)
+	if err != nil {
+		return err
+	}
+	if battle.State == battleWon {
+		prize := gym.Prize()
+		session.inventory.Money += prize
+		if profile.HasBadge(gym.Badge) {
+			fmt.Printf("You beat %s again and won $%d\n", gym.Leader, prize)
+		} else {
+			profile.Badges = append(profile.Badges, gym.Badge)
+			fmt.Printf("You beat %s and earned the %s! You also won $%d\n", gym.Leader, gym.Badge, prize)
+		}
+	}
+	return session.finishBattle(battle, gym.Leader)
+}
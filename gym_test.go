@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestFindGym(t *testing.T) {
+	for _, city := range []string{"pewter-city", "pewter"} {
+		if gym, ok := findGym(city); !ok || gym.Leader != "Brock" {
+			t.Errorf("%s: expected Brock's gym, got %+v", city, gym)
+		}
+	}
+	if _, ok := findGym("pallet-town"); ok {
+		t.Errorf("expected no gym in pallet town")
+	}
+
+	badges := map[string]bool{}
+	for _, gym := range gymLeaders {
+		if len(gym.Roster) == 0 || len(gym.Roster) > maxPartySize {
+			t.Errorf("%s: a leader needs 1 to %d pokemon, has %d", gym.City, maxPartySize, len(gym.Roster))
+		}
+		if badges[gym.Badge] {
+			t.Errorf("%s: badge %s is given out twice", gym.City, gym.Badge)
+		}
+		badges[gym.Badge] = true
+	}
+	if gym, _ := findGym("viridian"); gym.Prize() != 5000 {
+		t.Errorf("expected Giovanni to pay $5000, got %d", gym.Prize())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Habitat struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	Names           []LocalizedName `json:"names"`
+	Pokemon_species []NamedResource `json:"pokemon_species"`
+}
+
+type PalParkArea struct {
+	Id                 int             `json:"id"`
+	Name               string          `json:"name"`
+	Names              []LocalizedName `json:"names"`
+	Pokemon_encounters []struct {
+		Base_score      int           `json:"base_score"`
+		Rate            int           `json:"rate"`
+		Pokemon_species NamedResource `json:"pokemon_species"`
+	} `json:"pokemon_encounters"`
+}
+
+// fetch a habitat by name, like "cave" or "waters-edge"
+func (client *Client) GetHabitat(name string) (Habitat, error) {
+	var habitat Habitat
+	err := client.GetJSON(client.ResourceURL("pokemon-habitat", name), &habitat)
+	return habitat, err
+}
+
+// fetch a pal park area by name, like "forest" or "sea"
+func (client *Client) GetPalParkArea(name string) (PalParkArea, error) {
+	var area PalParkArea
+	err := client.GetJSON(client.ResourceURL("pal-park-area", name), &area)
+	return area, err
+}
+
+// list the species that live in a habitat
+func habitatCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a habitat, like cave, forest or sea")
+		return nil
+	}
+
+	habitat, err := session.client.GetHabitat(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Habitat:", englishName(habitat.Names, habitat.Name))
+	fmt.Printf("Pokemon (%d):\n", len(habitat.Pokemon_species))
+	printSpeciesList(habitat.Pokemon_species)
+	return nil
+}
+
+// list the species found in a pal park area, most common first
+func palParkCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pal park area, like field, forest, mountain, pond or sea")
+		return nil
+	}
+
+	area, err := session.client.GetPalParkArea(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	encounters := append(area.Pokemon_encounters[:0:0], area.Pokemon_encounters...)
+	sort.SliceStable(encounters, func(i, j int) bool {
+		return encounters[i].Rate > encounters[j].Rate
+	})
+	fmt.Println("Pal park area:", englishName(area.Names, area.Name))
+	fmt.Printf("Pokemon (%d):\n", len(encounters))
+	for _, encounter := range encounters {
+		fmt.Printf("- %s (rate %d, score %d)\n", encounter.Pokemon_species.Name, encounter.Rate, encounter.Base_score)
+	}
+	return nil
+}
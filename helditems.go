@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// an item a wild pokemon can be holding, with how often per game version
+type HeldItem struct {
+	Item            NamedResource `json:"item"`
+	Version_details []struct {
+		Rarity  int           `json:"rarity"`
+		Version NamedResource `json:"version"`
+	} `json:"version_details"`
+}
+
+// the highest chance of the item across versions, in percent
+func (held HeldItem) Rarity() int {
+	rarity := 0
+	for _, detail := range held.Version_details {
+		if detail.Rarity > rarity {
+			rarity = detail.Rarity
+		}
+	}
+	return rarity
+}
+
+// print held items as "- item (N%)"
+func printHeldItems(items []HeldItem) {
+	for _, held := range items {
+		fmt.Printf("- %s (%d%%)\n", held.Item.Name, held.Rarity())
+	}
+}
+
+// list the items a wild pokemon can be found holding and how likely each is in every game
+func heldItemsCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	pokemon, err := session.client.ResolvePokemon(strings.ToLower(params[0]))
+	if err != nil {
+		return err
+	}
+
+	if len(pokemon.Held_items) == 0 {
+		fmt.Println("Wild", pokemon.Name, "don't hold items")
+		return nil
+	}
+	fmt.Println("Wild", pokemon.Name, "can hold:")
+	for _, held := range pokemon.Held_items {
+		fmt.Println("-", held.Item.Name)
+		for _, detail := range held.Version_details {
+			fmt.Printf("    %s: %d%%\n", detail.Version.Name, detail.Rarity)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// something that happened to the player's pokemon, one per line in the history file
+type HistoryEvent struct {
+	Time time.Time `json:"time"`
+	// what happened, like "release" or "catch"
+	Kind    string `json:"kind"`
+	Pokemon string `json:"pokemon"`
+	// for catches, one event per ball thrown
+	Ball string `json:"ball,omitempty"`
+	// the chance the ball had, and how many times it shook before the result
+	Chance float64 `json:"chance,omitempty"`
+	Shakes int     `json:"shakes,omitempty"`
+	// a critical capture shakes once and is caught or not
+	Critical bool `json:"critical,omitempty"`
+	// "caught", "broke free" or "fled"
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// path of the history file
+func historyPath() string {
+	return filepath.Join(pokedexDir(), "history.jsonl")
+}
+
+// append an event to the history file at path, lines are only ever added so the file is a full record
+func appendHistory(path string, event HistoryEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// record an event in the session's history, a session without a history path keeps none
+func (session *Session) Record(event HistoryEvent) error {
+	if session.historyPath == "" {
+		return nil
+	}
+	return appendHistory(session.historyPath, event)
+}
+
+// every event in the history file at path, oldest first, a missing file is an empty history
+func readHistory(path string) ([]HistoryEvent, error) {
+	events := []HistoryEvent{}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var event HistoryEvent
+		err = json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// which catch events to show, zero values match everything
+type CatchFilter struct {
+	Species string
+	// from the start of From to the end of To, both local dates
+	From time.Time
+	To   time.Time
+}
+
+func (filter CatchFilter) Matches(event HistoryEvent) bool {
+	if event.Kind != "catch" {
+		return false
+	}
+	if filter.Species != "" && event.Pokemon != filter.Species {
+		return false
+	}
+	if !filter.From.IsZero() && event.Time.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !event.Time.Before(filter.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// history [catches [--species name] [--from date] [--to date]] - show what happened to your pokemon
+func historyCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+
+	events, err := readHistory(session.historyPath)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		for _, event := range events {
+			fmt.Println(event.Time.Format("2006-01-02 15:04"), event.Kind, event.Pokemon)
+		}
+		return nil
+	}
+	if params[0] != "catches" {
+		fmt.Println("Use history catches to see catch attempts")
+		return nil
+	}
+
+	filter := CatchFilter{}
+	if species, ok := flagValue(flags, "species"); ok {
+		filter.Species = strings.ToLower(species)
+	}
+	for name, date := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value, ok := flagValue(flags, name)
+		if !ok {
+			continue
+		}
+		*date, err = time.ParseInLocation(dateLayout, value, time.Local)
+		if err != nil {
+			return fmt.Errorf("--%s takes a date like 2024-03-01", name)
+		}
+	}
+
+	attempts, caught, criticals, criticalCatches := 0, 0, 0, 0
+	for _, event := range events {
+		if !filter.Matches(event) {
+			continue
+		}
+		attempts++
+		if event.Outcome == "caught" {
+			caught++
+		}
+		shakes := fmt.Sprintf("%d shakes", event.Shakes)
+		if event.Critical {
+			criticals++
+			if event.Outcome == "caught" {
+				criticalCatches++
+			}
+			shakes = "critical, " + shakes
+		}
+		fmt.Printf("%s %s, %s ball (%.1f%%), %s: %s\n",
+			event.Time.Format("2006-01-02 15:04"), event.Pokemon, event.Ball, 100*event.Chance, shakes, event.Outcome)
+	}
+	if attempts == 0 {
+		fmt.Println("No catch attempts found")
+		return nil
+	}
+	fmt.Printf("%d throws, %d caught (%.0f%%)\n", attempts, caught, 100*float64(caught)/float64(attempts))
+	if criticals > 0 {
+		fmt.Printf("%d critical captures, %d caught\n", criticals, criticalCatches)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	for _, name := range []string{"pidgey", "rattata"} {
+		err := appendHistory(path, HistoryEvent{Kind: "release", Pokemon: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	events := []HistoryEvent{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event HistoryEvent
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 || events[0].Pokemon != "pidgey" || events[1].Pokemon != "rattata" {
+		t.Errorf("expected both releases in order, got %+v", events)
+	}
+	if events[0].Time.IsZero() {
+		t.Errorf("expected events to be timestamped")
+	}
+}
+
+func TestCatchFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	for i, event := range []HistoryEvent{
+		{Kind: "catch", Pokemon: "pidgey", Outcome: "broke free"},
+		{Kind: "catch", Pokemon: "pidgey", Outcome: "caught"},
+		{Kind: "release", Pokemon: "pidgey"},
+		{Kind: "catch", Pokemon: "rattata", Outcome: "fled"},
+	} {
+		event.Time = day.AddDate(0, 0, i)
+		err := appendHistory(path, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := readHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		filter   CatchFilter
+		expected int
+	}{
+		{CatchFilter{}, 3},
+		{CatchFilter{Species: "pidgey"}, 2},
+		{CatchFilter{From: day.AddDate(0, 0, 1)}, 2},
+		// the end date includes the whole day
+		{CatchFilter{To: time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local)}, 2},
+		{CatchFilter{Species: "rattata", To: day}, 0},
+	}
+	for _, c := range cases {
+		matched := 0
+		for _, event := range events {
+			if c.filter.Matches(event) {
+				matched++
+			}
+		}
+		if matched != c.expected {
+			t.Errorf("%+v: expected %d catches, got %d", c.filter, c.expected, matched)
+		}
+	}
+}
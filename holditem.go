@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// held items that do something in battle, the shop sells them all
+const (
+	leftovers   = "leftovers"
+	choiceBand  = "choice-band"
+	choiceSpecs = "choice-specs"
+	choiceScarf = "choice-scarf"
+	oranBerry   = "oran-berry"
+	sitrusBerry = "sitrus-berry"
+)
+
+var battleItems = []string{leftovers, choiceBand, choiceSpecs, choiceScarf, oranBerry, sitrusBerry}
+
+// choice items boost a stat by half but lock the holder into the first move it uses
+func isChoiceItem(item string) bool {
+	return item == choiceBand || item == choiceSpecs || item == choiceScarf
+}
+
+// give a battler back some hp, never more than its max
+func (battle *Battle) heal(battler *Battler, amount int, item string) {
+	if amount > battler.Stats["hp"]-battler.HP {
+		amount = battler.Stats["hp"] - battler.HP
+	}
+	if amount <= 0 {
+		return
+	}
+	battler.HP += amount
+	battle.emit(BattleEvent{Kind: "heal", Target: battler.Name, Move: item, Damage: amount, HP: battler.HP,
+		Message: fmt.Sprintf("%s restored %d HP with its %s (%d/%d HP)", battler.Name, amount, item, battler.HP, battler.Stats["hp"])})
+}
+
+// a battler holding a berry eats it once its hp drops to half
+func (battle *Battle) eatBerry(battler *Battler) {
+	if battler.Fainted() || battler.HP > battler.Stats["hp"]/2 {
+		return
+	}
+	switch battler.Item {
+	case oranBerry:
+		battler.Item = ""
+		battle.heal(battler, 10, oranBerry)
+	case sitrusBerry:
+		battler.Item = ""
+		battle.heal(battler, max1(battler.Stats["hp"]/4), sitrusBerry)
+	}
+}
+
+// leftovers heal a sixteenth of max hp after every turn
+func (battle *Battle) heldItemsEndOfTurn() {
+	for _, side := range battle.Sides {
+		battler := side.Current()
+		if battler.Item == leftovers && !battler.Fainted() {
+			battle.heal(battler, max1(battler.Stats["hp"]/16), leftovers)
+		}
+	}
+}
+
+// give [pokemon] [item] - have a caught pokemon hold an item from the bag, the item it held goes back in the bag
+func giveCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter a pokemon and an item")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	item := apiName(params[1:])
+	err = session.inventory.UseItem(item)
+	if err != nil {
+		return err
+	}
+	if caught.Item != "" {
+		session.inventory.AddItems(caught.Item, 1)
+		fmt.Println(caught.Item, "went back in your bag")
+	}
+	caught.Item = item
+	fmt.Println(caught.DisplayName(), "is now holding", item)
+	if !knowsItem(item) {
+		fmt.Println(item, "doesn't do anything in battle, only", strings.Join(battleItems, ", "), "do")
+	}
+	return session.Save()
+}
+
+// take [pokemon] - put the item a caught pokemon is holding back in the bag
+func takeCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	if caught.Item == "" {
+		return fmt.Errorf("%s isn't holding anything", caught.DisplayName())
+	}
+	session.inventory.AddItems(caught.Item, 1)
+	fmt.Println("You took the", caught.Item, "from", caught.DisplayName())
+	caught.Item = ""
+	return session.Save()
+}
+
+// whether an item does something when held in battle
+func knowsItem(item string) bool {
+	for _, battleItem := range battleItems {
+		if battleItem == item {
+			return true
+		}
+	}
+	return false
+}
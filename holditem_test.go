@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestChoiceItems(t *testing.T) {
+	growl := &BattleMove{Name: "growl", Type: "normal", Class: "status", Accuracy: 100, PP: 40, MaxPP: 40}
+	slow := testBattler("snorlax", 20, 30, tackle(), growl)
+	slow.Item = choiceScarf
+	fast := testBattler("jolteon", 20, 40, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{slow}}, &BattleSide{Name: "Gary", Team: []*Battler{fast}}, false, rand.New(rand.NewSource(1)))
+
+	battle.Step([2]BattleAction{{Kind: "move", Move: 0}, {Kind: "move"}})
+	if first := battle.Events[0]; first.Kind != "move" || first.Actor != "snorlax" {
+		t.Errorf("expected the scarf to make snorlax go first, got %+v", first)
+	}
+	// locked into tackle, so asking for growl still tackles
+	battle.Step([2]BattleAction{{Kind: "move", Move: 1}, {Kind: "move"}})
+	if slow.Moves[0].PP != 33 || growl.PP != 40 {
+		t.Errorf("expected the choice lock to use tackle twice, got %d tackle and %d growl pp", slow.Moves[0].PP, growl.PP)
+	}
+
+	banded := testBattler("machamp", 20, 30, tackle())
+	banded.Item = choiceBand
+	if got := banded.attackStat("physical"); got != 30 {
+		t.Errorf("expected a choice band to raise attack to 30, got %d", got)
+	}
+	if got := banded.attackStat("special"); got != 20 {
+		t.Errorf("expected a choice band to leave special attack alone, got %d", got)
+	}
+}
+
+func TestLeftoversAndBerries(t *testing.T) {
+	holder := testBattler("snorlax", 20, 30, tackle())
+	holder.Stats["hp"] = 64
+	holder.HP = 40
+	holder.Item = leftovers
+	other := testBattler("pidgey", 5, 10)
+	other.Item = sitrusBerry
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{holder}}, &BattleSide{Name: "wild pidgey", Team: []*Battler{other}}, true, rand.New(rand.NewSource(1)))
+
+	battle.endOfTurn()
+	if holder.HP != 44 {
+		t.Errorf("expected leftovers to heal 4 hp, got %d", holder.HP)
+	}
+	battle.hurt(battle.Sides[opponentSide], other, 16, "tackle")
+	if other.HP != 21 || other.Item != "" {
+		t.Errorf("expected the sitrus berry to be eaten and heal 7 hp, got %d hp holding %q", other.HP, other.Item)
+	}
+	battle.hurt(battle.Sides[opponentSide], other, 10, "tackle")
+	if other.HP != 11 {
+		t.Errorf("expected an eaten berry not to heal again, got %d hp", other.HP)
+	}
+}
+
+func TestGiveAndTake(t *testing.T) {
+	session := &Session{pokedex: NewPokedex(), inventory: NewInventory()}
+	session.pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Name: "snorlax"}})
+	if err := giveCommand(session, []string{"snorlax", "leftovers"}); err == nil {
+		t.Errorf("expected giving an item that isn't in the bag to be an error")
+	}
+
+	session.inventory.AddItems(leftovers, 1)
+	session.inventory.AddItems(oranBerry, 1)
+	if err := giveCommand(session, []string{"snorlax", "leftovers"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := giveCommand(session, []string{"snorlax", "oran", "berry"}); err != nil {
+		t.Fatal(err)
+	}
+	caught := session.pokedex.Pokemon[0]
+	if caught.Item != oranBerry || session.inventory.Items[leftovers] != 1 || session.inventory.Items[oranBerry] != 0 {
+		t.Errorf("expected the leftovers to go back in the bag for the berry, got %q and %v", caught.Item, session.inventory.Items)
+	}
+	if err := takeCommand(session, []string{"snorlax"}); err != nil {
+		t.Fatal(err)
+	}
+	if caught.Item != "" || session.inventory.Items[oranBerry] != 1 {
+		t.Errorf("expected the berry to be taken back, got %q and %v", caught.Item, session.inventory.Items)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// the page export --format html fills in, built into the binary so the report needs nothing else
+//
+//go:embed report.html.tmpl
+var reportPage string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportPage))
+
+// what the report page is filled in with
+type HTMLReport struct {
+	Player  string
+	Date    string
+	Entries []HTMLReportEntry
+}
+
+// one caught pokemon's card
+type HTMLReportEntry struct {
+	ID       int
+	Name     string
+	Label    string
+	Types    string
+	Level    int
+	Shiny    bool
+	CaughtOn string
+	// the sprite as a data url so the page works offline, "" without one
+	Sprite template.URL
+	Stats  []HTMLReportStat
+}
+
+// a stat and how long its bar is, as a percent of the highest stat in the report
+type HTMLReportStat struct {
+	Name    string
+	Value   int
+	Percent int
+}
+
+// the sprite as a data url, false unless it's an image since the url goes into the page as it is
+func spriteDataURL(data []byte) (template.URL, bool) {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", false
+	}
+	return template.URL("data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)), true
+}
+
+// a page with a card for every caught pokemon: its sprite, the shiny one for shinies, stat bars and when it was caught
+func (session *Session) exportHTML(w io.Writer) error {
+	report := HTMLReport{Player: session.profile.Name, Date: time.Now().Format(dateLayout)}
+	highest := 1
+	for _, caught := range session.pokedex.Pokemon {
+		stats, err := session.caughtStats(caught)
+		if err != nil {
+			return err
+		}
+		entry := HTMLReportEntry{ID: caught.InstanceID, Name: caught.Name, Label: caughtLabel(caught), Types: caught.TypeNames(),
+			Level: caught.Level, Shiny: caught.Shiny, CaughtOn: caught.CaughtOn}
+		sprite := caught.Sprites.Front_default
+		if caught.Shiny && caught.Sprites.Front_shiny != "" {
+			sprite = caught.Sprites.Front_shiny
+		}
+		// a sprite that can't be fetched leaves the card without a picture rather than the report unwritten
+		if sprite != "" {
+			if data, err := session.client.GetAsset(sprite); err == nil {
+				entry.Sprite, _ = spriteDataURL(data)
+			}
+		}
+		for _, stat := range statOrder {
+			entry.Stats = append(entry.Stats, HTMLReportStat{Name: showdownStats[stat], Value: stats[stat]})
+			if stats[stat] > highest {
+				highest = stats[stat]
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, entry := range report.Entries {
+		for i := range entry.Stats {
+			entry.Stats[i].Percent = 100 * entry.Stats[i].Value / highest
+		}
+	}
+	return reportTemplate.Execute(w, report)
+}
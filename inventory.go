@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// a kind of pokeball and how much it improves the odds of a catch
+type Ball struct {
+	Name       string
+	Multiplier float64
+	// a master ball never fails
+	Guaranteed bool
+}
+
+var balls = map[string]Ball{
+	"poke":   {Name: "poke", Multiplier: 1},
+	"great":  {Name: "great", Multiplier: 1.5},
+	"ultra":  {Name: "ultra", Multiplier: 2},
+	"master": {Name: "master", Multiplier: 255, Guaranteed: true},
+}
+
+// berries that make a pokemon easier to catch, and by how much
+var catchBerries = map[string]float64{
+	"razz":         1.5,
+	"silver-pinap": 1.8,
+	"golden-razz":  2.5,
+}
+
+// accept "ultra", "ultra-ball" and "ultraball"
+func findBall(name string) (Ball, bool) {
+	name = strings.ToLower(name)
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "ball"), "-")
+	ball, ok := balls[name]
+	return ball, ok
+}
+
+// what the player is carrying
+type Inventory struct {
+	Balls   map[string]int `json:"balls"`
+	Berries map[string]int `json:"berries"`
+	// the ball catch throws when no --ball is given
+	Selected string `json:"selected"`
+	Money    int    `json:"money"`
+	// everything else, like evolution stones, by api name
+	Items map[string]int `json:"items"`
+}
+
+// the bag a new player starts with
+func NewInventory() *Inventory {
+	return &Inventory{
+		Balls:    map[string]int{"poke": 20, "great": 5, "ultra": 2},
+		Berries:  map[string]int{"razz": 5},
+		Selected: "poke",
+	}
+}
+
+// take one ball out of the bag
+func (inventory *Inventory) UseBall(ball Ball) error {
+	if inventory.Balls[ball.Name] <= 0 {
+		return fmt.Errorf("you have no %s balls left", ball.Name)
+	}
+	inventory.Balls[ball.Name]--
+	return nil
+}
+
+// put balls in the bag
+func (inventory *Inventory) AddBalls(ball Ball, count int) {
+	if inventory.Balls == nil {
+		inventory.Balls = make(map[string]int)
+	}
+	inventory.Balls[ball.Name] += count
+}
+
+// take one berry out of the bag
+func (inventory *Inventory) UseBerry(berry string) error {
+	if inventory.Berries[berry] <= 0 {
+		return fmt.Errorf("you have no %s berries left", berry)
+	}
+	inventory.Berries[berry]--
+	return nil
+}
+
+// put berries in the bag
+func (inventory *Inventory) AddBerries(berry string, count int) {
+	if inventory.Berries == nil {
+		inventory.Berries = make(map[string]int)
+	}
+	inventory.Berries[berry] += count
+}
+
+// put other items in the bag
+func (inventory *Inventory) AddItems(item string, count int) {
+	if inventory.Items == nil {
+		inventory.Items = make(map[string]int)
+	}
+	inventory.Items[item] += count
+}
+
+// take one item out of the bag
+func (inventory *Inventory) UseItem(item string) error {
+	if inventory.Items[item] <= 0 {
+		return fmt.Errorf("you have no %s left", item)
+	}
+	inventory.Items[item]--
+	return nil
+}
+
+// ball names sorted from weakest to strongest
+func ballNames() []string {
+	names := []string{}
+	for name := range balls {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return balls[names[i]].Multiplier < balls[names[j]].Multiplier
+	})
+	return names
+}
+
+// show the money, balls and berries in the bag
+func inventoryCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	inventory := session.inventory
+
+	fmt.Printf("Money: $%d\n", inventory.Money)
+	fmt.Println("Balls:")
+	for _, name := range ballNames() {
+		marker := ""
+		if name == inventory.Selected {
+			marker = " (selected)"
+		}
+		fmt.Printf("- %s ball x%d%s\n", name, inventory.Balls[name], marker)
+	}
+	berries := []string{}
+	for name, count := range inventory.Berries {
+		if count > 0 {
+			berries = append(berries, name)
+		}
+	}
+	if len(berries) > 0 {
+		sort.Strings(berries)
+		fmt.Println("Berries:")
+		for _, name := range berries {
+			fmt.Printf("- %s berry x%d\n", name, inventory.Berries[name])
+		}
+	}
+	items := []string{}
+	for name, count := range inventory.Items {
+		if count > 0 {
+			items = append(items, name)
+		}
+	}
+	if len(items) > 0 {
+		sort.Strings(items)
+		fmt.Println("Items:")
+		for _, name := range items {
+			fmt.Printf("- %s x%d\n", name, inventory.Items[name])
+		}
+	}
+	return nil
+}
+
+// ball [ball] - choose the ball catch throws by default
+func ballCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Throwing", session.inventory.Selected, "balls")
+		return nil
+	}
+
+	ball, ok := findBall(params[0])
+	if !ok {
+		fmt.Println("Unknown ball, choose one of:", strings.Join(ballNames(), ", "))
+		return nil
+	}
+	session.inventory.Selected = ball.Name
+	fmt.Println("Throwing", ball.Name, "balls from now on")
+	return session.Save()
+}
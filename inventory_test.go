@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestFindBall(t *testing.T) {
+	for _, name := range []string{"ultra", "Ultra-Ball", "ultraball"} {
+		if ball, ok := findBall(name); !ok || ball.Name != "ultra" {
+			t.Errorf("%v: expected the ultra ball, got %+v", name, ball)
+		}
+	}
+	if _, ok := findBall("beast"); ok {
+		t.Errorf("expected no beast ball")
+	}
+}
+
+func TestUseBall(t *testing.T) {
+	inventory := &Inventory{Balls: map[string]int{"great": 1}}
+	if err := inventory.UseBall(balls["great"]); err != nil {
+		t.Fatal(err)
+	}
+	if err := inventory.UseBall(balls["great"]); err == nil {
+		t.Errorf("expected an error with no great balls left")
+	}
+	inventory.AddBalls(balls["master"], 1)
+	if inventory.Balls["master"] != 1 {
+		t.Errorf("expected a master ball, got %v", inventory.Balls)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Item struct {
+	Id             int              `json:"id"`
+	Name           string           `json:"name"`
+	Cost           int              `json:"cost"`
+	Fling_power    *int             `json:"fling_power"`
+	Fling_effect   *NamedResource   `json:"fling_effect"`
+	Category       NamedResource    `json:"category"`
+	Attributes     []NamedResource  `json:"attributes"`
+	Effect_entries []EffectEntry    `json:"effect_entries"`
+	Names          []LocalizedName  `json:"names"`
+	Machines       []MachineVersion `json:"machines"`
+	Sprites        struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+// fetch an item by name or id
+func (client *Client) GetItem(name string) (Item, error) {
+	var item Item
+	err := client.GetJSON(client.ResourceURL("item", name), &item)
+	return item, err
+}
+
+// whether the item has an attribute like "holdable" or "consumable"
+func (item Item) HasAttribute(attribute string) bool {
+	for _, a := range item.Attributes {
+		if a.Name == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// show an item's category, effect, fling power and cost
+func itemCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter an item")
+		return nil
+	}
+
+	item, err := session.client.GetItem(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Item:", englishName(item.Names, item.Name))
+	fmt.Println("Category:", item.Category.Name)
+	if item.Cost > 0 {
+		fmt.Println("Cost:", item.Cost)
+	} else {
+		fmt.Println("Cost: can't be bought")
+	}
+	fmt.Println("Fling power:", optionalInt(item.Fling_power))
+	if item.Fling_effect != nil {
+		fmt.Println("Fling effect:", item.Fling_effect.Name)
+	}
+	if len(item.Attributes) > 0 {
+		attributes := []string{}
+		for _, attribute := range item.Attributes {
+			attributes = append(attributes, attribute.Name)
+		}
+		fmt.Println("Attributes:", strings.Join(attributes, ", "))
+	}
+	effect, short := englishEffect(item.Effect_entries)
+	if effect == "" {
+		effect = short
+	}
+	if effect != "" {
+		fmt.Println("Effect:", effect)
+	}
+	return nil
+}
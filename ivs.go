@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// limits on individual and effort values, from the games
+const (
+	maxIV       = 31
+	maxStatEV   = 252
+	maxTotalEVs = 510
+)
+
+// individual values for every stat, rolled once when a pokemon is caught
+func rollIVs() map[string]int {
+	ivs := make(map[string]int)
+	for _, stat := range statOrder {
+		ivs[stat] = rand.Intn(maxIV + 1)
+	}
+	return ivs
+}
+
+// the effort values a pokemon gives the one that defeats it, by stat
+func (pokemon Pokemon) EffortYield() map[string]int {
+	effort := make(map[string]int)
+	for _, stat := range pokemon.Stats {
+		if stat.Effort > 0 {
+			effort[stat.Stat.Name] = stat.Effort
+		}
+	}
+	return effort
+}
+
+// add effort values up to the per stat and total limits, returns what was actually gained
+func (caught *CaughtPokemon) GainEVs(effort map[string]int) map[string]int {
+	if caught.EVs == nil {
+		caught.EVs = make(map[string]int)
+	}
+	total := 0
+	for _, ev := range caught.EVs {
+		total += ev
+	}
+	gained := make(map[string]int)
+	for _, stat := range statOrder {
+		amount := effort[stat]
+		if amount > maxStatEV-caught.EVs[stat] {
+			amount = maxStatEV - caught.EVs[stat]
+		}
+		if amount > maxTotalEVs-total {
+			amount = maxTotalEVs - total
+		}
+		if amount > 0 {
+			caught.EVs[stat] += amount
+			total += amount
+			gained[stat] = amount
+		}
+	}
+	return gained
+}
+
+// "+1 speed, +2 attack"
+func formatEVs(evs map[string]int) string {
+	text := ""
+	for _, stat := range statOrder {
+		if evs[stat] > 0 {
+			if text != "" {
+				text += ", "
+			}
+			text += fmt.Sprintf("+%d %s", evs[stat], stat)
+		}
+	}
+	return text
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestStatValue(t *testing.T) {
+	// a fully trained level 50 pikachu, as damage calculators show it
+	if hp := statValue("hp", 35, 31, 252, 50); hp != 142 {
+		t.Errorf("expected 142 hp, got %d", hp)
+	}
+	if speed := statValue("speed", 90, 31, 252, 50); speed != 142 {
+		t.Errorf("expected 142 speed, got %d", speed)
+	}
+	if statValue("attack", 55, 0, 0, 50) != statAtLevel("attack", 55, 50) {
+		t.Errorf("expected no ivs and evs to match statAtLevel")
+	}
+}
+
+func TestGainEVs(t *testing.T) {
+	caught := &CaughtPokemon{}
+	caught.GainEVs(map[string]int{"speed": 250})
+	gained := caught.GainEVs(map[string]int{"speed": 3, "attack": 2})
+	if gained["speed"] != 2 || gained["attack"] != 2 || caught.EVs["speed"] != maxStatEV {
+		t.Errorf("expected speed to stop at %d, got %v", maxStatEV, caught.EVs)
+	}
+
+	caught.GainEVs(map[string]int{"hp": 252})
+	gained = caught.GainEVs(map[string]int{"defense": 10})
+	if gained["defense"] != 4 {
+		t.Errorf("expected only 4 evs left under the total limit, got %v", gained)
+	}
+	if gained := caught.GainEVs(map[string]int{"special-attack": 1}); len(gained) != 0 {
+		t.Errorf("expected nothing gained at the limit, got %v", gained)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// JobManager runs background work (prefetching, warm-up) and stops it cleanly on shutdown
+type JobManager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mutex  sync.Mutex
+	closed bool
+}
+
+// create a new job manager, jobs run until Shutdown is called
+func NewJobManager() *JobManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobManager{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// run job in a goroutine, the context it gets is cancelled on shutdown
+// returns false if the manager is already shut down and the job was not started
+func (jobs *JobManager) Go(job func(ctx context.Context)) bool {
+	jobs.mutex.Lock()
+	defer jobs.mutex.Unlock()
+	if jobs.closed {
+		return false
+	}
+
+	jobs.wg.Add(1)
+	go func() {
+		defer jobs.wg.Done()
+		job(jobs.ctx)
+	}()
+	return true
+}
+
+// cancel all running jobs and wait up to timeout for them to return
+// returns false if some jobs were still running when the timeout passed
+func (jobs *JobManager) Shutdown(timeout time.Duration) bool {
+	jobs.mutex.Lock()
+	jobs.closed = true
+	jobs.mutex.Unlock()
+	jobs.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		jobs.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
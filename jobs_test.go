@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJobManagerShutdown(t *testing.T) {
+	jobs := NewJobManager()
+	stopped := make(chan struct{})
+	jobs.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	if !jobs.Shutdown(time.Second) {
+		t.Errorf("expected jobs to stop before the timeout")
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Errorf("expected job context to be cancelled")
+	}
+
+	if jobs.Go(func(ctx context.Context) {}) {
+		t.Errorf("expected no new jobs after shutdown")
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import "sort"
+
+// a move a pokemon can learn and how, per version group
+type LearnableMove struct {
+	Move                  NamedResource `json:"move"`
+	Version_group_details []struct {
+		Level_learned_at  int           `json:"level_learned_at"`
+		Move_learn_method NamedResource `json:"move_learn_method"`
+		Version_group     NamedResource `json:"version_group"`
+	} `json:"version_group_details"`
+}
+
+// the moves part of a pokemon, fetched apart from Pokemon so caught pokemon don't save their whole learnset
+type Learnset struct {
+	Moves []LearnableMove `json:"moves"`
+}
+
+// fetch the moves a pokemon can learn, the response is the cached pokemon one
+func (client *Client) GetLearnset(name string) (Learnset, error) {
+	var learnset Learnset
+	err := client.GetJSON(client.ResourceURL("pokemon", name), &learnset)
+	return learnset, err
+}
+
+// the level the move is learned at in the most recent game that teaches it by leveling up, false if none does
+func (move LearnableMove) LevelLearned() (int, bool) {
+	level, ok := 0, false
+	for _, detail := range move.Version_group_details {
+		if detail.Move_learn_method.Name == "level-up" {
+			level, ok = detail.Level_learned_at, true
+		}
+	}
+	return level, ok
+}
+
+// the last four moves learned by leveling up to level, what a wild pokemon at that level knows in the games
+func (learnset Learnset) LevelUpMoves(level int) []string {
+	type learned struct {
+		name  string
+		level int
+	}
+	moves := []learned{}
+	for _, move := range learnset.Moves {
+		if at, ok := move.LevelLearned(); ok && at <= level {
+			moves = append(moves, learned{move.Move.Name, at})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].level < moves[j].level
+	})
+	if len(moves) > maxMoves {
+		moves = moves[len(moves)-maxMoves:]
+	}
+	names := []string{}
+	for _, move := range moves {
+		names = append(names, move.name)
+	}
+	return names
+}
+
+// whether the pokemon can learn move in any way at all
+func (learnset Learnset) Has(move string) bool {
+	for _, learnable := range learnset.Moves {
+		if learnable.Move.Name == move {
+			return true
+		}
+	}
+	return false
+}
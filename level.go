@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math/rand"
+)
+
+// where a pokemon can be found in the wild and how, from /pokemon/<name>/encounters
+type EncounterArea struct {
+	Location_area   NamedResource             `json:"location_area"`
+	Version_details []EncounterVersionDetails `json:"version_details"`
+}
+
+// lowest and highest level a pokemon is met at
+type levelRange struct {
+	Min int
+	Max int
+}
+
+// level range for pokemon that are never met in the wild, like starters and most legendaries
+var defaultLevelRange = levelRange{Min: 5, Max: 20}
+
+const maxLevel = 100
+
+// fetch every location area a pokemon can be found in
+func (client *Client) GetEncounterAreas(pokemon Pokemon) ([]EncounterArea, error) {
+	url := pokemon.Location_area_encounters
+	if url == "" {
+		url = client.ResourceURL("pokemon", pokemon.Name) + "/encounters"
+	}
+	var areas []EncounterArea
+	err := client.GetJSON(url, &areas)
+	return areas, err
+}
+
+// every level range the pokemon is met at across areas, versions and encounter methods
+func wildLevelRanges(areas []EncounterArea) []levelRange {
+	ranges := []levelRange{}
+	for _, area := range areas {
+		for _, version := range area.Version_details {
+			for _, details := range version.Encounter_details {
+				ranges = append(ranges, levelRange{Min: details.Min_level, Max: details.Max_level})
+			}
+		}
+	}
+	return ranges
+}
+
+// pick one of the ranges and a level within it
+func rollLevel(ranges []levelRange) int {
+	if len(ranges) == 0 {
+		ranges = []levelRange{defaultLevelRange}
+	}
+	r := ranges[rand.Intn(len(ranges))]
+	if r.Max < r.Min {
+		r.Max = r.Min
+	}
+	level := r.Min + rand.Intn(r.Max-r.Min+1)
+	if level < 1 {
+		return 1
+	}
+	if level > maxLevel {
+		return maxLevel
+	}
+	return level
+}
+
+// a level for a freshly caught pokemon, from the levels it's met at in the games
+func (client *Client) RollWildLevel(pokemon Pokemon) int {
+	// without encounter data the default range is as good a guess as any
+	areas, err := client.GetEncounterAreas(pokemon)
+	if err != nil {
+		return rollLevel(nil)
+	}
+	return rollLevel(wildLevelRanges(areas))
+}
+
+// a stat's value at a level, using the games' formula without ivs and evs
+func statAtLevel(stat string, base int, level int) int {
+	return statValue(stat, base, 0, 0, level)
+}
+
+// a stat's value at a level from the games' formula, a quarter of the evs count
+func statValue(stat string, base int, iv int, ev int, level int) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		return value + level + 10
+	}
+	return value + 5
+}
+
+// the pokemon's actual value for a stat at its level and with its ivs, evs and nature
+func (caught *CaughtPokemon) Stat(stat string, nature Nature) int {
+	value := statValue(stat, caught.BaseStat(stat), caught.IVs[stat], caught.EVs[stat], caught.Level)
+	return int(float64(value) * nature.Multiplier(stat))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatAtLevel(t *testing.T) {
+	// pikachu at level 50 with no ivs or evs
+	if hp := statAtLevel("hp", 35, 50); hp != 95 {
+		t.Errorf("expected 95 hp, got %v", hp)
+	}
+	if attack := statAtLevel("attack", 55, 50); attack != 60 {
+		t.Errorf("expected 60 attack, got %v", attack)
+	}
+
+	caught := &CaughtPokemon{Level: 50}
+	err := json.Unmarshal([]byte(`{"stats": [{"base_stat": 55, "stat": {"name": "attack"}}]}`), &caught.Pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adamant := Nature{Increased_stat: &NamedResource{Name: "attack"}, Decreased_stat: &NamedResource{Name: "special-attack"}}
+	if attack := caught.Stat("attack", adamant); attack != 66 {
+		t.Errorf("expected 66 attack with an adamant nature, got %v", attack)
+	}
+}
+
+func TestRollLevel(t *testing.T) {
+	var areas []EncounterArea
+	err := json.Unmarshal([]byte(`[{"location_area": {"name": "viridian-forest-area"}, "version_details": [
+		{"version": {"name": "red"}, "encounter_details": [{"min_level": 3, "max_level": 5}, {"min_level": 4, "max_level": 4}]}
+	]}]`), &areas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranges := wildLevelRanges(areas)
+	if len(ranges) != 2 {
+		t.Fatalf("expected 2 level ranges, got %v", ranges)
+	}
+	for i := 0; i < 100; i++ {
+		if level := rollLevel(ranges); level < 3 || level > 5 {
+			t.Fatalf("rolled level %d outside 3-5", level)
+		}
+		if level := rollLevel(nil); level < defaultLevelRange.Min || level > defaultLevelRange.Max {
+			t.Fatalf("rolled level %d outside the default range", level)
+		}
+	}
+}
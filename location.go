@@ -0,0 +1,48 @@
+package main
+
+import "fmt"
+
+// a place on the map, made up of one or more location areas
+type Location struct {
+	Id     int             `json:"id"`
+	Name   string          `json:"name"`
+	Names  []LocalizedName `json:"names"`
+	Region *NamedResource  `json:"region"`
+	Areas  []NamedResource `json:"areas"`
+}
+
+// fetch a location by name, like "canalave-city"
+func (client *Client) GetLocation(name string) (Location, error) {
+	var location Location
+	err := client.GetJSON(client.ResourceURL("location", name), &location)
+	return location, err
+}
+
+// show a location's region and the areas in it that explore accepts
+func locationCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a location")
+		return nil
+	}
+
+	location, err := session.client.GetLocation(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Location:", englishName(location.Names, location.Name))
+	if location.Region != nil {
+		fmt.Println("Region:", location.Region.Name)
+	}
+	if len(location.Areas) == 0 {
+		fmt.Println("No areas to explore")
+		return nil
+	}
+	fmt.Printf("Areas (%d):\n", len(location.Areas))
+	for _, area := range location.Areas {
+		fmt.Println("-", area.Name)
+	}
+	return nil
+}
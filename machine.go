@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// a tm, hm or tr in one version group
+type Machine struct {
+	Id            int           `json:"id"`
+	Item          NamedResource `json:"item"`
+	Move          NamedResource `json:"move"`
+	Version_group NamedResource `json:"version_group"`
+}
+
+// how items and moves link to their machines
+type MachineVersion struct {
+	Machine struct {
+		Url string `json:"url"`
+	} `json:"machine"`
+	Version_group NamedResource `json:"version_group"`
+}
+
+// fetch a machine by its api url
+func (client *Client) GetMachine(url string) (Machine, error) {
+	var machine Machine
+	err := client.GetJSON(url, &machine)
+	return machine, err
+}
+
+// fetch several machines at once, results line up with urls
+func (client *Client) GetMachines(urls []string) ([]Machine, error) {
+	results := make([]Machine, len(urls))
+	errs := make([]error, len(urls))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < detailWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = client.GetMachine(urls[i])
+			}
+		}()
+	}
+
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// the machines behind a list of item or move links, in version group order
+func (client *Client) machinesFor(versions []MachineVersion) ([]Machine, error) {
+	urls := []string{}
+	for _, version := range versions {
+		urls = append(urls, version.Machine.Url)
+	}
+	machines, err := client.GetMachines(urls)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(machines, func(i, j int) bool {
+		return resourceID(machines[i].Version_group.Url) < resourceID(machines[j].Version_group.Url)
+	})
+	return machines, nil
+}
+
+// machine [tm01|machine id] [version group] - show which move a machine teaches in each game
+func machineCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	client := session.client
+	if len(params) == 0 {
+		fmt.Println("Please enter a machine, like tm01 or hm03")
+		return nil
+	}
+	versionGroup := ""
+	if len(params) > 1 {
+		versionGroup = strings.ToLower(params[1])
+	}
+
+	// a number is a machine id, which is already a single version group
+	var machines []Machine
+	if _, err := strconv.Atoi(params[0]); err == nil {
+		machine, err := client.GetMachine(client.ResourceURL("machine", params[0]))
+		if err != nil {
+			return err
+		}
+		machines = []Machine{machine}
+	} else {
+		item, err := client.GetItem(strings.ToLower(params[0]))
+		if err != nil {
+			return err
+		}
+		if len(item.Machines) == 0 {
+			fmt.Println(item.Name, "is not a machine")
+			return nil
+		}
+		machines, err = client.machinesFor(item.Machines)
+		if err != nil {
+			return err
+		}
+	}
+
+	found := false
+	for _, machine := range machines {
+		if versionGroup != "" && machine.Version_group.Name != versionGroup {
+			continue
+		}
+		found = true
+		fmt.Printf("- %s: %s teaches %s\n", machine.Version_group.Name, machine.Item.Name, machine.Move.Name)
+	}
+	if !found {
+		fmt.Println(params[0], "is not a machine in", versionGroup)
+	}
+	return nil
+}
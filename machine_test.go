@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMachinesForSortsByVersionGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /machine/<version group id>/
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/machine/"), "/")
+		fmt.Fprintf(w, `{"id": %s, "item": {"name": "tm01"}, "move": {"name": "mega-punch"}, "version_group": {"name": "vg%s", "url": "https://pokeapi.co/api/v2/version-group/%s/"}}`, id, id, id)
+	}))
+	defer server.Close()
+
+	versions := []MachineVersion{}
+	for _, id := range []int{7, 1, 3} {
+		var version MachineVersion
+		version.Machine.Url = fmt.Sprintf("%s/machine/%d/", server.URL, id)
+		versions = append(versions, version)
+	}
+
+	client := NewClient(NewCache(time.Minute))
+	machines, err := client.machinesFor(versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, machine := range machines {
+		names = append(names, machine.Version_group.Name)
+	}
+	if strings.Join(names, ",") != "vg1,vg3,vg7" {
+		t.Errorf("expected machines in version group order, got %v", names)
+	}
+}
@@ -2,34 +2,44 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"math/rand"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
-	"sync"
+	"text/tabwriter"
 	"time"
 )
 
 // ------------- Structs, Interfaces -------------
-type Cache struct {
-	entries map[string]cacheEntry
-	mutex   sync.Mutex
-}
-
-type cacheEntry struct {
-	createdAt time.Time
-	val       []byte
-}
-
 type Pokemon struct {
-	Id              int    `json:"id"`
-	Name            string `json:"name"`
-	Base_experience int    `json:"base_experience"`
-	Height          int    `json:"height"`
-	Weight          int    `json:"weight"`
-	Types           []struct {
+	Id              int           `json:"id"`
+	Name            string        `json:"name"`
+	Base_experience int           `json:"base_experience"`
+	Is_default      bool          `json:"is_default"`
+	Height          int           `json:"height"`
+	Weight          int           `json:"weight"`
+	Species         NamedResource `json:"species"`
+	Sprites         struct {
+		Front_default string `json:"front_default"`
+		Front_shiny   string `json:"front_shiny"`
+	} `json:"sprites"`
+	Cries struct {
+		Latest string `json:"latest"`
+		Legacy string `json:"legacy"`
+	} `json:"cries"`
+	Held_items []HeldItem       `json:"held_items"`
+	Abilities  []PokemonAbility `json:"abilities"`
+	// url of the location areas the pokemon can be found in, see GetEncounterAreas
+	Location_area_encounters string `json:"location_area_encounters"`
+	Types                    []struct {
 		Type struct {
 			Name string `json:"name"`
 		} `json:"type"`
@@ -43,14 +53,66 @@ type Pokemon struct {
 	} `json:"stats"`
 }
 
+// the base value of one stat, like "speed", or 0 if the pokemon doesn't have it
+func (pokemon Pokemon) BaseStat(name string) int {
+	for _, stat := range pokemon.Stats {
+		if stat.Stat.Name == name {
+			return stat.Base_stat
+		}
+	}
+	return 0
+}
+
+// a reference to another resource, the api uses these for list results and links between resources
+type NamedResource struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// marks shiny pokemon wherever they are listed
+const shinyMarker = "✨"
+
+// a pokemon in your pokedex: the api data plus everything about this particular one
+type CaughtPokemon struct {
+	Pokemon
+	// set with the nature command, "" for none
+	Nature string `json:"nature,omitempty"`
+	// told apart from other pokemon of the same species by this, the json name
+	// can't be "id" because that's the embedded pokemon's id
+	InstanceID int      `json:"instance_id"`
+	Nickname   string   `json:"nickname,omitempty"`
+	Favorite   bool     `json:"favorite,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	// which form was caught, like "alola", "" for the species' default form
+	Form  string `json:"form,omitempty"`
+	Shiny bool   `json:"shiny,omitempty"`
+	Level int    `json:"level"`
+	// total experience points, the species' growth rate decides how many each level takes
+	Experience int `json:"experience"`
+	// balls it took to catch
+	Throws int `json:"throws,omitempty"`
+	// fainted during a nuzlocke run, it can't rejoin the party
+	Dead bool `json:"dead,omitempty"`
+	// moves it was taught, empty for the ones a wild pokemon of its level knows
+	Moves []string `json:"moves,omitempty"`
+	// individual values rolled when caught and effort values earned in battle, by stat
+	IVs map[string]int `json:"ivs,omitempty"`
+	EVs map[string]int `json:"evs,omitempty"`
+	// from 0 to 255, some pokemon only evolve once they're happy enough
+	Happiness int `json:"happiness"`
+	// left at the daycare, it can't join the party until it's collected
+	Daycare bool `json:"daycare,omitempty"`
+	// the item it holds in battle, given from the bag, "" for none
+	Item string `json:"item,omitempty"`
+	// the date it was caught, hatched or traded for, "" for pokemon imported or kept from before dates were
+	CaughtOn string `json:"caught_on,omitempty"`
+}
+
 type LocationAreas struct {
-	Count    int    `json:"count"`
-	Next     string `json:"next"`
-	Previous string `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		Url  string `json:"url"`
-	} `json:"results"`
+	Count    int             `json:"count"`
+	Next     string          `json:"next"`
+	Previous string          `json:"previous"`
+	Results  []NamedResource `json:"results"`
 }
 
 type MapConfig struct {
@@ -63,13 +125,93 @@ type ExploreRequest struct {
 	Name     string `json:"name"`
 	Location struct {
 		Name string `json:"name"`
-	} `json:"location_area"`
-	Pokemon_encounters []struct {
-		Pokemon        Pokemon `json:"pokemon"`
-		VersionDetails []struct {
-			Rate int `json:"rate"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
+	} `json:"location"`
+	Pokemon_encounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+// a pokemon that can be found in a location area, and how likely it is in each game version
+type PokemonEncounter struct {
+	Pokemon        Pokemon                   `json:"pokemon"`
+	VersionDetails []EncounterVersionDetails `json:"version_details"`
+}
+
+// how a pokemon is met in one game version
+type EncounterVersionDetails struct {
+	// chance out of 100 of meeting this pokemon, summed over all encounter methods
+	Max_chance        int           `json:"max_chance"`
+	Version           NamedResource `json:"version"`
+	Encounter_details []struct {
+		Chance    int           `json:"chance"`
+		Min_level int           `json:"min_level"`
+		Max_level int           `json:"max_level"`
+		Method    NamedResource `json:"method"`
+	} `json:"encounter_details"`
+}
+
+// whether the pokemon can be met in the game version at all
+func (encounter PokemonEncounter) InVersion(version string) bool {
+	for _, details := range encounter.VersionDetails {
+		if details.Version.Name == version {
+			return true
+		}
+	}
+	return false
+}
+
+// every game version with encounters in the area, in the order the api lists them
+func areaVersions(area ExploreRequest) []string {
+	versions := []string{}
+	seen := map[string]bool{}
+	for _, encounter := range area.Pokemon_encounters {
+		for _, details := range encounter.VersionDetails {
+			if !seen[details.Version.Name] {
+				seen[details.Version.Name] = true
+				versions = append(versions, details.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// encounter chance in percent, for one version or the best chance across all versions if version is ""
+func (encounter PokemonEncounter) Rate(version string) int {
+	rate := 0
+	for _, details := range encounter.VersionDetails {
+		if version != "" && details.Version.Name != version {
+			continue
+		}
+		if details.Max_chance > rate {
+			rate = details.Max_chance
+		}
+	}
+	return rate
+}
+
+// state shared by every command for the lifetime of the REPL
+type Session struct {
+	config    *Config
+	client    *Client
+	jobs      *JobManager
+	warmer    *Warmer
+	mapConfig *MapConfig
+	pokedex   *Pokedex
+	inventory *Inventory
+	profile   *Profile
+	teams     map[string]*Team
+	// commands publish what happens here, achievements listen
+	events *EventBus
+	// the configured webhooks, nil for none
+	webhooks *Webhooks
+	// where Save writes the pokedex and inventory, "" to not save
+	savePath string
+	// where Record appends history events, "" to keep no history
+	historyPath string
+	// where battles save their replays, "" to save none
+	replayDir string
+	// commands that ask questions read answers from here, it's the scanner the REPL reads from
+	input *bufio.Scanner
+	// loaded the first time search runs
+	speciesIndex *SpeciesIndex
 }
 
 type Command struct {
@@ -95,117 +237,131 @@ func (f ParamFunc) Execute(args ...interface{}) error {
 	return f(args...)
 }
 
-// create and return a new cache
-func NewCache(interval time.Duration) *Cache {
-	cache := Cache{
-		entries: make(map[string]cacheEntry),
-	}
-
-	// run the old cache cleaner in a goroutine
-	go cache.Reaploop(interval)
-
-	return &cache
-}
-
-// add a new (key, value) pair to the cache
-func (cache *Cache) Add(key string, val []byte) {
-	cache.mutex.Lock()
-	defer cache.mutex.Unlock()
-	cache.entries[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val,
-	}
-}
-
-// (key, value) = (url to query, response body)
-// returns the value and a boolean indicating if the key was found
-func (cache *Cache) Get(key string) ([]byte, bool) {
-	// use locks to make map access thread safe
-	cache.mutex.Lock()
-	defer cache.mutex.Unlock()
-	val, ok := cache.entries[key]
-
-	if ok {
-		return val.val, true
-	}
-	return nil, false
-}
-
-// called whenever NewCache is called, each time an interval passes, remove all entries in the cache that are older than the interval
-func (cache *Cache) Reaploop(interval time.Duration) {
-	for {
-		time.Sleep(interval)
-
-		cache.mutex.Lock()
-
-		// list of keys to delete
-		toDelete := []string{}
-
-		for key, val := range cache.entries {
-			if time.Since(val.createdAt) > interval {
-				toDelete = append(toDelete, key)
-			}
-		}
-
-		for _, key := range toDelete {
-			delete(cache.entries, key)
-		}
-
-		cache.mutex.Unlock()
-	}
-}
-
 func helpCommand() error {
 	fmt.Println("This is the Pokemon Pokedex CLI")
 	fmt.Println("Available commands:")
 	fmt.Println("help - Show help (display this msg)")
 	fmt.Println("exit - Exit the CLI")
 	fmt.Println("map - Displays the names of the next 20 location areas")
+	fmt.Println("map --limit [n] - Displays the names of the next n location areas (also works for mapb)")
+	fmt.Println("map --all - Displays the names of every location area")
 	fmt.Println("mapb - Displays the names of the previous 20 location areas")
-	fmt.Println("explore [location] - show all pokemon in a location")
+	fmt.Println("explore [location] - show all pokemon in a location and how likely you are to meet them")
+	fmt.Println("explore [location] --details - also show each pokemon's types and base stats")
+	fmt.Println("explore [location] --version [game] - only show pokemon found in one game, like red or emerald")
+	fmt.Println("location [location] - show a location's region and the areas you can explore in it")
+	fmt.Println("search [name] - find pokemon whose name contains some text")
+	fmt.Println("search --type [type] [--type type] - find pokemon that have all of the given types")
+	fmt.Println("search --stat speed>=120 [--stat attack>=100] - find pokemon by base stats (needs --type unless graphql is enabled)")
+	fmt.Println("random [--type type] [--gen n] - meet a random pokemon and try to catch it")
+	fmt.Println("encounter [location] [--version game] - meet a wild pokemon in a location, as often as in the games, and try to catch it")
 	fmt.Println("catch [pokemon] - catch a pokemon")
+	fmt.Println("catch [pokemon] --ball [ball] - throw a poke, great, ultra or master ball instead of the selected one")
+	fmt.Println("catch [pokemon] --berry [berry] - feed a razz, silver-pinap or golden-razz berry first to make the catch easier")
+	fmt.Println("ball [ball] - choose which ball catch throws")
+	fmt.Println("trade [n] [pokemon] - see today's npc trade offers or take one, some pokemon evolve when traded")
+	fmt.Println("daycare [leave [pokemon] [pokemon]|collect] - breed two pokemon, their eggs hatch as you explore")
+	fmt.Println("nuzlocke [start|end] - the nuzlocke challenge: one catch per area, fainted pokemon are lost")
+	fmt.Println("progress - show how much of each generation and region you've caught")
+	fmt.Println("shop [buy item [count]] - see prices, or buy balls and berries")
+	fmt.Println("history [catches] [--species name] [--from date] [--to date] - show past releases and catch attempts")
+	fmt.Println("achievements - show your achievements and how close you are to the rest")
+	fmt.Println("trainer - show your trainer card: catches, success rate, favorite type and distance walked")
+	fmt.Println("trainer name [name] - change your trainer name")
+	fmt.Println("inventory - show the balls and berries in your bag")
 	fmt.Println("inspect [pokemon] - inspect a pokemon")
+	fmt.Println("inspect [pokemon] --sprite - also draw the pokemon's sprite")
 	fmt.Println("pokedex - show all pokemon in your pokedex")
+	fmt.Println("pokedex --fav - only show favorites")
+	fmt.Println("pokedex --tag [tag] - only show pokemon with a tag, repeat --tag to need several")
+	fmt.Println("learn [pokemon] [move] - teach a caught pokemon a move from its level-up or machine moves")
+	fmt.Println("forget [pokemon] [move] - make a caught pokemon forget a move")
+	fmt.Println("battle [pokemon] [--level n] [--difficulty easy|normal|hard] [--explain] [--log file] - battle a wild pokemon with your party, --explain shows the damage math, one at a --level you pick can't be caught")
+	fmt.Println("  in a wild battle c throws your selected ball, weakened pokemon and ones with a status condition are easier to catch")
+	fmt.Println("  --log file adds every battle event to file as a json line, gym, tournament and pvp battles take it too")
+	fmt.Println("battle --host [--port n] | --connect host:port - battle another player over the network")
+	fmt.Println("replay [file] [--speed x] - list saved battle replays or watch one again")
+	fmt.Println("gym [city] [--difficulty easy|normal|hard] [--explain] [--log file] - list the gyms, or challenge a gym leader for their badge")
+	fmt.Println("party - show the pokemon traveling with you")
+	fmt.Println("deposit [pokemon] - send a party pokemon to the pc")
+	fmt.Println("withdraw [pokemon] - bring a pokemon from the pc into your party")
+	fmt.Println("box list [n] - show the pokemon in a pc box")
+	fmt.Println("team [list|show|create|delete|use] [name] - manage named teams")
+	fmt.Println("team [add|remove] [name] [pokemon] - change who is on a team, one of each species")
+	fmt.Println("team export [name] [--format showdown] [--out file] - write a team as a pokemon showdown paste")
+	fmt.Println("team import [file] [--name team] - make a team from a pokemon showdown paste, its pokemon join your pokedex")
+	fmt.Println("serve [--port 8080] [--host localhost] - answer a json api until enter is pressed: GET /api/pokedex, GET /api/pokedex/{pokemon},")
+	fmt.Println("  POST /api/catch with {\"pokemon\": name, \"ball\": ball} and GET /api/explore/{area}?version=v")
+	fmt.Println("serve --grpc [--port 50051] [--host localhost] - answer the same calls over grpc, others play on your pokedex with pokedexcli --remote host:port")
+	fmt.Println("export [--format csv] [file] - write every caught pokemon with its types, stats, level, shiny flag and catch date")
+	fmt.Println("export --format markdown [file] - write a collection report with completion and highlights, with a table for each generation")
+	fmt.Println("export --format html [file] - write a page with every caught pokemon's sprite, stat bars and catch date, it works offline")
+	fmt.Println("export --format json [file] - write your whole save, pokedex, bag, profile and teams, to share or back up")
+	fmt.Println("import [file] - replace your save with one from export --format json, saves from older versions are upgraded")
+	fmt.Println("fav [pokemon] - mark or unmark a pokemon as a favorite")
+	fmt.Println("tag [pokemon] [tag]... - tag a pokemon, like tag pikachu starter")
+	fmt.Println("untag [pokemon] [tag]... - remove tags from a pokemon")
+	fmt.Println("release [pokemon] - let a pokemon you caught go")
+	fmt.Println("nickname [pokemon] [nickname] - name a pokemon you caught, then use the nickname or its #id in other commands")
+	fmt.Println("cry [pokemon] [--legacy] - play a pokemon's cry, --legacy for the original game sound")
+	fmt.Println("compare [pokemon] [pokemon] - show two pokemon side by side, the better value of each stat marked with *")
+	fmt.Println("move [move] - show a move's power, accuracy, pp, type and effect")
+	fmt.Println("ability [ability] - show an ability's effect and the pokemon that can have it")
+	fmt.Println("type [type] - show what a type is strong and weak against")
+	fmt.Println("helditems [pokemon] - show the items a wild pokemon can hold and how often in each game")
+	fmt.Println("forms [pokemon] - list a species' forms, like regional variants, megas and gigantamax")
+	fmt.Println("species [pokemon] - show pokedex text, genus, capture rate, base happiness and growth rate")
+	fmt.Println("dexentry [pokemon] [game] - show the pokedex entry from a game, or the latest one")
+	fmt.Println("evolution [pokemon] - show the whole evolution chain and how each evolution happens")
+	fmt.Println("evolve [pokemon] [species] - evolve a caught pokemon once it meets the requirements, stones come from the shop")
+	fmt.Println("item [item] - show an item's category, effect, fling power and cost")
+	fmt.Println("rating - show your pvp elo rating and a leaderboard of the players you've battled")
+	fmt.Println("tournament [start|battle|quit|results] [--difficulty d] - fight through a bracket of themed ai trainers for prize money")
+	fmt.Println("simulate [team|party] vs [team|pokemon...] [--n battles] [--level n] [--difficulty d] - estimate a team's chance to win from many ai battles")
+	fmt.Println("give [pokemon] [item] - have a caught pokemon hold an item from your bag, like leftovers, a choice item or a berry")
+	fmt.Println("take [pokemon] - put the item a caught pokemon holds back in your bag")
+	fmt.Println("berry [berry] - show a berry's growth time, firmness, flavors and natural gift type")
+	fmt.Println("regions - list every region")
+	fmt.Println("region [region] - show a region's games and locations")
+	fmt.Println("generation [n] - show a generation's games and the pokemon it introduced")
+	fmt.Println("nature [nature] - show which stats a nature raises and lowers")
+	fmt.Println("nature [nature] [pokemon] - give a pokemon you caught a nature")
+	fmt.Println("egggroup [group] - list the pokemon in an egg group, like monster or water1")
+	fmt.Println("habitat [habitat] - list the pokemon that live in a habitat, like cave, forest or sea")
+	fmt.Println("palpark [area] - list the pokemon found in a pal park area and how often")
+	fmt.Println("machine [tm] [game] - show which move a tm or hm teaches in each game, like tm01 or hm03")
+	fmt.Println("cache stats - show cache size and hit ratio")
+	fmt.Println("cache clear - empty the cache")
+	fmt.Println("cache rm [url|pokemon] - forget a cached response so it is downloaded again")
+	fmt.Println("warm [status] - fetch every location area into the cache in the background")
+	fmt.Println("stats api - show requests, bytes downloaded, cache hits and latency per endpoint")
 	return nil
 }
 
 // use pokedex API to get the names of 20 location areas and print the names of the 20 location areas
 func mapCommand(args ...interface{}) error {
-	mapConfig := args[0].(*MapConfig)
-	cache := args[1].(*Cache)
+	session := args[0].(*Session)
+	mapConfig := session.mapConfig
+	client := session.client
+	jobs := session.jobs
+	_, flags := parseFlags(args[1].([]string), "all")
+	if _, ok := flags["all"]; ok {
+		return mapAllCommand(client)
+	}
+	limit, err := pageSize(session, flags)
+	if err != nil {
+		return err
+	}
 	var locationAreas LocationAreas
-	url := *mapConfig.Next
-
-	//  check if the url to search is in the cache
-	locationAreasBytes, ok := cache.Get(url)
-
-	if ok {
-		// convert the bytes to a struct
-		err := json.Unmarshal(locationAreasBytes, &locationAreas)
-		if err != nil {
-			return err
-		}
-	} else {
-		resp, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		// decode the response body into a struct
-		err = json.NewDecoder(resp.Body).Decode(&locationAreas)
-		if err != nil {
-			return err
-		}
+	// the next page starts where the last one ended, whatever size it was
+	url, err := withQueryInt(*mapConfig.Next, "limit", limit)
+	if err != nil {
+		return err
+	}
 
-		// cache the response body
-		// convert the struct to bytes
-		locationAreasBytes, err := json.Marshal(locationAreas)
-		if err != nil {
-			return err
-		}
-		// save the bytes in the cache
-		cache.Add(url, locationAreasBytes)
+	err = client.GetJSON(url, &locationAreas)
+	if err != nil {
+		return err
 	}
 
 	// print the names of the 20 location areas
@@ -217,53 +373,101 @@ func mapCommand(args ...interface{}) error {
 	mapConfig.Next = &locationAreas.Next
 	mapConfig.Previous = &locationAreas.Previous
 
+	// get the neighbouring pages ready so paging again is instant
+	client.Prefetch(jobs, locationAreas.Next)
+	client.Prefetch(jobs, locationAreas.Previous)
+
+	session.profile.PagesWalked++
+	return session.Save()
+}
+
+// page size from map --limit, falling back to the config default
+func pageSize(session *Session, flags map[string][]string) (int, error) {
+	value, ok := flagValue(flags, "limit")
+	if !ok {
+		return session.config.Map.PageSize, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("--limit must be a positive number, got %q", value)
+	}
+	return limit, nil
+}
+
+// set a numeric query parameter on a url, keeping everything else
+func withQueryInt(rawURL string, key string, value int) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Set(key, strconv.Itoa(value))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// rebuild the api's previous page url so the page has limit entries and ends where the current page starts
+func previousPageURL(rawURL string, limit int) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	oldLimit, _ := strconv.Atoi(query.Get("limit"))
+
+	offset = offset + oldLimit - limit
+	if offset < 0 {
+		offset = 0
+	}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// print every location area, one page big enough for all of them, rendered as it streams in
+func mapAllCommand(client *Client) error {
+	url := client.ListURL("location-area", 0, 100000)
+	count := 0
+	err := client.StreamResults(url, func(locationArea NamedResource) error {
+		fmt.Println(locationArea.Name)
+		count++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(count, "location areas")
 	return nil
 }
 
 // get the names of the previous 20 location areas
 func mapbCommand(args ...interface{}) error {
-	mapConfig := args[0].(*MapConfig)
+	session := args[0].(*Session)
+	mapConfig := session.mapConfig
 
 	// if no previous page, return an error
 	if mapConfig.Previous == nil || *mapConfig.Previous == "" {
 		return fmt.Errorf("no previous page")
 	}
 
-	url := *mapConfig.Previous
-	cache := args[1].(*Cache)
+	client := session.client
+	jobs := session.jobs
+	_, flags := parseFlags(args[1].([]string))
+	limit, err := pageSize(session, flags)
+	if err != nil {
+		return err
+	}
+	url, err := previousPageURL(*mapConfig.Previous, limit)
+	if err != nil {
+		return err
+	}
 	var locationAreas LocationAreas
 
-	//  check if the url to search is in the cache
-	locationAreasBytes, ok := cache.Get(url)
-
-	if ok {
-		// convert the bytes to a struct
-		err := json.Unmarshal(locationAreasBytes, &locationAreas)
-		if err != nil {
-			return err
-		}
-
-	} else {
-		// make request
-		resp, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		// decode the response body into a struct
-		var locationAreas LocationAreas
-		err = json.NewDecoder(resp.Body).Decode(&locationAreas)
-		if err != nil {
-			return err
-		}
-
-		// convert the struct to bytes, cache the response body
-		locationAreasBytes, err := json.Marshal(locationAreas)
-		if err != nil {
-			return err
-		}
-		cache.Add(url, locationAreasBytes)
+	err = client.GetJSON(url, &locationAreas)
+	if err != nil {
+		return err
 	}
 
 	// print the names of the 20 location areas
@@ -275,128 +479,184 @@ func mapbCommand(args ...interface{}) error {
 	mapConfig.Next = &locationAreas.Next
 	mapConfig.Previous = &locationAreas.Previous
 
-	return nil
+	// get the neighbouring pages ready so paging again is instant
+	client.Prefetch(jobs, locationAreas.Next)
+	client.Prefetch(jobs, locationAreas.Previous)
+
+	session.profile.PagesWalked++
+	return session.Save()
 }
 
 // show all pokemon in a location
 func exploreCommand(args ...interface{}) error {
-	location := args[0].(string)
-	cache := args[1].(*Cache)
-	location_url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s", location)
-	var exploreRequest ExploreRequest
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "details")
+	if len(params) == 0 {
+		fmt.Println("Please enter a location")
+		return nil
+	}
+	client := session.client
+	version, _ := flagValue(flags, "version")
+	version = strings.ToLower(version)
+	exploreRequest, encounters, err := client.Explore(params[0], version)
+	if err != nil {
+		return err
+	}
 
-	// check if the location is in the cache
-	exploreRequestBytes, ok := cache.Get(location)
-	if ok {
-		// convert the bytes to a struct
-		err := json.Unmarshal(exploreRequestBytes, &exploreRequest)
-		if err != nil {
-			return err
+	// print the pokemon
+	if version != "" {
+		fmt.Println("Exploring", exploreRequest.Name, "in", version)
+	} else {
+		fmt.Println("Exploring", exploreRequest.Name)
+	}
+	fmt.Println("Pokemon encounters:")
+	if _, ok := flags["details"]; ok {
+		names := []string{}
+		rates := []int{}
+		for _, pokemon := range encounters {
+			names = append(names, pokemon.Pokemon.Name)
+			rates = append(rates, pokemon.Rate(version))
 		}
+		err = printPokemonDetails(client, names, rates)
 	} else {
-		// make request
-		resp, err := http.Get(location_url)
-		if err != nil {
-			return err
+		for _, pokemon := range encounters {
+			fmt.Printf("- %s (%d%%)\n", pokemon.Pokemon.Name, pokemon.Rate(version))
 		}
-		defer resp.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+	session.explored(exploreRequest)
+	return session.Save()
+}
 
-		// decode the response body into a struct
-		err = json.NewDecoder(resp.Body).Decode(&exploreRequest)
-		if err != nil {
-			return err
-		}
+// the location area and the pokemon met there, most likely first, only the ones in version unless it's ""
+func (client *Client) Explore(location, version string) (ExploreRequest, []PokemonEncounter, error) {
+	var exploreRequest ExploreRequest
+	err := client.GetJSON(client.ResourceURL("location-area", location), &exploreRequest)
+	if err != nil {
+		return exploreRequest, nil, err
+	}
 
-		// convert the struct to bytes, cache the response body
-		exploreRequestBytes, err := json.Marshal(exploreRequest)
-		if err != nil {
-			return err
+	// only what can be met in the chosen game, if there is one
+	encounters := []PokemonEncounter{}
+	for _, encounter := range exploreRequest.Pokemon_encounters {
+		if version == "" || encounter.InVersion(version) {
+			encounters = append(encounters, encounter)
 		}
-		cache.Add(location, exploreRequestBytes)
 	}
-
-	// print the pokemon
-	fmt.Println("Exploring", exploreRequest.Name)
-	fmt.Println("Pokemon encounters:")
-	for _, pokemon := range exploreRequest.Pokemon_encounters {
-		fmt.Println("-", pokemon.Pokemon.Name)
+	if len(encounters) == 0 && version != "" {
+		return exploreRequest, nil, fmt.Errorf("no pokemon in %s in %s, try one of: %s", exploreRequest.Name, version,
+			strings.Join(areaVersions(exploreRequest), ", "))
 	}
 
-	return nil
+	// most likely encounters first
+	sort.SliceStable(encounters, func(i, j int) bool {
+		return encounters[i].Rate(version) > encounters[j].Rate(version)
+	})
+	return exploreRequest, encounters, nil
 }
 
-// catch a pokemon
-func catchCommand(args ...interface{}) error {
-	pokemon := args[0].(string)
-	cache := args[1].(*Cache)
-	pokedex := args[2].(map[string]Pokemon)
-	var pokemonStruct Pokemon
-
-	pokemonUrl := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s", pokemon)
-
-	// check if you've already caught the pokemon
-	_, ok := pokedex[pokemon]
-	if ok {
-		return fmt.Errorf("you've already caught %s", pokemon)
+// tell whoever listens that the player explored an area
+func (session *Session) explored(area ExploreRequest) {
+	// which region the area is in is only needed for exploration achievements
+	event := GameEvent{Kind: "explore", Location: area.Location.Name}
+	if location, err := session.client.GetLocation(event.Location); err == nil && location.Region != nil {
+		event.Region = location.Region.Name
 	}
+	session.events.Publish(event)
+}
 
-	// check if the pokemon is in the cache
-	pokemonBytes, ok := cache.Get(pokemonUrl)
+// fetch every pokemon concurrently and print a table of their encounter rates, types and base stats
+func printPokemonDetails(client *Client, names []string, rates []int) error {
+	details, errs := client.GetPokemonBatch(names, detailWorkers)
 
-	if ok {
-		// convert the bytes to a struct
-		err := json.Unmarshal(pokemonBytes, &pokemonStruct)
-		if err != nil {
-			return err
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "NAME\tRATE\tTYPES\tHP\tATK\tDEF\tSP.ATK\tSP.DEF\tSPEED")
+	for i, pokemon := range details {
+		if errs[i] != nil {
+			fmt.Fprintf(table, "%s\t%d%%\t(%v)\t\t\t\t\t\t\n", names[i], rates[i], errs[i])
+			continue
 		}
-	} else {
-		// make request
-		resp, err := http.Get(pokemonUrl)
-		if err != nil {
-			return err
+		types := []string{}
+		for _, pokemonType := range pokemon.Types {
+			types = append(types, pokemonType.Type.Name)
 		}
-		defer resp.Body.Close()
-
-		// decode the response body into a struct
-		err = json.NewDecoder(resp.Body).Decode(&pokemonStruct)
-		if err != nil {
-			return err
+		stats := map[string]int{}
+		for _, pokemonStat := range pokemon.Stats {
+			stats[pokemonStat.Stat.Name] = pokemonStat.Base_stat
 		}
+		fmt.Fprintf(table, "%s\t%d%%\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", pokemon.Name, rates[i], strings.Join(types, "/"),
+			stats["hp"], stats["attack"], stats["defense"], stats["special-attack"], stats["special-defense"], stats["speed"])
+	}
+	return table.Flush()
+}
 
-		// convert the struct to bytes, cache the response body
-		pokemonBytes, err := json.Marshal(pokemonStruct)
-		if err != nil {
-			return err
-		}
-		cache.Add(pokemonUrl, pokemonBytes)
+// catch a pokemon
+func catchCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	pokemon := params[0]
+	client := session.client
+	if session.profile.Nuzlocke.Active() {
+		return fmt.Errorf("during a nuzlocke run pokemon can only be caught with encounter")
 	}
 
-	// use a random chance scaled by pokemon's base experience (higher the experience, the lower the chance) to catch the pokemon
-	rollVal := rand.Intn(1000) + 1
-	chance := (1000.0 - float64(pokemonStruct.Base_experience)) / 1000.0
-	fmt.Println("Trying to catch", pokemonStruct.Name, "with a probability of success", chance)
-	if rollVal > pokemonStruct.Base_experience {
-		fmt.Println("You caught", pokemonStruct.Name)
-		pokedex[pokemonStruct.Name] = pokemonStruct
-	} else {
-		fmt.Println("You failed to catch", pokemonStruct.Name)
+	options, ok := session.catchOptions(flags)
+	if !ok {
+		return nil
 	}
 
-	return nil
+	// a species name with no pokemon of its own, like deoxys, is caught in its default form
+	pokemonStruct, err := client.ResolvePokemon(pokemon)
+	if err != nil {
+		return err
+	}
+	return session.Catch(pokemonStruct, options)
 }
 
 // display the stats of a pokemon that you have caught
 func inspectCommand(args ...interface{}) error {
-	pokemon := args[0].(string)
-	pokedex := args[1].(map[string]Pokemon)
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "sprite")
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	pokemon := params[0]
+	pokedex := session.pokedex
 
-	// check if the pokemon is in the pokedex
-	pokemonStruct, ok := pokedex[pokemon]
-	if !ok {
-		fmt.Println("You have not caught", pokemon)
+	// check if the pokemon is in the pokedex, by nickname, id or name
+	pokemonStruct, err := pokedex.Find(pokemon)
+	if err != nil {
+		fmt.Println(err)
 	} else {
-		fmt.Println("Inspecting", pokemon)
+		fmt.Println("Inspecting", pokemonStruct.DisplayName())
+		if _, ok := flags["sprite"]; ok {
+			sprite := pokemonStruct.Sprites.Front_default
+			if pokemonStruct.Shiny && pokemonStruct.Sprites.Front_shiny != "" {
+				sprite = pokemonStruct.Sprites.Front_shiny
+			}
+			err := printSprite(session.client, sprite)
+			if err != nil {
+				return err
+			}
+		}
 		fmt.Println("Name:", pokemonStruct.Name)
+		if pokemonStruct.Nickname != "" {
+			fmt.Println("Nickname:", pokemonStruct.Nickname)
+		}
+		fmt.Printf("ID: #%d\n", pokemonStruct.InstanceID)
+		if pokemonStruct.Form != "" {
+			fmt.Println("Form:", pokemonStruct.Form)
+		}
+		if pokemonStruct.Shiny {
+			fmt.Println("Shiny:", shinyMarker)
+		}
 		fmt.Println("Height:", pokemonStruct.Height)
 		fmt.Println("Weight:", pokemonStruct.Weight)
 		fmt.Println("Base experience:", pokemonStruct.Base_experience)
@@ -404,10 +664,34 @@ func inspectCommand(args ...interface{}) error {
 		for _, pokemonType := range pokemonStruct.Types {
 			fmt.Println("-", pokemonType.Type.Name)
 		}
+		if len(pokemonStruct.Held_items) > 0 {
+			fmt.Println("Held items in the wild:")
+			printHeldItems(pokemonStruct.Held_items)
+		}
+		fmt.Printf("Level: %d (%d xp)\n", pokemonStruct.Level, pokemonStruct.Experience)
+		if pokemonStruct.Throws > 0 {
+			fmt.Println("Balls thrown to catch it:", pokemonStruct.Throws)
+		}
+		// stats depend on level and nature, show the base stat next to the actual one
+		nature := Nature{}
+		if pokemonStruct.Nature != "" {
+			nature, err = session.client.GetNature(pokemonStruct.Nature)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Nature:", nature.Name)
+		}
 		fmt.Println("Stats:")
 		for _, pokemonStat := range pokemonStruct.Stats {
-			fmt.Println("-", pokemonStat.Stat.Name, ":", pokemonStat.Base_stat)
+			name := pokemonStat.Stat.Name
+			fmt.Printf("- %s: %d (base %d, iv %d, ev %d)\n", name, pokemonStruct.Stat(name, nature), pokemonStat.Base_stat,
+				pokemonStruct.IVs[name], pokemonStruct.EVs[name])
+		}
+		moves, err := session.client.Moveset(pokemonStruct)
+		if err != nil {
+			return err
 		}
+		fmt.Println("Moves:", strings.Join(moves, ", "))
 	}
 
 	return nil
@@ -415,15 +699,92 @@ func inspectCommand(args ...interface{}) error {
 
 // list all the pokemon you have caught
 func pokedexCommand(args ...interface{}) error {
-	pokedex := args[0].(map[string]Pokemon)
+	session := args[0].(*Session)
+	_, flags := parseFlags(args[1].([]string), "fav")
+	pokedex := session.pokedex
+	_, onlyFavorites := flags["fav"]
+	tags := flags["tag"]
+
 	fmt.Println("Pokedex:")
-	for pokemonName, _ := range pokedex {
-		fmt.Println("-", pokemonName)
+	shinies := 0
+	shown := 0
+	// duplicates are numbered "1 of 3" in the order they were caught
+	counts := make(map[string]int)
+	seen := make(map[string]int)
+	for _, caught := range pokedex.Pokemon {
+		counts[caught.Name]++
+	}
+	for _, caught := range pokedex.Pokemon {
+		seen[caught.Name]++
+		if onlyFavorites && !caught.Favorite {
+			continue
+		}
+		tagged := true
+		for _, tag := range tags {
+			if !caught.HasTag(tag) {
+				tagged = false
+			}
+		}
+		if !tagged {
+			continue
+		}
+		shown++
+
+		line := fmt.Sprintf("- #%d %s", caught.InstanceID, caught.DisplayName())
+		if caught.Favorite {
+			line = fmt.Sprintf("- #%d ★ %s", caught.InstanceID, caught.DisplayName())
+		}
+		if caught.Nickname != "" {
+			line += " (" + caught.Name + ")"
+		}
+		if caught.Form != "" {
+			line += " (" + caught.Form + " form)"
+		}
+		if counts[caught.Name] > 1 {
+			line += fmt.Sprintf(" (%d of %d)", seen[caught.Name], counts[caught.Name])
+		}
+		if caught.Shiny {
+			line += " " + shinyMarker
+			shinies++
+		}
+		if caught.Dead {
+			line += " (dead)"
+		}
+		if caught.Daycare {
+			line += " (at the daycare)"
+		}
+		if len(caught.Tags) > 0 {
+			line += " [" + strings.Join(caught.Tags, ", ") + "]"
+		}
+		fmt.Println(line)
+	}
+	if shown < pokedex.Len() {
+		fmt.Printf("%d of %d caught, %d shiny\n", shown, pokedex.Len(), shinies)
+	} else {
+		fmt.Printf("%d caught, %d different pokemon, %d shiny\n", pokedex.Len(), len(counts), shinies)
 	}
 	return nil
 }
 
 func main() {
+	baseURL := flag.String("base-url", "", "PokeAPI base url, like http://localhost/api/v2 for a local mirror (overrides the config file)")
+	remote := flag.String("remote", "", "play on a pokedex served with serve --grpc at host:port instead of your own")
+	flag.Parse()
+
+	if *remote != "" {
+		err := runRemote(*remote, bufio.NewScanner(os.Stdin))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// background work like prefetching, stopped on exit
+	jobs := NewJobManager()
+	// set up once the config is read, whatever they still have queued goes out on exit
+	var webhooks *Webhooks
+
 	// map from command name to command
 	cmdHandler := make(map[string]Command)
 	cmdHandler["help"] = Command{
@@ -435,17 +796,61 @@ func main() {
 	cmdHandler["exit"] = Command{
 		name:        "exit",
 		description: "Exit the CLI",
-		callback:    NoParamFunc(func() error { os.Exit(0); return nil }),
+		callback: NoParamFunc(func() error {
+			webhooks.Close(2 * time.Second)
+			jobs.Shutdown(2 * time.Second)
+			os.Exit(0)
+			return nil
+		}),
+	}
+
+	config, err := LoadConfig(configPath())
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// warnings like schema drift go to a log file instead of cluttering the REPL
+	logFile, err := openLogFile()
+	if err != nil {
+		log.SetOutput(io.Discard)
+	} else {
+		defer logFile.Close()
+		log.SetOutput(logFile)
+	}
+
+	// reap as often as the shortest ttl, each entry expires according to its resource class
+	var cache *Cache = NewCache(config.MinCacheTTL())
+	cache.SetTTLPolicy(config.CacheTTL)
+	// keep long sessions from growing memory without bound
+	cache.SetLimits(config.Cache.MaxEntries, config.Cache.MaxBytes)
+	// entries on disk expire with the same ttls, the week is only for entries that have none
+	disk, err := NewDiskCache(filepath.Join(pokedexDir(), "cache"), 7*24*time.Hour)
+	if err != nil {
+		fmt.Println("disk cache disabled:", err)
+	} else {
+		cache.SetDisk(disk)
+	}
+	client := NewClient(cache)
+	if *baseURL != "" {
+		config.API.BaseURL = *baseURL
+	}
+	client.SetBaseURL(config.API.BaseURL)
+	transport, err := NewTransport(config.HTTP)
+	// webhooks go through the same proxy and certificates as the api
+	webhookClient := &http.Client{Timeout: webhookTimeout}
+	if err != nil {
+		fmt.Println("using default http settings:", err)
+	} else {
+		client.SetTransport(transport)
+		webhookClient.Transport = transport
 	}
 
 	// initialize the mapConfig and initial url starting
-	initMapURL := "https://pokeapi.co/api/v2/location-area/?offset=0&limit=20"
+	initMapURL := client.ListURL("location-area", 0, config.Map.PageSize)
 	mapConfig := MapConfig{
 		Next:     &initMapURL,
 		Previous: nil,
 	}
-	// cache for maps add a reasonable interval like 5 minutes
-	var cache *Cache = NewCache(5 * time.Minute)
 
 	cmdHandler["map"] = Command{
 		name:        "map",
@@ -483,77 +888,433 @@ func main() {
 		callback:    ParamFunc(pokedexCommand),
 	}
 
-	// pokedex
-	pokedex := make(map[string]Pokemon)
+	cmdHandler["cache"] = Command{
+		name:        "cache",
+		description: "inspect the response cache",
+		callback:    ParamFunc(cacheCommand),
+	}
+
+	cmdHandler["stats"] = Command{
+		name:        "stats",
+		description: "show usage statistics",
+		callback:    ParamFunc(statsCommand),
+	}
+
+	cmdHandler["warm"] = Command{
+		name:        "warm",
+		description: "fetch every location area into the cache in the background",
+		callback:    ParamFunc(warmCommand),
+	}
+
+	cmdHandler["move"] = Command{
+		name:        "move",
+		description: "look up a move",
+		callback:    ParamFunc(moveCommand),
+	}
+
+	cmdHandler["ability"] = Command{
+		name:        "ability",
+		description: "look up an ability",
+		callback:    ParamFunc(abilityCommand),
+	}
+
+	cmdHandler["type"] = Command{
+		name:        "type",
+		description: "show a type's matchups",
+		callback:    ParamFunc(typeCommand),
+	}
+
+	cmdHandler["species"] = Command{
+		name:        "species",
+		description: "show a pokemon species' pokedex data",
+		callback:    ParamFunc(speciesCommand),
+	}
+
+	cmdHandler["evolution"] = Command{
+		name:        "evolution",
+		description: "show a pokemon's evolution chain",
+		callback:    ParamFunc(evolutionCommand),
+	}
+	cmdHandler["evolve"] = Command{
+		name:        "evolve",
+		description: "evolve a caught pokemon",
+		callback:    ParamFunc(evolveCommand),
+	}
+	cmdHandler["rating"] = Command{
+		name:        "rating",
+		description: "show your pvp rating and leaderboard",
+		callback:    ParamFunc(ratingCommand),
+	}
+	cmdHandler["tournament"] = Command{
+		name:        "tournament",
+		description: "fight through a tournament bracket",
+		callback:    ParamFunc(tournamentCommand),
+	}
+	cmdHandler["simulate"] = Command{
+		name:        "simulate",
+		description: "estimate a team's chance to win a battle",
+		callback:    ParamFunc(simulateCommand),
+	}
+	cmdHandler["give"] = Command{
+		name:        "give",
+		description: "give a caught pokemon an item to hold",
+		callback:    ParamFunc(giveCommand),
+	}
+	cmdHandler["take"] = Command{
+		name:        "take",
+		description: "take a caught pokemon's held item",
+		callback:    ParamFunc(takeCommand),
+	}
+
+	cmdHandler["item"] = Command{
+		name:        "item",
+		description: "look up an item",
+		callback:    ParamFunc(itemCommand),
+	}
+
+	cmdHandler["berry"] = Command{
+		name:        "berry",
+		description: "look up a berry",
+		callback:    ParamFunc(berryCommand),
+	}
+
+	cmdHandler["regions"] = Command{
+		name:        "regions",
+		description: "list every region",
+		callback:    ParamFunc(regionsCommand),
+	}
+
+	cmdHandler["region"] = Command{
+		name:        "region",
+		description: "show the locations in a region",
+		callback:    ParamFunc(regionCommand),
+	}
+
+	cmdHandler["generation"] = Command{
+		name:        "generation",
+		description: "show the pokemon introduced in a generation",
+		callback:    ParamFunc(generationCommand),
+	}
+
+	cmdHandler["nature"] = Command{
+		name:        "nature",
+		description: "look up a nature or give one to a pokemon",
+		callback:    ParamFunc(natureCommand),
+	}
+
+	cmdHandler["egggroup"] = Command{
+		name:        "egggroup",
+		description: "list the pokemon in an egg group",
+		callback:    ParamFunc(eggGroupCommand),
+	}
+
+	cmdHandler["habitat"] = Command{
+		name:        "habitat",
+		description: "list the pokemon that live in a habitat",
+		callback:    ParamFunc(habitatCommand),
+	}
+
+	cmdHandler["palpark"] = Command{
+		name:        "palpark",
+		description: "list the pokemon found in a pal park area",
+		callback:    ParamFunc(palParkCommand),
+	}
+
+	cmdHandler["machine"] = Command{
+		name:        "machine",
+		description: "show which move a tm or hm teaches",
+		callback:    ParamFunc(machineCommand),
+	}
+
+	cmdHandler["location"] = Command{
+		name:        "location",
+		description: "show the areas within a location",
+		callback:    ParamFunc(locationCommand),
+	}
+
+	cmdHandler["dexentry"] = Command{
+		name:        "dexentry",
+		description: "show a pokemon's pokedex entry",
+		callback:    ParamFunc(dexEntryCommand),
+	}
+
+	cmdHandler["search"] = Command{
+		name:        "search",
+		description: "find pokemon by part of their name",
+		callback:    ParamFunc(searchCommand),
+	}
+
+	cmdHandler["compare"] = Command{
+		name:        "compare",
+		description: "compare the stats of two pokemon",
+		callback:    ParamFunc(compareCommand),
+	}
+
+	cmdHandler["random"] = Command{
+		name:        "random",
+		description: "meet a random pokemon",
+		callback:    ParamFunc(randomCommand),
+	}
+
+	cmdHandler["cry"] = Command{
+		name:        "cry",
+		description: "play a pokemon's cry",
+		callback:    ParamFunc(cryCommand),
+	}
+
+	cmdHandler["forms"] = Command{
+		name:        "forms",
+		description: "list the forms of a species",
+		callback:    ParamFunc(formsCommand),
+	}
+
+	cmdHandler["helditems"] = Command{
+		name:        "helditems",
+		description: "show the items a wild pokemon can hold",
+		callback:    ParamFunc(heldItemsCommand),
+	}
+
+	cmdHandler["inventory"] = Command{
+		name:        "inventory",
+		description: "show the balls and berries in your bag",
+		callback:    ParamFunc(inventoryCommand),
+	}
+
+	cmdHandler["ball"] = Command{
+		name:        "ball",
+		description: "choose the ball catch throws",
+		callback:    ParamFunc(ballCommand),
+	}
+
+	cmdHandler["trainer"] = Command{
+		name:        "trainer",
+		description: "show your trainer card",
+		callback:    ParamFunc(trainerCommand),
+	}
+
+	cmdHandler["encounter"] = Command{
+		name:        "encounter",
+		description: "meet a wild pokemon in a location",
+		callback:    ParamFunc(encounterCommand),
+	}
+
+	cmdHandler["release"] = Command{
+		name:        "release",
+		description: "let a caught pokemon go",
+		callback:    ParamFunc(releaseCommand),
+	}
+
+	cmdHandler["nickname"] = Command{
+		name:        "nickname",
+		description: "give a caught pokemon a nickname",
+		callback:    ParamFunc(nicknameCommand),
+	}
+
+	cmdHandler["export"] = Command{
+		name:        "export",
+		description: "export your pokedex",
+		callback:    ParamFunc(exportCommand),
+	}
+	cmdHandler["serve"] = Command{
+		name:        "serve",
+		description: "serve your pokedex as a json or grpc api",
+		callback:    ParamFunc(serveCommand),
+	}
+	cmdHandler["import"] = Command{
+		name:        "import",
+		description: "import a save exported as json",
+		callback:    ParamFunc(importCommand),
+	}
+	cmdHandler["fav"] = Command{
+		name:        "fav",
+		description: "mark a caught pokemon as a favorite",
+		callback:    ParamFunc(favCommand),
+	}
+
+	cmdHandler["tag"] = Command{
+		name:        "tag",
+		description: "tag a caught pokemon",
+		callback:    ParamFunc(tagCommand),
+	}
+
+	cmdHandler["untag"] = Command{
+		name:        "untag",
+		description: "remove tags from a caught pokemon",
+		callback:    ParamFunc(untagCommand),
+	}
+
+	cmdHandler["party"] = Command{
+		name:        "party",
+		description: "show your party",
+		callback:    ParamFunc(partyCommand),
+	}
+
+	cmdHandler["deposit"] = Command{
+		name:        "deposit",
+		description: "send a party pokemon to the pc",
+		callback:    ParamFunc(depositCommand),
+	}
+
+	cmdHandler["withdraw"] = Command{
+		name:        "withdraw",
+		description: "bring a pc pokemon into your party",
+		callback:    ParamFunc(withdrawCommand),
+	}
+
+	cmdHandler["box"] = Command{
+		name:        "box",
+		description: "show the pokemon in a pc box",
+		callback:    ParamFunc(boxCommand),
+	}
+
+	cmdHandler["team"] = Command{
+		name:        "team",
+		description: "build named teams",
+		callback:    ParamFunc(teamCommand),
+	}
+
+	cmdHandler["achievements"] = Command{
+		name:        "achievements",
+		description: "list achievements",
+		callback:    ParamFunc(achievementsCommand),
+	}
+
+	cmdHandler["shop"] = Command{
+		name:        "shop",
+		description: "buy balls and berries",
+		callback:    ParamFunc(shopCommand),
+	}
+
+	cmdHandler["history"] = Command{
+		name:        "history",
+		description: "show past events and catch attempts",
+		callback:    ParamFunc(historyCommand),
+	}
+
+	cmdHandler["progress"] = Command{
+		name:        "progress",
+		description: "show pokedex completion",
+		callback:    ParamFunc(progressCommand),
+	}
+
+	cmdHandler["trade"] = Command{
+		name:        "trade",
+		description: "trade pokemon with npcs",
+		callback:    ParamFunc(tradeCommand),
+	}
+	cmdHandler["daycare"] = Command{
+		name:        "daycare",
+		description: "breed pokemon and hatch eggs",
+		callback:    ParamFunc(daycareCommand),
+	}
+	cmdHandler["nuzlocke"] = Command{
+		name:        "nuzlocke",
+		description: "start or end a nuzlocke run",
+		callback:    ParamFunc(nuzlockeCommand),
+	}
+
+	cmdHandler["battle"] = Command{
+		name:        "battle",
+		description: "battle a wild pokemon",
+		callback:    ParamFunc(battleCommand),
+	}
+	cmdHandler["replay"] = Command{
+		name:        "replay",
+		description: "watch a saved battle again",
+		callback:    ParamFunc(replayCommand),
+	}
+
+	cmdHandler["gym"] = Command{
+		name:        "gym",
+		description: "challenge a gym leader",
+		callback:    ParamFunc(gymCommand),
+	}
+
+	cmdHandler["learn"] = Command{
+		name:        "learn",
+		description: "teach a pokemon a move",
+		callback:    ParamFunc(learnCommand),
+	}
+
+	cmdHandler["forget"] = Command{
+		name:        "forget",
+		description: "make a pokemon forget a move",
+		callback:    ParamFunc(forgetCommand),
+	}
+
+	// the pokedex and bag from last time, no save file means a new player
+	_, statErr := os.Stat(savePath())
+	firstRun := errors.Is(statErr, os.ErrNotExist)
+	save, err := LoadSave(savePath())
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println("Fix or move the save file so it isn't overwritten")
+		os.Exit(1)
+	}
+
+	// walk the same pages map shows so those are cached too
+	warmer := NewWarmer(client, jobs, config.Map.PageSize, time.Duration(config.Cache.WarmInterval))
+	webhooks = NewWebhooks(config.Webhooks, webhookClient, len(save.Pokedex.SpeciesCaught()))
+
+	session := &Session{
+		config:      config,
+		client:      client,
+		jobs:        jobs,
+		warmer:      warmer,
+		mapConfig:   &mapConfig,
+		pokedex:     save.Pokedex,
+		inventory:   save.Inventory,
+		profile:     save.Profile,
+		teams:       save.Teams,
+		savePath:    savePath(),
+		historyPath: historyPath(),
+		replayDir:   replayDir(),
+		input:       bufio.NewScanner(os.Stdin),
+		events:      NewEventBus(),
+		webhooks:    webhooks,
+	}
+	session.events.Subscribe(session.trackAchievements)
+	session.events.Subscribe(session.earnMoney)
+	session.events.Subscribe(session.checkCompletion)
+	session.events.Subscribe(session.trackNuzlocke)
+	session.events.Subscribe(session.trackDaycare)
+	session.events.Subscribe(session.notifyWebhooks)
+
+	if config.Cache.WarmOnStartup {
+		session.warmer.Start()
+	}
+
+	if firstRun {
+		session.chooseStarter()
+	}
+
+	err = session.claimDaily(time.Now())
+	if err != nil {
+		fmt.Println(err)
+	}
 
 	// REPL loop
+	input := session.input
 	for {
 		fmt.Print("pokedex > ")
-		// wait for user input
-		input := bufio.NewScanner(os.Stdin)
-		input.Scan()
-		cmd := input.Text()
-		if cmd == "" {
-			continue
-		}
-		params := strings.Split(cmd, " ")
-
-		// commands with a cli parameter
-		if len(params) == 2 {
-			if params[0] == "explore" {
-				err := cmdHandler[params[0]].callback.Execute(params[1], cache)
-				if err != nil {
-					fmt.Println(err)
-				}
-				continue
-			} else if params[0] == "catch" {
-				err := cmdHandler[params[0]].callback.Execute(params[1], cache, pokedex)
-				if err != nil {
-					fmt.Println(err)
-				}
-				continue
-			} else if params[0] == "inspect" {
-				err := cmdHandler[params[0]].callback.Execute(params[1], pokedex)
-				if err != nil {
-					fmt.Println(err)
-				}
-				continue
-			} else {
-				fmt.Println("Command not found")
-				continue
-			}
-		}
-
-		if cmd == "explore" {
-			fmt.Println("Please enter a location")
-			continue
-		}
-		if cmd == "catch" {
-			fmt.Println("Please enter a pokemon")
-			continue
+		// wait for user input, end of input exits like the exit command does
+		if !input.Scan() {
+			fmt.Println()
+			cmdHandler["exit"].callback.Execute()
 		}
-		if cmd == "inspect" {
-			fmt.Println("Please enter a pokemon")
+		params := strings.Fields(input.Text())
+		if len(params) == 0 {
 			continue
 		}
 
-		if cmd == "pokedex" {
-			err := cmdHandler[cmd].callback.Execute(pokedex)
-			if err != nil {
-				fmt.Println(err)
-			}
+		command, ok := cmdHandler[params[0]]
+		if !ok {
+			fmt.Println("Command not found")
 			continue
 		}
-
-		if cmd == "map" || cmd == "mapb" {
-			err := cmdHandler[cmd].callback.Execute(&mapConfig, cache)
-			if err != nil {
-				fmt.Println(err)
-			}
-		} else if cmdHandler[cmd].callback != nil {
-			cmdHandler[cmd].callback.Execute()
-		} else {
-			fmt.Println("Command not found")
+		err := command.callback.Execute(session, params[1:])
+		if err != nil {
+			fmt.Println(err)
 		}
 	}
 }
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -61,3 +64,121 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestLRUEviction(t *testing.T) {
+	cache := NewCache(time.Minute)
+	cache.SetLimits(2, 0)
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+
+	// touching a makes b the least recently used entry
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("expected to find a")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected to find c")
+	}
+}
+
+func TestByteBudget(t *testing.T) {
+	cache := NewCache(time.Minute)
+	cache.SetLimits(0, 10)
+	cache.Add("a", []byte("123456"))
+	cache.Add("b", []byte("123456"))
+
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected a to be evicted")
+	}
+	entries, size := cache.Len()
+	if entries != 1 || size != 6 {
+		t.Errorf("expected 1 entry of 6 bytes, got %v entries of %v bytes", entries, size)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	cache := NewCache(time.Minute)
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.Get("https://example.com")
+	cache.Get("https://example.com/missing")
+
+	stats := cache.Stats()
+	if stats.Entries != 1 || stats.Bytes != 8 {
+		t.Errorf("expected 1 entry of 8 bytes, got %v entries of %v bytes", stats.Entries, stats.Bytes)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %v and %v", stats.Hits, stats.Misses)
+	}
+	if stats.Oldest.IsZero() {
+		t.Errorf("expected an oldest entry")
+	}
+}
+
+func TestPreviousPageURL(t *testing.T) {
+	// the page before offset=40 with a new limit of 30 ends at 40
+	prev, err := previousPageURL("https://pokeapi.co/api/v2/location-area/?offset=20&limit=20", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != "https://pokeapi.co/api/v2/location-area/?limit=30&offset=10" {
+		t.Errorf("unexpected url %v", prev)
+	}
+
+	prev, err = previousPageURL("https://pokeapi.co/api/v2/location-area/?offset=0&limit=20", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != "https://pokeapi.co/api/v2/location-area/?limit=50&offset=0" {
+		t.Errorf("unexpected url %v", prev)
+	}
+}
+
+func TestExploreByVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "route-1", "pokemon_encounters": [
+			{"pokemon": {"name": "pidgey"}, "version_details": [
+				{"max_chance": 30, "version": {"name": "red"}}, {"max_chance": 20, "version": {"name": "blue"}}]},
+			{"pokemon": {"name": "rattata"}, "version_details": [{"max_chance": 50, "version": {"name": "red"}}]},
+			{"pokemon": {"name": "spearow"}, "version_details": [{"max_chance": 40, "version": {"name": "blue"}}]}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	cases := []struct {
+		version string
+		// name:rate, most likely first
+		expected []string
+		err      string
+	}{
+		{version: "", expected: []string{"rattata:50", "spearow:40", "pidgey:30"}},
+		{version: "red", expected: []string{"rattata:50", "pidgey:30"}},
+		{version: "blue", expected: []string{"spearow:40", "pidgey:20"}},
+		{version: "gold", err: "no pokemon in route-1 in gold, try one of: red, blue"},
+	}
+	for _, c := range cases {
+		_, encounters, err := client.Explore("route-1", c.version)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("version %q: expected error %q, got %v", c.version, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("version %q: %v", c.version, err)
+			continue
+		}
+		found := []string{}
+		for _, encounter := range encounters {
+			found = append(found, fmt.Sprintf("%s:%d", encounter.Pokemon.Name, encounter.Rate(c.version)))
+		}
+		if strings.Join(found, " ") != strings.Join(c.expected, " ") {
+			t.Errorf("version %q: expected %v, got %v", c.version, c.expected, found)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// counters for one api endpoint, like "pokemon" or "location-area"
+type EndpointMetrics struct {
+	Endpoint    string
+	Requests    int
+	Bytes       int64
+	CacheHits   int
+	CacheMisses int
+	Latency     time.Duration
+}
+
+// average time a network request to the endpoint took
+func (metrics EndpointMetrics) AverageLatency() time.Duration {
+	if metrics.Requests == 0 {
+		return 0
+	}
+	return metrics.Latency / time.Duration(metrics.Requests)
+}
+
+// how the api client has been used this session, per endpoint
+type APIMetrics struct {
+	mutex     sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+func (metrics *APIMetrics) endpoint(name string) *EndpointMetrics {
+	if metrics.endpoints == nil {
+		metrics.endpoints = make(map[string]*EndpointMetrics)
+	}
+	endpoint, ok := metrics.endpoints[name]
+	if !ok {
+		endpoint = &EndpointMetrics{Endpoint: name}
+		metrics.endpoints[name] = endpoint
+	}
+	return endpoint
+}
+
+// record a lookup that was answered (or not) by the cache
+func (metrics *APIMetrics) CacheLookup(name string, hit bool) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	if hit {
+		metrics.endpoint(name).CacheHits++
+	} else {
+		metrics.endpoint(name).CacheMisses++
+	}
+}
+
+// record a network request, its size on the wire and how long it took
+func (metrics *APIMetrics) Request(name string, bytes int64, latency time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	endpoint := metrics.endpoint(name)
+	endpoint.Requests++
+	endpoint.Bytes += bytes
+	endpoint.Latency += latency
+}
+
+// a copy of every endpoint's counters, sorted by endpoint name
+func (metrics *APIMetrics) Snapshot() []EndpointMetrics {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	snapshot := []EndpointMetrics{}
+	for _, endpoint := range metrics.endpoints {
+		snapshot = append(snapshot, *endpoint)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Endpoint < snapshot[j].Endpoint
+	})
+	return snapshot
+}
+
+// the endpoint a url belongs to: the first path segment after the base url, or the url's host and path otherwise
+func endpointName(baseURL string, url string) string {
+	base := canonicalURL(baseURL)
+	if strings.HasPrefix(url, base) {
+		rest := strings.TrimPrefix(url, base)
+		return strings.SplitN(strings.SplitN(rest, "?", 2)[0], "/", 2)[0]
+	}
+	url = strings.SplitN(url, "?", 2)[0]
+	url = strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	return strings.TrimSuffix(url, "/")
+}
+
+// counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (counter *countingReader) Read(p []byte) (int, error) {
+	n, err := counter.reader.Read(p)
+	counter.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Move struct {
+	Id             int              `json:"id"`
+	Name           string           `json:"name"`
+	Power          *int             `json:"power"`
+	Accuracy       *int             `json:"accuracy"`
+	Pp             int              `json:"pp"`
+	Priority       int              `json:"priority"`
+	Effect_chance  *int             `json:"effect_chance"`
+	Type           NamedResource    `json:"type"`
+	Damage_class   NamedResource    `json:"damage_class"`
+	Effect_entries []EffectEntry    `json:"effect_entries"`
+	Names          []LocalizedName  `json:"names"`
+	Machines       []MachineVersion `json:"machines"`
+	Meta           *MoveMeta        `json:"meta"`
+	Stat_changes   []StatChange     `json:"stat_changes"`
+	// who the move is used on, like "user" or "selected-pokemon"
+	Target NamedResource `json:"target"`
+}
+
+// fetch a move by name or id
+func (client *Client) GetMove(name string) (Move, error) {
+	var move Move
+	err := client.GetJSON(client.ResourceURL("move", name), &move)
+	return move, err
+}
+
+// the move's effect with $effect_chance filled in
+func (move Move) EffectText() string {
+	effect, short := englishEffect(move.Effect_entries)
+	if effect == "" {
+		effect = short
+	}
+	if move.Effect_chance != nil {
+		effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*move.Effect_chance))
+	}
+	return effect
+}
+
+// "-" for moves without a value, like the power of status moves
+func optionalInt(value *int) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.Itoa(*value)
+}
+
+// look up a move's power, accuracy, pp, type, damage class and effect
+func moveCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a move")
+		return nil
+	}
+
+	move, err := session.client.GetMove(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Move:", englishName(move.Names, move.Name))
+	fmt.Println("Type:", move.Type.Name)
+	fmt.Println("Damage class:", move.Damage_class.Name)
+	fmt.Println("Power:", optionalInt(move.Power))
+	fmt.Println("Accuracy:", optionalInt(move.Accuracy))
+	fmt.Println("PP:", move.Pp)
+	if move.Priority != 0 {
+		fmt.Println("Priority:", move.Priority)
+	}
+	if effect := move.EffectText(); effect != "" {
+		fmt.Println("Effect:", effect)
+	}
+
+	// group the version groups by machine, the same tm number often teaches a move across several games
+	if len(move.Machines) > 0 {
+		machines, err := session.client.machinesFor(move.Machines)
+		if err != nil {
+			return err
+		}
+		order := []string{}
+		games := map[string][]string{}
+		for _, machine := range machines {
+			if _, ok := games[machine.Item.Name]; !ok {
+				order = append(order, machine.Item.Name)
+			}
+			games[machine.Item.Name] = append(games[machine.Item.Name], machine.Version_group.Name)
+		}
+		fmt.Println("Taught by:")
+		for _, name := range order {
+			fmt.Printf("- %s (%s)\n", name, strings.Join(games[name], ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// how a pokemon at a level can learn a move, "" if it can't
+func (learnset Learnset) HowLearned(move string, level int) string {
+	for _, learnable := range learnset.Moves {
+		if learnable.Move.Name != move {
+			continue
+		}
+		if at, ok := learnable.LevelLearned(); ok && at <= level {
+			return fmt.Sprintf("level %d", at)
+		}
+		for _, detail := range learnable.Version_group_details {
+			if detail.Move_learn_method.Name == "machine" {
+				return "machine"
+			}
+		}
+	}
+	return ""
+}
+
+// the moves a caught pokemon knows, the ones a wild pokemon of its level would know until it's taught others
+func (client *Client) Moveset(caught *CaughtPokemon) ([]string, error) {
+	if len(caught.Moves) > 0 {
+		return caught.Moves, nil
+	}
+	learnset, err := client.GetLearnset(caught.Name)
+	if err != nil {
+		return nil, err
+	}
+	return learnset.LevelUpMoves(caught.Level), nil
+}
+
+// whether a move is in the moveset
+func knowsMove(moves []string, move string) bool {
+	for _, m := range moves {
+		if m == move {
+			return true
+		}
+	}
+	return false
+}
+
+// teach a caught pokemon a move, asking which move to forget when it already knows four
+// returns false if the player decided not to
+func (session *Session) teachMove(caught *CaughtPokemon, move string) (bool, error) {
+	moves, err := session.client.Moveset(caught)
+	if err != nil {
+		return false, err
+	}
+	if knowsMove(moves, move) {
+		return false, fmt.Errorf("%s already knows %s", caught.DisplayName(), move)
+	}
+	moves = append([]string{}, moves...)
+	if len(moves) < maxMoves {
+		caught.Moves = append(moves, move)
+		fmt.Println(caught.DisplayName(), "learned", move+"!")
+		return true, nil
+	}
+
+	fmt.Printf("%s already knows %d moves: %s\n", caught.DisplayName(), maxMoves, strings.Join(moves, ", "))
+	for {
+		answer, ok := session.Prompt(fmt.Sprintf("Which move should it forget for %s? (enter to keep them all)", move))
+		if !ok || answer == "" {
+			fmt.Println(caught.DisplayName(), "did not learn", move)
+			return false, nil
+		}
+		forget := apiName(strings.Fields(answer))
+		for i, m := range moves {
+			if m == forget {
+				moves[i] = move
+				caught.Moves = moves
+				fmt.Printf("%s forgot %s and learned %s!\n", caught.DisplayName(), forget, move)
+				return true, nil
+			}
+		}
+		fmt.Println("Choose one of:", strings.Join(moves, ", "))
+	}
+}
+
+// learn [pokemon] [move] - teach a caught pokemon a move it can learn by now
+func learnCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter a pokemon and a move")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	move := apiName(params[1:])
+	learnset, err := session.client.GetLearnset(caught.Name)
+	if err != nil {
+		return err
+	}
+	how := learnset.HowLearned(move, caught.Level)
+	if how == "" {
+		return fmt.Errorf("%s can't learn %s at level %d", caught.DisplayName(), move, caught.Level)
+	}
+	learned, err := session.teachMove(caught, move)
+	if err != nil || !learned {
+		return err
+	}
+	return session.Save()
+}
+
+// forget [pokemon] [move] - make a caught pokemon forget a move, it has to keep one
+func forgetCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter a pokemon and a move")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	move := apiName(params[1:])
+	moves, err := session.client.Moveset(caught)
+	if err != nil {
+		return err
+	}
+	if !knowsMove(moves, move) {
+		return fmt.Errorf("%s doesn't know %s", caught.DisplayName(), move)
+	}
+	if len(moves) == 1 {
+		return fmt.Errorf("%s can't forget its only move", caught.DisplayName())
+	}
+	kept := []string{}
+	for _, m := range moves {
+		if m != move {
+			kept = append(kept, m)
+		}
+	}
+	caught.Moves = kept
+	fmt.Println(caught.DisplayName(), "forgot", move)
+	return session.Save()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHowLearned(t *testing.T) {
+	var learnset Learnset
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "thunderbolt"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "machine"}}]},
+		{"move": {"name": "thunder"}, "version_group_details": [{"level_learned_at": 50, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "volt-tackle"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "egg"}}]}
+	]}`), &learnset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{"thunderbolt": "machine", "thunder": "", "volt-tackle": "", "surf": ""}
+	for move, expected := range cases {
+		if how := learnset.HowLearned(move, 20); how != expected {
+			t.Errorf("%s: expected %q, got %q", move, expected, how)
+		}
+	}
+	if how := learnset.HowLearned("thunder", 50); how != "level 50" {
+		t.Errorf("expected thunder at level 50, got %q", how)
+	}
+}
+
+func TestTeachMoveReplaces(t *testing.T) {
+	caught := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu"}, Moves: []string{"growl", "tail-whip", "quick-attack", "thunder-shock"}}
+	session := &Session{input: bufio.NewScanner(strings.NewReader("splash\nTail Whip\n\n"))}
+
+	learned, err := session.teachMove(caught, "thunderbolt")
+	if err != nil || !learned {
+		t.Fatalf("expected thunderbolt to be learned, got %v (%v)", learned, err)
+	}
+	if strings.Join(caught.Moves, ",") != "growl,thunderbolt,quick-attack,thunder-shock" {
+		t.Errorf("expected tail-whip replaced, got %v", caught.Moves)
+	}
+	if _, err := session.teachMove(caught, "growl"); err == nil {
+		t.Errorf("expected an error teaching a known move")
+	}
+	// enter keeps the moves it has
+	if learned, _ := session.teachMove(caught, "thunder"); learned {
+		t.Errorf("expected thunder not to be learned")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Nature struct {
+	Id             int             `json:"id"`
+	Name           string          `json:"name"`
+	Names          []LocalizedName `json:"names"`
+	Increased_stat *NamedResource  `json:"increased_stat"`
+	Decreased_stat *NamedResource  `json:"decreased_stat"`
+	Likes_flavor   *NamedResource  `json:"likes_flavor"`
+	Hates_flavor   *NamedResource  `json:"hates_flavor"`
+}
+
+// fetch a nature by name
+func (client *Client) GetNature(name string) (Nature, error) {
+	var nature Nature
+	err := client.GetJSON(client.ResourceURL("nature", name), &nature)
+	return nature, err
+}
+
+// a nature raises one stat by 10% and lowers another by 10%, neutral natures change the same stat both ways
+func (nature Nature) Multiplier(stat string) float64 {
+	if nature.Increased_stat == nil || nature.Decreased_stat == nil {
+		return 1.0
+	}
+	if nature.Increased_stat.Name == nature.Decreased_stat.Name {
+		return 1.0
+	}
+	switch stat {
+	case nature.Increased_stat.Name:
+		return 1.1
+	case nature.Decreased_stat.Name:
+		return 0.9
+	}
+	return 1.0
+}
+
+// nature [nature] [pokemon] - show what a nature does, or give it to a pokemon you caught
+func natureCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a nature")
+		return nil
+	}
+
+	nature, err := session.client.GetNature(strings.ToLower(params[0]))
+	if err != nil {
+		return err
+	}
+
+	if len(params) > 1 {
+		caught, err := session.pokedex.Find(params[1])
+		if err != nil {
+			return err
+		}
+		caught.Nature = nature.Name
+		fmt.Println(caught.DisplayName(), "now has a", nature.Name, "nature")
+		return session.Save()
+	}
+
+	fmt.Println("Nature:", englishName(nature.Names, nature.Name))
+	if nature.Multiplier(nameOrEmpty(nature.Increased_stat)) == 1.0 {
+		fmt.Println("Neutral nature, no stat changes")
+	} else {
+		fmt.Println("Raises:", nature.Increased_stat.Name, "(+10%)")
+		fmt.Println("Lowers:", nature.Decreased_stat.Name, "(-10%)")
+	}
+	if nature.Likes_flavor != nil && nature.Hates_flavor != nil {
+		fmt.Println("Likes", nature.Likes_flavor.Name, "food, hates", nature.Hates_flavor.Name, "food")
+	}
+	return nil
+}
+
+// the name of an optional resource, "" if it's missing
+func nameOrEmpty(resource *NamedResource) string {
+	if resource == nil {
+		return ""
+	}
+	return resource.Name
+}
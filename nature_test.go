@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestNatureMultiplier(t *testing.T) {
+	adamant := Nature{
+		Name:           "adamant",
+		Increased_stat: &NamedResource{Name: "attack"},
+		Decreased_stat: &NamedResource{Name: "special-attack"},
+	}
+	cases := map[string]float64{"attack": 1.1, "special-attack": 0.9, "speed": 1.0}
+	for stat, expected := range cases {
+		if multiplier := adamant.Multiplier(stat); multiplier != expected {
+			t.Errorf("%v: expected %v, got %v", stat, expected, multiplier)
+		}
+	}
+
+	hardy := Nature{
+		Name:           "hardy",
+		Increased_stat: &NamedResource{Name: "attack"},
+		Decreased_stat: &NamedResource{Name: "attack"},
+	}
+	if multiplier := hardy.Multiplier("attack"); multiplier != 1.0 {
+		t.Errorf("neutral nature should not change attack, got %v", multiplier)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// where a nuzlocke run is: off -> active -> lost when the party is wiped out, or off again when ended
+const (
+	nuzlockeOff    = ""
+	nuzlockeActive = "active"
+	nuzlockeLost   = "lost"
+)
+
+// an opt-in challenge run, kept in the profile so it carries across sessions
+type Nuzlocke struct {
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	// location areas whose one encounter is used up, and the pokemon met there
+	Areas map[string]string `json:"areas"`
+	// species caught during the run, a species met again doesn't use up an area
+	Species []string `json:"species"`
+}
+
+func (nuzlocke *Nuzlocke) Active() bool {
+	return nuzlocke.Status == nuzlockeActive
+}
+
+// begin a new run, forgetting the last one
+func (nuzlocke *Nuzlocke) Start(now time.Time) {
+	*nuzlocke = Nuzlocke{Status: nuzlockeActive, StartedAt: now, Areas: make(map[string]string)}
+}
+
+// give up on the run
+func (nuzlocke *Nuzlocke) End() error {
+	if nuzlocke.Status == nuzlockeOff {
+		return errors.New("you aren't doing a nuzlocke run")
+	}
+	nuzlocke.Status = nuzlockeOff
+	return nil
+}
+
+// whether a species was already caught during the run
+func (nuzlocke *Nuzlocke) HasSpecies(species string) bool {
+	for _, s := range nuzlocke.Species {
+		if s == species {
+			return true
+		}
+	}
+	return false
+}
+
+// check that the first pokemon met in an area may be caught, and use up the area if so
+func (nuzlocke *Nuzlocke) Encounter(area string, caught *CaughtPokemon) error {
+	if met, ok := nuzlocke.Areas[area]; ok {
+		return fmt.Errorf("you already met %s in %s, only the first encounter in an area counts", met, area)
+	}
+	if nuzlocke.HasSpecies(caught.SpeciesName()) {
+		return fmt.Errorf("you already caught a %s this run, keep looking in %s for something new", caught.SpeciesName(), area)
+	}
+	if nuzlocke.Areas == nil {
+		nuzlocke.Areas = make(map[string]string)
+	}
+	nuzlocke.Areas[area] = caught.Name
+	return nil
+}
+
+// a party pokemon fainted, in a nuzlocke run that's forever and losing the whole party ends the run
+func (session *Session) Faint(caught *CaughtPokemon) {
+	nuzlocke := &session.profile.Nuzlocke
+	if !nuzlocke.Active() {
+		return
+	}
+	caught.Dead = true
+	session.pokedex.leaveParty(caught)
+	fmt.Println(caught.DisplayName(), "has died")
+	if len(session.pokedex.PartyMembers()) == 0 {
+		nuzlocke.Status = nuzlockeLost
+		fmt.Println("Your whole party is gone, the nuzlocke run is over")
+	}
+}
+
+// remember species caught during a run, the catch saves afterwards
+func (session *Session) trackNuzlocke(event GameEvent) {
+	nuzlocke := &session.profile.Nuzlocke
+	if event.Kind == "catch" && nuzlocke.Active() && !nuzlocke.HasSpecies(event.Caught.SpeciesName()) {
+		nuzlocke.Species = append(nuzlocke.Species, event.Caught.SpeciesName())
+	}
+}
+
+// nuzlocke [start|end] - show the run, or start or give up on one
+func nuzlockeCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	nuzlocke := &session.profile.Nuzlocke
+
+	if len(params) == 0 {
+		switch nuzlocke.Status {
+		case nuzlockeOff:
+			fmt.Println("You aren't doing a nuzlocke run, start one with nuzlocke start")
+			return nil
+		case nuzlockeLost:
+			fmt.Println("Your last nuzlocke run was lost")
+		default:
+			fmt.Println("Nuzlocke run since", nuzlocke.StartedAt.Format(dateLayout))
+		}
+		fmt.Println("Areas used:", len(nuzlocke.Areas))
+		fmt.Println("Species caught:", len(nuzlocke.Species))
+		dead := 0
+		for _, caught := range session.pokedex.Pokemon {
+			if caught.Dead {
+				dead++
+			}
+		}
+		fmt.Println("Pokemon lost:", dead)
+		return nil
+	}
+
+	switch params[0] {
+	case "start":
+		if nuzlocke.Active() {
+			return errors.New("you're already doing a nuzlocke run")
+		}
+		if !session.Confirm("Start a nuzlocke run? Only the first pokemon in each area can be caught, and fainted pokemon are gone for good") {
+			return nil
+		}
+		nuzlocke.Start(time.Now())
+		fmt.Println("Good luck! Catch pokemon with encounter from now on")
+	case "end":
+		err := nuzlocke.End()
+		if err != nil {
+			return err
+		}
+		fmt.Println("The nuzlocke run is over, the usual rules apply again")
+	default:
+		fmt.Println("Use nuzlocke start or nuzlocke end")
+		return nil
+	}
+	return session.Save()
+}
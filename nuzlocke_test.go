@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNuzlockeRun(t *testing.T) {
+	session := &Session{pokedex: NewPokedex(), profile: NewProfile(), events: NewEventBus()}
+	session.events.Subscribe(session.trackNuzlocke)
+	nuzlocke := &session.profile.Nuzlocke
+	nuzlocke.Start(time.Now())
+
+	pidgey := &CaughtPokemon{Pokemon: Pokemon{Name: "pidgey", Species: NamedResource{Name: "pidgey"}}}
+	if err := nuzlocke.Encounter("route-1", pidgey); err != nil {
+		t.Fatal(err)
+	}
+	session.pokedex.Add(pidgey)
+	session.events.Publish(GameEvent{Kind: "catch", Caught: pidgey})
+
+	rattata := &CaughtPokemon{Pokemon: Pokemon{Name: "rattata", Species: NamedResource{Name: "rattata"}}}
+	if err := nuzlocke.Encounter("route-1", rattata); err == nil {
+		t.Errorf("expected a second encounter on route 1 to be refused")
+	}
+	// a duplicate doesn't use up the area
+	if err := nuzlocke.Encounter("route-2", pidgey); err == nil {
+		t.Errorf("expected a duplicate species to be refused")
+	}
+	if err := nuzlocke.Encounter("route-2", rattata); err != nil {
+		t.Errorf("expected route 2 to still be open, got %v", err)
+	}
+	session.pokedex.Add(rattata)
+
+	session.Faint(pidgey)
+	if !pidgey.Dead || session.pokedex.InParty(pidgey) || !nuzlocke.Active() {
+		t.Errorf("expected pidgey dead and out of the party with the run still going")
+	}
+	if err := session.pokedex.Withdraw(pidgey); err == nil {
+		t.Errorf("expected a dead pokemon to stay in the pc")
+	}
+	session.Faint(rattata)
+	if nuzlocke.Status != nuzlockeLost {
+		t.Errorf("expected the run to be lost, got %q", nuzlocke.Status)
+	}
+}
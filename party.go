@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// one line about a caught pokemon for party and box listings
+func caughtSummary(caught *CaughtPokemon) string {
+	line := fmt.Sprintf("#%d %s", caught.InstanceID, caught.DisplayName())
+	if caught.Nickname != "" {
+		line += " (" + caught.Name + ")"
+	}
+	line += fmt.Sprintf(" lv.%d", caught.Level)
+	if caught.Shiny {
+		line += " " + shinyMarker
+	}
+	if caught.Item != "" {
+		line += " @ " + caught.Item
+	}
+	return line
+}
+
+// show the pokemon traveling with you
+func partyCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	members := session.pokedex.PartyMembers()
+	fmt.Printf("Party (%d/%d):\n", len(members), maxPartySize)
+	for i, caught := range members {
+		fmt.Printf("%d. %s\n", i+1, caughtSummary(caught))
+	}
+	return nil
+}
+
+// deposit [pokemon] - move a party pokemon to the pc
+func depositCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	err = session.pokedex.Deposit(caught)
+	if err != nil {
+		return err
+	}
+	fmt.Println(caught.DisplayName(), "was sent to the pc")
+	return session.Save()
+}
+
+// withdraw [pokemon] - move a pc pokemon to the party
+func withdrawCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	err = session.pokedex.Withdraw(caught)
+	if err != nil {
+		return err
+	}
+	fmt.Println(caught.DisplayName(), "joined your party")
+	return session.Save()
+}
+
+// box list [n] - show the pokemon in a pc box, the first one by default
+func boxCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 || params[0] != "list" {
+		fmt.Println("Use box list [n] to see what's in a box")
+		return nil
+	}
+
+	n := 1
+	if len(params) > 1 {
+		var err error
+		n, err = strconv.Atoi(params[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("%q isn't a box number", params[1])
+		}
+	}
+	boxes := session.pokedex.BoxCount()
+	if n > boxes {
+		return fmt.Errorf("you only have %d boxes", boxes)
+	}
+
+	box := session.pokedex.Box(n)
+	fmt.Printf("Box %d of %d (%d/%d):\n", n, boxes, len(box), boxSize)
+	for _, caught := range box {
+		fmt.Println("-", caughtSummary(caught))
+	}
+	return nil
+}
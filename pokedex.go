@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the pokemon a player has caught, each with its own id so two of a species can be told apart
+type Pokedex struct {
+	// the id the next catch gets
+	NextID  int              `json:"next_id"`
+	Pokemon []*CaughtPokemon `json:"pokemon"`
+	// instance ids of the pokemon traveling with the player, in party order, everything else is in the pc
+	Party []int `json:"party"`
+}
+
+// the most pokemon a party can hold
+const maxPartySize = 6
+
+// pokemon per pc box
+const boxSize = 30
+
+func NewPokedex() *Pokedex {
+	return &Pokedex{NextID: 1}
+}
+
+// the name commands show for a caught pokemon, its nickname if it has one
+func (caught *CaughtPokemon) DisplayName() string {
+	if caught.Nickname != "" {
+		return caught.Nickname
+	}
+	return caught.Name
+}
+
+// add a caught pokemon, giving it the next id
+// it joins the party if there's room and goes to the pc otherwise, returns whether it joined the party
+func (pokedex *Pokedex) Add(caught *CaughtPokemon) bool {
+	if pokedex.NextID < 1 {
+		pokedex.NextID = 1
+	}
+	caught.InstanceID = pokedex.NextID
+	pokedex.NextID++
+	pokedex.Pokemon = append(pokedex.Pokemon, caught)
+	if len(pokedex.Party) < maxPartySize {
+		pokedex.Party = append(pokedex.Party, caught.InstanceID)
+		return true
+	}
+	return false
+}
+
+// take a pokemon out of the pokedex, returns whether it was there
+func (pokedex *Pokedex) Remove(caught *CaughtPokemon) bool {
+	pokedex.leaveParty(caught)
+	for i, p := range pokedex.Pokemon {
+		if p == caught {
+			pokedex.Pokemon = append(pokedex.Pokemon[:i], pokedex.Pokemon[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (pokedex *Pokedex) Len() int {
+	return len(pokedex.Pokemon)
+}
+
+// whether the pokemon is in the party rather than the pc
+func (pokedex *Pokedex) InParty(caught *CaughtPokemon) bool {
+	for _, id := range pokedex.Party {
+		if id == caught.InstanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// the party in order
+func (pokedex *Pokedex) PartyMembers() []*CaughtPokemon {
+	members := []*CaughtPokemon{}
+	for _, id := range pokedex.Party {
+		for _, caught := range pokedex.Pokemon {
+			if caught.InstanceID == id {
+				members = append(members, caught)
+			}
+		}
+	}
+	return members
+}
+
+// the pokemon in the pc, in the order they were caught
+func (pokedex *Pokedex) Boxed() []*CaughtPokemon {
+	boxed := []*CaughtPokemon{}
+	for _, caught := range pokedex.Pokemon {
+		if !pokedex.InParty(caught) {
+			boxed = append(boxed, caught)
+		}
+	}
+	return boxed
+}
+
+// the pc pokemon in box n, counting from 1
+func (pokedex *Pokedex) Box(n int) []*CaughtPokemon {
+	boxed := pokedex.Boxed()
+	start := (n - 1) * boxSize
+	if n < 1 || start >= len(boxed) {
+		return nil
+	}
+	end := start + boxSize
+	if end > len(boxed) {
+		end = len(boxed)
+	}
+	return boxed[start:end]
+}
+
+// how many boxes the pc is using, at least one
+func (pokedex *Pokedex) BoxCount() int {
+	count := (len(pokedex.Boxed()) + boxSize - 1) / boxSize
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+// move a party pokemon to the pc
+func (pokedex *Pokedex) Deposit(caught *CaughtPokemon) error {
+	if !pokedex.InParty(caught) {
+		return fmt.Errorf("%s is already in the pc", caught.DisplayName())
+	}
+	if len(pokedex.Party) == 1 {
+		return fmt.Errorf("%s is the last pokemon in your party", caught.DisplayName())
+	}
+	pokedex.leaveParty(caught)
+	return nil
+}
+
+// move a pc pokemon to the party
+func (pokedex *Pokedex) Withdraw(caught *CaughtPokemon) error {
+	if pokedex.InParty(caught) {
+		return fmt.Errorf("%s is already in your party", caught.DisplayName())
+	}
+	if caught.Dead {
+		return fmt.Errorf("%s fainted in your nuzlocke run and can't come back", caught.DisplayName())
+	}
+	if caught.Daycare {
+		return fmt.Errorf("%s is at the daycare, collect it first", caught.DisplayName())
+	}
+	if len(pokedex.Party) >= maxPartySize {
+		return fmt.Errorf("your party is full, deposit a pokemon first")
+	}
+	pokedex.Party = append(pokedex.Party, caught.InstanceID)
+	return nil
+}
+
+func (pokedex *Pokedex) leaveParty(caught *CaughtPokemon) {
+	for i, id := range pokedex.Party {
+		if id == caught.InstanceID {
+			pokedex.Party = append(pokedex.Party[:i], pokedex.Party[i+1:]...)
+			return
+		}
+	}
+}
+
+// whether any caught pokemon is the pokemon name, like "pikachu" or "raichu-alola"
+func (pokedex *Pokedex) HasPokemon(name string) bool {
+	return pokedex.Count(name) > 0
+}
+
+// how many of the pokemon name have been caught, a pokemon can be caught any number of times
+func (pokedex *Pokedex) Count(name string) int {
+	count := 0
+	for _, caught := range pokedex.Pokemon {
+		if caught.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+// find a caught pokemon by nickname, id ("#3" or "3") or pokemon name
+// a pokemon name only works when there is just one of it
+func (pokedex *Pokedex) Find(ref string) (*CaughtPokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for _, caught := range pokedex.Pokemon {
+			if caught.InstanceID == id {
+				return caught, nil
+			}
+		}
+		return nil, fmt.Errorf("you have no pokemon #%d", id)
+	}
+
+	for _, caught := range pokedex.Pokemon {
+		if caught.Nickname != "" && strings.EqualFold(caught.Nickname, ref) {
+			return caught, nil
+		}
+	}
+
+	matches := []*CaughtPokemon{}
+	for _, caught := range pokedex.Pokemon {
+		if caught.Name == strings.ToLower(ref) {
+			matches = append(matches, caught)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("you have not caught %s", ref)
+	case 1:
+		return matches[0], nil
+	}
+	ids := []string{}
+	for _, caught := range matches {
+		ids = append(ids, "#"+strconv.Itoa(caught.InstanceID))
+	}
+	return nil, fmt.Errorf("you have %d %s (%s), use a nickname or id", len(matches), ref, strings.Join(ids, ", "))
+}
+
+// saves from before instance ids kept the pokedex as a map of pokemon name to pokemon, read both
+func (pokedex *Pokedex) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	_, hasPokemon := fields["pokemon"]
+	_, hasNextID := fields["next_id"]
+	if hasPokemon || hasNextID || len(fields) == 0 {
+		// an alias type doesn't have this method, so decoding into it doesn't recurse
+		type plain Pokedex
+		var decoded plain
+		err := json.Unmarshal(data, &decoded)
+		if err != nil {
+			return err
+		}
+		*pokedex = Pokedex(decoded)
+		if pokedex.NextID < 1 {
+			pokedex.NextID = 1
+		}
+		// saves from before the pc had everyone in one list, the first six make up the party
+		if _, hasParty := fields["party"]; !hasParty {
+			for i := 0; i < len(pokedex.Pokemon) && i < maxPartySize; i++ {
+				pokedex.Party = append(pokedex.Party, pokedex.Pokemon[i].InstanceID)
+			}
+		}
+		return nil
+	}
+
+	// the old format: number the pokemon in name order
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	*pokedex = *NewPokedex()
+	for _, name := range names {
+		caught := &CaughtPokemon{}
+		err := json.Unmarshal(fields[name], caught)
+		if err != nil {
+			return fmt.Errorf("pokedex entry %s: %w", name, err)
+		}
+		pokedex.Add(caught)
+	}
+	return nil
+}
+
+// nickname [pokemon] [nickname] - name a caught pokemon, every command accepts the nickname after that
+func nicknameCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter a pokemon and a nickname")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	nickname := params[1]
+	// nicknames that look like ids or another pokemon's nickname would make Find ambiguous
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		fmt.Println("Nicknames can't be numbers")
+		return nil
+	}
+	for _, other := range session.pokedex.Pokemon {
+		if other != caught && strings.EqualFold(other.Nickname, nickname) {
+			fmt.Println("Your", other.Name, "is already called", other.Nickname)
+			return nil
+		}
+	}
+
+	caught.Nickname = nickname
+	fmt.Println(caught.Name, "is now called", nickname)
+	return session.Save()
+}
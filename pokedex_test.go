@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+func TestPokedexFind(t *testing.T) {
+	pokedex := NewPokedex()
+	first := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu"}, Nickname: "Sparky"}
+	second := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu"}}
+	eevee := &CaughtPokemon{Pokemon: Pokemon{Name: "eevee"}}
+	pokedex.Add(first)
+	pokedex.Add(second)
+	pokedex.Add(eevee)
+
+	cases := map[string]*CaughtPokemon{
+		"sparky": first,
+		"#2":     second,
+		"3":      eevee,
+		"Eevee":  eevee,
+	}
+	for ref, expected := range cases {
+		caught, err := pokedex.Find(ref)
+		if err != nil || caught != expected {
+			t.Errorf("%v: expected #%d, got %+v (%v)", ref, expected.InstanceID, caught, err)
+		}
+	}
+
+	// two pikachu, the name alone doesn't say which
+	if _, err := pokedex.Find("pikachu"); err == nil {
+		t.Errorf("expected an error for an ambiguous name")
+	}
+	if _, err := pokedex.Find("mew"); err == nil {
+		t.Errorf("expected an error for a pokemon that wasn't caught")
+	}
+
+	pokedex.Remove(second)
+	if caught, err := pokedex.Find("pikachu"); err != nil || caught != first {
+		t.Errorf("expected the one pikachu left, got %+v (%v)", caught, err)
+	}
+	if !pokedex.HasPokemon("eevee") || pokedex.HasPokemon("mew") {
+		t.Errorf("unexpected HasPokemon results")
+	}
+	if pokedex.Count("pikachu") != 1 || pokedex.Count("mew") != 0 {
+		t.Errorf("unexpected counts")
+	}
+
+	// ids are never reused
+	pokedex.Add(second)
+	if second.InstanceID != 4 {
+		t.Errorf("expected id 4, got %d", second.InstanceID)
+	}
+	if pokedex.Count("pikachu") != 2 {
+		t.Errorf("expected both pikachu to count, got %d", pokedex.Count("pikachu"))
+	}
+}
+
+func TestPartyAndBoxes(t *testing.T) {
+	pokedex := NewPokedex()
+	all := []*CaughtPokemon{}
+	for i := 0; i < maxPartySize+boxSize+1; i++ {
+		caught := &CaughtPokemon{Pokemon: Pokemon{Name: "rattata"}}
+		joined := pokedex.Add(caught)
+		if joined != (i < maxPartySize) {
+			t.Fatalf("catch %d: expected joined party %v, got %v", i, i < maxPartySize, joined)
+		}
+		all = append(all, caught)
+	}
+	if len(pokedex.PartyMembers()) != maxPartySize {
+		t.Errorf("expected a full party, got %d", len(pokedex.PartyMembers()))
+	}
+	if pokedex.BoxCount() != 2 || len(pokedex.Box(1)) != boxSize || len(pokedex.Box(2)) != 1 {
+		t.Errorf("expected one full box and one with a single pokemon, got %d boxes", pokedex.BoxCount())
+	}
+
+	if err := pokedex.Withdraw(all[10]); err == nil {
+		t.Errorf("expected an error withdrawing into a full party")
+	}
+	if err := pokedex.Deposit(all[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := pokedex.Withdraw(all[10]); err != nil {
+		t.Fatal(err)
+	}
+	if members := pokedex.PartyMembers(); members[len(members)-1] != all[10] || pokedex.InParty(all[0]) {
+		t.Errorf("expected #11 at the end of the party and #1 in the pc")
+	}
+
+	pokedex.Remove(all[10])
+	if len(pokedex.Party) != maxPartySize-1 {
+		t.Errorf("expected releasing a party member to leave a gap, got %v", pokedex.Party)
+	}
+
+	last := NewPokedex()
+	only := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu"}}
+	last.Add(only)
+	if err := last.Deposit(only); err == nil {
+		t.Errorf("expected an error depositing the last party member")
+	}
+}
@@ -0,0 +1,4 @@
+// Package pokedexpb is the pokedex grpc service, generated from pokedex.proto.
+package pokedexpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pokedex.proto
@@ -0,0 +1,863 @@
+// The pokedex core as a gRPC service, serve --grpc answers it and pokedexcli --remote talks to it.
+// The calls and messages match serve's json api field for field.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pokedex.proto
+
+package pokedexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CatchRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon string                 `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	// the selected ball when empty
+	Ball          string `protobuf:"bytes,2,opt,name=ball,proto3" json:"ball,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatchRequest) Reset() {
+	*x = CatchRequest{}
+	mi := &file_pokedex_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatchRequest) ProtoMessage() {}
+
+func (x *CatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatchRequest.ProtoReflect.Descriptor instead.
+func (*CatchRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CatchRequest) GetPokemon() string {
+	if x != nil {
+		return x.Pokemon
+	}
+	return ""
+}
+
+func (x *CatchRequest) GetBall() string {
+	if x != nil {
+		return x.Ball
+	}
+	return ""
+}
+
+type CatchResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon  string                 `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	Ball     string                 `protobuf:"bytes,2,opt,name=ball,proto3" json:"ball,omitempty"`
+	Chance   float64                `protobuf:"fixed64,3,opt,name=chance,proto3" json:"chance,omitempty"`
+	Shakes   int32                  `protobuf:"varint,4,opt,name=shakes,proto3" json:"shakes,omitempty"`
+	Critical bool                   `protobuf:"varint,5,opt,name=critical,proto3" json:"critical,omitempty"`
+	// "caught" or "broke free"
+	Outcome   string `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	BallsLeft int32  `protobuf:"varint,7,opt,name=balls_left,json=ballsLeft,proto3" json:"balls_left,omitempty"`
+	// set when the pokemon was caught
+	Caught        *Pokemon `protobuf:"bytes,8,opt,name=caught,proto3" json:"caught,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatchResult) Reset() {
+	*x = CatchResult{}
+	mi := &file_pokedex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatchResult) ProtoMessage() {}
+
+func (x *CatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatchResult.ProtoReflect.Descriptor instead.
+func (*CatchResult) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CatchResult) GetPokemon() string {
+	if x != nil {
+		return x.Pokemon
+	}
+	return ""
+}
+
+func (x *CatchResult) GetBall() string {
+	if x != nil {
+		return x.Ball
+	}
+	return ""
+}
+
+func (x *CatchResult) GetChance() float64 {
+	if x != nil {
+		return x.Chance
+	}
+	return 0
+}
+
+func (x *CatchResult) GetShakes() int32 {
+	if x != nil {
+		return x.Shakes
+	}
+	return 0
+}
+
+func (x *CatchResult) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *CatchResult) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *CatchResult) GetBallsLeft() int32 {
+	if x != nil {
+		return x.BallsLeft
+	}
+	return 0
+}
+
+func (x *CatchResult) GetCaught() *Pokemon {
+	if x != nil {
+		return x.Caught
+	}
+	return nil
+}
+
+type InspectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// an id like "#3", a nickname or a species name
+	Pokemon       string `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectRequest) Reset() {
+	*x = InspectRequest{}
+	mi := &file_pokedex_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectRequest) ProtoMessage() {}
+
+func (x *InspectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectRequest.ProtoReflect.Descriptor instead.
+func (*InspectRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InspectRequest) GetPokemon() string {
+	if x != nil {
+		return x.Pokemon
+	}
+	return ""
+}
+
+type ListCaughtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCaughtRequest) Reset() {
+	*x = ListCaughtRequest{}
+	mi := &file_pokedex_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCaughtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCaughtRequest) ProtoMessage() {}
+
+func (x *ListCaughtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCaughtRequest.ProtoReflect.Descriptor instead.
+func (*ListCaughtRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{3}
+}
+
+type ListCaughtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       []*Pokemon             `protobuf:"bytes,1,rep,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCaughtResponse) Reset() {
+	*x = ListCaughtResponse{}
+	mi := &file_pokedex_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCaughtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCaughtResponse) ProtoMessage() {}
+
+func (x *ListCaughtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCaughtResponse.ProtoReflect.Descriptor instead.
+func (*ListCaughtResponse) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCaughtResponse) GetPokemon() []*Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type Pokemon struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number   int32                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Nickname string                 `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Form     string                 `protobuf:"bytes,5,opt,name=form,proto3" json:"form,omitempty"`
+	Types    []string               `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
+	Level    int32                  `protobuf:"varint,7,opt,name=level,proto3" json:"level,omitempty"`
+	Shiny    bool                   `protobuf:"varint,8,opt,name=shiny,proto3" json:"shiny,omitempty"`
+	Favorite bool                   `protobuf:"varint,9,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	Party    bool                   `protobuf:"varint,10,opt,name=party,proto3" json:"party,omitempty"`
+	Item     string                 `protobuf:"bytes,11,opt,name=item,proto3" json:"item,omitempty"`
+	// a date like 2024-03-01
+	CaughtOn      string `protobuf:"bytes,12,opt,name=caught_on,json=caughtOn,proto3" json:"caught_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_pokedex_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pokemon) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pokemon) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Pokemon) GetForm() string {
+	if x != nil {
+		return x.Form
+	}
+	return ""
+}
+
+func (x *Pokemon) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Pokemon) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Pokemon) GetShiny() bool {
+	if x != nil {
+		return x.Shiny
+	}
+	return false
+}
+
+func (x *Pokemon) GetFavorite() bool {
+	if x != nil {
+		return x.Favorite
+	}
+	return false
+}
+
+func (x *Pokemon) GetParty() bool {
+	if x != nil {
+		return x.Party
+	}
+	return false
+}
+
+func (x *Pokemon) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *Pokemon) GetCaughtOn() string {
+	if x != nil {
+		return x.CaughtOn
+	}
+	return ""
+}
+
+type PokemonDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	Nature        string                 `protobuf:"bytes,2,opt,name=nature,proto3" json:"nature,omitempty"`
+	Experience    int32                  `protobuf:"varint,3,opt,name=experience,proto3" json:"experience,omitempty"`
+	Happiness     int32                  `protobuf:"varint,4,opt,name=happiness,proto3" json:"happiness,omitempty"`
+	Stats         map[string]int32       `protobuf:"bytes,5,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Ivs           map[string]int32       `protobuf:"bytes,6,rep,name=ivs,proto3" json:"ivs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Evs           map[string]int32       `protobuf:"bytes,7,rep,name=evs,proto3" json:"evs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Moves         []string               `protobuf:"bytes,8,rep,name=moves,proto3" json:"moves,omitempty"`
+	Sprite        string                 `protobuf:"bytes,9,opt,name=sprite,proto3" json:"sprite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PokemonDetails) Reset() {
+	*x = PokemonDetails{}
+	mi := &file_pokedex_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokemonDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokemonDetails) ProtoMessage() {}
+
+func (x *PokemonDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokemonDetails.ProtoReflect.Descriptor instead.
+func (*PokemonDetails) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PokemonDetails) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetNature() string {
+	if x != nil {
+		return x.Nature
+	}
+	return ""
+}
+
+func (x *PokemonDetails) GetExperience() int32 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *PokemonDetails) GetHappiness() int32 {
+	if x != nil {
+		return x.Happiness
+	}
+	return 0
+}
+
+func (x *PokemonDetails) GetStats() map[string]int32 {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetIvs() map[string]int32 {
+	if x != nil {
+		return x.Ivs
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetEvs() map[string]int32 {
+	if x != nil {
+		return x.Evs
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetMoves() []string {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetSprite() string {
+	if x != nil {
+		return x.Sprite
+	}
+	return ""
+}
+
+type ExploreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Area  string                 `protobuf:"bytes,1,opt,name=area,proto3" json:"area,omitempty"`
+	// only pokemon met in this game when set
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExploreRequest) Reset() {
+	*x = ExploreRequest{}
+	mi := &file_pokedex_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExploreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExploreRequest) ProtoMessage() {}
+
+func (x *ExploreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExploreRequest.ProtoReflect.Descriptor instead.
+func (*ExploreRequest) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExploreRequest) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *ExploreRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type Area struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Area          string                 `protobuf:"bytes,1,opt,name=area,proto3" json:"area,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Pokemon       []*Encounter           `protobuf:"bytes,3,rep,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Area) Reset() {
+	*x = Area{}
+	mi := &file_pokedex_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Area) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Area) ProtoMessage() {}
+
+func (x *Area) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Area.ProtoReflect.Descriptor instead.
+func (*Area) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Area) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *Area) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Area) GetPokemon() []*Encounter {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type Encounter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// chance out of 100 of meeting it
+	Rate          int32 `protobuf:"varint,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Encounter) Reset() {
+	*x = Encounter{}
+	mi := &file_pokedex_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Encounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Encounter) ProtoMessage() {}
+
+func (x *Encounter) ProtoReflect() protoreflect.Message {
+	mi := &file_pokedex_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Encounter.ProtoReflect.Descriptor instead.
+func (*Encounter) Descriptor() ([]byte, []int) {
+	return file_pokedex_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Encounter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Encounter) GetRate() int32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+var File_pokedex_proto protoreflect.FileDescriptor
+
+const file_pokedex_proto_rawDesc = "" +
+	"\n" +
+	"\rpokedex.proto\x12\apokedex\"<\n" +
+	"\fCatchRequest\x12\x18\n" +
+	"\apokemon\x18\x01 \x01(\tR\apokemon\x12\x12\n" +
+	"\x04ball\x18\x02 \x01(\tR\x04ball\"\xea\x01\n" +
+	"\vCatchResult\x12\x18\n" +
+	"\apokemon\x18\x01 \x01(\tR\apokemon\x12\x12\n" +
+	"\x04ball\x18\x02 \x01(\tR\x04ball\x12\x16\n" +
+	"\x06chance\x18\x03 \x01(\x01R\x06chance\x12\x16\n" +
+	"\x06shakes\x18\x04 \x01(\x05R\x06shakes\x12\x1a\n" +
+	"\bcritical\x18\x05 \x01(\bR\bcritical\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x1d\n" +
+	"\n" +
+	"balls_left\x18\a \x01(\x05R\tballsLeft\x12(\n" +
+	"\x06caught\x18\b \x01(\v2\x10.pokedex.PokemonR\x06caught\"*\n" +
+	"\x0eInspectRequest\x12\x18\n" +
+	"\apokemon\x18\x01 \x01(\tR\apokemon\"\x13\n" +
+	"\x11ListCaughtRequest\"@\n" +
+	"\x12ListCaughtResponse\x12*\n" +
+	"\apokemon\x18\x01 \x03(\v2\x10.pokedex.PokemonR\apokemon\"\x9a\x02\n" +
+	"\aPokemon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x12\n" +
+	"\x04form\x18\x05 \x01(\tR\x04form\x12\x14\n" +
+	"\x05types\x18\x06 \x03(\tR\x05types\x12\x14\n" +
+	"\x05level\x18\a \x01(\x05R\x05level\x12\x14\n" +
+	"\x05shiny\x18\b \x01(\bR\x05shiny\x12\x1a\n" +
+	"\bfavorite\x18\t \x01(\bR\bfavorite\x12\x14\n" +
+	"\x05party\x18\n" +
+	" \x01(\bR\x05party\x12\x12\n" +
+	"\x04item\x18\v \x01(\tR\x04item\x12\x1b\n" +
+	"\tcaught_on\x18\f \x01(\tR\bcaughtOn\"\x8c\x04\n" +
+	"\x0ePokemonDetails\x12*\n" +
+	"\apokemon\x18\x01 \x01(\v2\x10.pokedex.PokemonR\apokemon\x12\x16\n" +
+	"\x06nature\x18\x02 \x01(\tR\x06nature\x12\x1e\n" +
+	"\n" +
+	"experience\x18\x03 \x01(\x05R\n" +
+	"experience\x12\x1c\n" +
+	"\thappiness\x18\x04 \x01(\x05R\thappiness\x128\n" +
+	"\x05stats\x18\x05 \x03(\v2\".pokedex.PokemonDetails.StatsEntryR\x05stats\x122\n" +
+	"\x03ivs\x18\x06 \x03(\v2 .pokedex.PokemonDetails.IvsEntryR\x03ivs\x122\n" +
+	"\x03evs\x18\a \x03(\v2 .pokedex.PokemonDetails.EvsEntryR\x03evs\x12\x14\n" +
+	"\x05moves\x18\b \x03(\tR\x05moves\x12\x16\n" +
+	"\x06sprite\x18\t \x01(\tR\x06sprite\x1a8\n" +
+	"\n" +
+	"StatsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a6\n" +
+	"\bIvsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a6\n" +
+	"\bEvsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\">\n" +
+	"\x0eExploreRequest\x12\x12\n" +
+	"\x04area\x18\x01 \x01(\tR\x04area\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"b\n" +
+	"\x04Area\x12\x12\n" +
+	"\x04area\x18\x01 \x01(\tR\x04area\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12,\n" +
+	"\apokemon\x18\x03 \x03(\v2\x12.pokedex.EncounterR\apokemon\"3\n" +
+	"\tEncounter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x05R\x04rate2\xf6\x01\n" +
+	"\aPokedex\x124\n" +
+	"\x05Catch\x12\x15.pokedex.CatchRequest\x1a\x14.pokedex.CatchResult\x12;\n" +
+	"\aInspect\x12\x17.pokedex.InspectRequest\x1a\x17.pokedex.PokemonDetails\x12E\n" +
+	"\n" +
+	"ListCaught\x12\x1a.pokedex.ListCaughtRequest\x1a\x1b.pokedex.ListCaughtResponse\x121\n" +
+	"\aExplore\x12\x17.pokedex.ExploreRequest\x1a\r.pokedex.AreaB0Z.github.com/Warren-Wang-OG/pokedexcli/pokedexpbb\x06proto3"
+
+var (
+	file_pokedex_proto_rawDescOnce sync.Once
+	file_pokedex_proto_rawDescData []byte
+)
+
+func file_pokedex_proto_rawDescGZIP() []byte {
+	file_pokedex_proto_rawDescOnce.Do(func() {
+		file_pokedex_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokedex_proto_rawDesc), len(file_pokedex_proto_rawDesc)))
+	})
+	return file_pokedex_proto_rawDescData
+}
+
+var file_pokedex_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pokedex_proto_goTypes = []any{
+	(*CatchRequest)(nil),       // 0: pokedex.CatchRequest
+	(*CatchResult)(nil),        // 1: pokedex.CatchResult
+	(*InspectRequest)(nil),     // 2: pokedex.InspectRequest
+	(*ListCaughtRequest)(nil),  // 3: pokedex.ListCaughtRequest
+	(*ListCaughtResponse)(nil), // 4: pokedex.ListCaughtResponse
+	(*Pokemon)(nil),            // 5: pokedex.Pokemon
+	(*PokemonDetails)(nil),     // 6: pokedex.PokemonDetails
+	(*ExploreRequest)(nil),     // 7: pokedex.ExploreRequest
+	(*Area)(nil),               // 8: pokedex.Area
+	(*Encounter)(nil),          // 9: pokedex.Encounter
+	nil,                        // 10: pokedex.PokemonDetails.StatsEntry
+	nil,                        // 11: pokedex.PokemonDetails.IvsEntry
+	nil,                        // 12: pokedex.PokemonDetails.EvsEntry
+}
+var file_pokedex_proto_depIdxs = []int32{
+	5,  // 0: pokedex.CatchResult.caught:type_name -> pokedex.Pokemon
+	5,  // 1: pokedex.ListCaughtResponse.pokemon:type_name -> pokedex.Pokemon
+	5,  // 2: pokedex.PokemonDetails.pokemon:type_name -> pokedex.Pokemon
+	10, // 3: pokedex.PokemonDetails.stats:type_name -> pokedex.PokemonDetails.StatsEntry
+	11, // 4: pokedex.PokemonDetails.ivs:type_name -> pokedex.PokemonDetails.IvsEntry
+	12, // 5: pokedex.PokemonDetails.evs:type_name -> pokedex.PokemonDetails.EvsEntry
+	9,  // 6: pokedex.Area.pokemon:type_name -> pokedex.Encounter
+	0,  // 7: pokedex.Pokedex.Catch:input_type -> pokedex.CatchRequest
+	2,  // 8: pokedex.Pokedex.Inspect:input_type -> pokedex.InspectRequest
+	3,  // 9: pokedex.Pokedex.ListCaught:input_type -> pokedex.ListCaughtRequest
+	7,  // 10: pokedex.Pokedex.Explore:input_type -> pokedex.ExploreRequest
+	1,  // 11: pokedex.Pokedex.Catch:output_type -> pokedex.CatchResult
+	6,  // 12: pokedex.Pokedex.Inspect:output_type -> pokedex.PokemonDetails
+	4,  // 13: pokedex.Pokedex.ListCaught:output_type -> pokedex.ListCaughtResponse
+	8,  // 14: pokedex.Pokedex.Explore:output_type -> pokedex.Area
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pokedex_proto_init() }
+func file_pokedex_proto_init() {
+	if File_pokedex_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokedex_proto_rawDesc), len(file_pokedex_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pokedex_proto_goTypes,
+		DependencyIndexes: file_pokedex_proto_depIdxs,
+		MessageInfos:      file_pokedex_proto_msgTypes,
+	}.Build()
+	File_pokedex_proto = out.File
+	file_pokedex_proto_goTypes = nil
+	file_pokedex_proto_depIdxs = nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// how much of a generation or region has been caught
+type Completion struct {
+	Name   string
+	Caught int
+	Total  int
+}
+
+func (completion Completion) Percent() float64 {
+	if completion.Total == 0 {
+		return 0
+	}
+	return 100 * float64(completion.Caught) / float64(completion.Total)
+}
+
+func (completion Completion) Complete() bool {
+	return completion.Total > 0 && completion.Caught == completion.Total
+}
+
+func (completion Completion) String() string {
+	return fmt.Sprintf("%s %d/%d, %.0f%%", completion.Name, completion.Caught, completion.Total, completion.Percent())
+}
+
+// the species with at least one caught pokemon, forms count towards their species
+func (pokedex *Pokedex) SpeciesCaught() map[string]bool {
+	caught := make(map[string]bool)
+	for _, c := range pokedex.Pokemon {
+		caught[c.SpeciesName()] = true
+	}
+	return caught
+}
+
+// every generation in order, the list and each generation come from the cache after the first time
+func (client *Client) GetGenerations() ([]Generation, error) {
+	generations := []Generation{}
+	err := client.StreamResults(client.ListURL("generation", 0, 100), func(resource NamedResource) error {
+		generation, err := client.GetGeneration(resource.Name)
+		if err != nil {
+			return err
+		}
+		generations = append(generations, generation)
+		return nil
+	})
+	sort.Slice(generations, func(i, j int) bool {
+		return generations[i].Id < generations[j].Id
+	})
+	return generations, err
+}
+
+func countCaught(species []NamedResource, caught map[string]bool) int {
+	count := 0
+	for _, s := range species {
+		if caught[s.Name] {
+			count++
+		}
+	}
+	return count
+}
+
+// completion of each generation, in generation order
+func generationCompletion(generations []Generation, caught map[string]bool) []Completion {
+	completions := []Completion{}
+	for _, generation := range generations {
+		completions = append(completions, Completion{
+			Name:   englishName(generation.Names, generation.Name),
+			Caught: countCaught(generation.Pokemon_species, caught),
+			Total:  len(generation.Pokemon_species),
+		})
+	}
+	return completions
+}
+
+// completion of each region, a region's pokemon are the ones its generations introduced
+func regionCompletion(generations []Generation, caught map[string]bool) []Completion {
+	completions := []Completion{}
+	index := make(map[string]int)
+	for _, generation := range generations {
+		region := generation.Main_region.Name
+		i, ok := index[region]
+		if !ok {
+			i = len(completions)
+			index[region] = i
+			completions = append(completions, Completion{Name: regionTitle(region)})
+		}
+		completions[i].Caught += countCaught(generation.Pokemon_species, caught)
+		completions[i].Total += len(generation.Pokemon_species)
+	}
+	return completions
+}
+
+// "kanto" -> "Kanto"
+func regionTitle(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// celebrate regions that are complete for the first time, returns whether there were any
+func (session *Session) celebrateCompleted(regions []Completion) bool {
+	celebrated := false
+	for _, region := range regions {
+		if !region.Complete() || session.profile.HasCompleted(region.Name) {
+			continue
+		}
+		session.profile.CompletedRegions = append(session.profile.CompletedRegions, region.Name)
+		fmt.Printf("🎉 You've caught every pokemon from %s! 🎉\n", region.Name)
+		celebrated = true
+	}
+	return celebrated
+}
+
+// whether the region was already celebrated
+func (profile *Profile) HasCompleted(region string) bool {
+	for _, name := range profile.CompletedRegions {
+		if name == region {
+			return true
+		}
+	}
+	return false
+}
+
+// celebrate as soon as a catch completes a region, the catch saves afterwards
+func (session *Session) checkCompletion(event GameEvent) {
+	if event.Kind != "catch" && event.Kind != "evolve" && event.Kind != "hatch" && event.Kind != "trade" {
+		return
+	}
+	generations, err := session.client.GetGenerations()
+	if err != nil {
+		return
+	}
+	session.celebrateCompleted(regionCompletion(generations, session.pokedex.SpeciesCaught()))
+}
+
+// show caught and total species for each generation and region
+func progressCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	generations, err := session.client.GetGenerations()
+	if err != nil {
+		return err
+	}
+	caught := session.pokedex.SpeciesCaught()
+
+	fmt.Println("By generation:")
+	for _, completion := range generationCompletion(generations, caught) {
+		fmt.Println("-", completion)
+	}
+	fmt.Println("By region:")
+	regions := regionCompletion(generations, caught)
+	for _, completion := range regions {
+		fmt.Println("-", completion)
+	}
+	if session.celebrateCompleted(regions) {
+		return session.Save()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestRegionCompletion(t *testing.T) {
+	species := func(names ...string) []NamedResource {
+		resources := []NamedResource{}
+		for _, name := range names {
+			resources = append(resources, NamedResource{Name: name})
+		}
+		return resources
+	}
+	generations := []Generation{
+		{Name: "generation-i", Main_region: NamedResource{Name: "kanto"}, Pokemon_species: species("bulbasaur", "pikachu")},
+		{Name: "generation-ii", Main_region: NamedResource{Name: "johto"}, Pokemon_species: species("chikorita", "togepi", "pichu")},
+	}
+
+	pokedex := NewPokedex()
+	for _, name := range []string{"bulbasaur", "pikachu", "raichu-alola"} {
+		species := name
+		if name == "raichu-alola" {
+			species = "raichu"
+		}
+		pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Name: name, Species: NamedResource{Name: species}}})
+	}
+	pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Name: "togepi", Species: NamedResource{Name: "togepi"}}})
+
+	regions := regionCompletion(generations, pokedex.SpeciesCaught())
+	if len(regions) != 2 || regions[0].String() != "Kanto 2/2, 100%" || regions[1].String() != "Johto 1/3, 33%" {
+		t.Errorf("unexpected completion: %v", regions)
+	}
+
+	session := &Session{profile: NewProfile()}
+	if !session.celebrateCompleted(regions) {
+		t.Errorf("expected kanto to be celebrated")
+	}
+	if session.celebrateCompleted(regions) {
+		t.Errorf("expected kanto to be celebrated only once")
+	}
+}
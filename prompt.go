@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ask the user something and read their answer from the same input the REPL reads commands from
+// returns false when input has ended
+func (session *Session) Prompt(question string) (string, bool) {
+	fmt.Print(question, " ")
+	if !session.input.Scan() {
+		fmt.Println()
+		return "", false
+	}
+	return strings.TrimSpace(session.input.Text()), true
+}
+
+// ask a yes/no question, anything but y or yes is a no
+func (session *Session) Confirm(question string) bool {
+	answer, ok := session.Prompt(question + " [y/n]")
+	if !ok {
+		return false
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	session := &Session{input: bufio.NewScanner(strings.NewReader("y\n no\nYES\n\n"))}
+	expected := []bool{true, false, true, false, false}
+	for i, want := range expected {
+		if got := session.Confirm("ok?"); got != want {
+			t.Errorf("answer %d: expected %v, got %v", i, want, got)
+		}
+	}
+}
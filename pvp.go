@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// both players have to speak the same version of the protocol
+const pvpVersion = 2
+
+// the port the host listens on without --port
+const defaultPvPPort = "7777"
+
+// how long to wait for someone to connect, and for the other player between messages
+const (
+	pvpConnectTimeout = 5 * time.Minute
+	pvpDialTimeout    = 10 * time.Second
+	pvpTurnTimeout    = 5 * time.Minute
+)
+
+// one line of the pvp protocol
+// the guest connects and sends "hello" with its team, the host answers "hello" and then runs the battle:
+// it sends every battle "event", a "choose" before each turn that the guest answers with an "action",
+// and "end" once the battle is over, the state from the host's side
+// a host that won't battle the guest's team answers the hello with "reject" instead
+type PvPMessage struct {
+	Kind    string `json:"kind"`
+	Version int    `json:"version,omitempty"`
+	// the player's name, team and elo rating, in hellos
+	Name   string       `json:"name,omitempty"`
+	Team   []PvPPokemon `json:"team,omitempty"`
+	Rating int          `json:"rating,omitempty"`
+	// why the guest's team was turned down, in rejects
+	Reason string `json:"reason,omitempty"`
+	// the guest's active pokemon, the one it's up against and the weather and terrain, in chooses
+	Battler  *Battler      `json:"battler,omitempty"`
+	Opponent *Battler      `json:"opponent,omitempty"`
+	Weather  string        `json:"weather,omitempty"`
+	Action   *BattleAction `json:"action,omitempty"`
+	Event    *BattleEvent  `json:"event,omitempty"`
+	State    string        `json:"state,omitempty"`
+}
+
+// a guest's pokemon as the host gets it, the host rebuilds it from the api so the guest can't send made up stats or hp
+type PvPPokemon struct {
+	Name     string         `json:"name"`
+	Nickname string         `json:"nickname,omitempty"`
+	Level    int            `json:"level"`
+	Nature   string         `json:"nature,omitempty"`
+	IVs      map[string]int `json:"ivs,omitempty"`
+	EVs      map[string]int `json:"evs,omitempty"`
+	Moves    []string       `json:"moves,omitempty"`
+	Item     string         `json:"item,omitempty"`
+}
+
+func newPvPPokemon(caught *CaughtPokemon) PvPPokemon {
+	return PvPPokemon{Name: caught.Name, Nickname: caught.Nickname, Level: caught.Level, Nature: caught.Nature,
+		IVs: caught.IVs, EVs: caught.EVs, Moves: caught.Moves, Item: caught.Item}
+}
+
+// an error unless the pokemon could be in someone's party, with a level, ivs, evs, moves and item the games allow
+func (pokemon PvPPokemon) Check() error {
+	if pokemon.Name == "" {
+		return fmt.Errorf("a pokemon without a name")
+	}
+	if pokemon.Level < 1 || pokemon.Level > maxLevel {
+		return fmt.Errorf("%s is level %d, levels go from 1 to %d", pokemon.Name, pokemon.Level, maxLevel)
+	}
+	for stat, iv := range pokemon.IVs {
+		if iv < 0 || iv > maxIV {
+			return fmt.Errorf("%s has %d %s ivs, ivs go from 0 to %d", pokemon.Name, iv, stat, maxIV)
+		}
+	}
+	total := 0
+	for stat, ev := range pokemon.EVs {
+		if ev < 0 || ev > maxStatEV {
+			return fmt.Errorf("%s has %d %s evs, evs go from 0 to %d", pokemon.Name, ev, stat, maxStatEV)
+		}
+		total += ev
+	}
+	if total > maxTotalEVs {
+		return fmt.Errorf("%s has %d evs, at most %d are allowed", pokemon.Name, total, maxTotalEVs)
+	}
+	if len(pokemon.Moves) > maxMoves {
+		return fmt.Errorf("%s knows %d moves, at most %d are allowed", pokemon.Name, len(pokemon.Moves), maxMoves)
+	}
+	if pokemon.Item != "" && !knowsItem(pokemon.Item) {
+		return fmt.Errorf("%s holds an unknown item %s", pokemon.Name, pokemon.Item)
+	}
+	return nil
+}
+
+// the guest's team as battlers built on the host like a party of caught pokemon, every pokemon has to pass Check
+// and only know moves its species can learn
+func (client *Client) pvpTeam(team []PvPPokemon) ([]*Battler, error) {
+	if len(team) == 0 || len(team) > maxPartySize {
+		return nil, fmt.Errorf("a team has 1 to %d pokemon, this one has %d", maxPartySize, len(team))
+	}
+	battlers := []*Battler{}
+	for _, pokemon := range team {
+		err := pokemon.Check()
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := client.ResolvePokemon(pokemon.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown pokemon %s: %w", pokemon.Name, err)
+		}
+		learnset, err := client.GetLearnset(resolved.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, move := range pokemon.Moves {
+			if !learnset.Has(move) {
+				return nil, fmt.Errorf("%s can't learn %s", pokemon.Name, move)
+			}
+		}
+		caught := &CaughtPokemon{Pokemon: resolved, Nickname: pokemon.Nickname, Level: pokemon.Level, Nature: pokemon.Nature,
+			IVs: pokemon.IVs, EVs: pokemon.EVs, Moves: pokemon.Moves, Item: pokemon.Item}
+		battler, err := client.CaughtBattler(caught)
+		if err != nil {
+			return nil, err
+		}
+		battlers = append(battlers, battler)
+	}
+	return battlers, nil
+}
+
+// a connection to the other player, one json message per line
+type pvpPeer struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	encoder *json.Encoder
+}
+
+func newPvPPeer(conn net.Conn) *pvpPeer {
+	return &pvpPeer{conn: conn, reader: bufio.NewReader(conn), encoder: json.NewEncoder(conn)}
+}
+
+func (peer *pvpPeer) send(message PvPMessage) error {
+	peer.conn.SetWriteDeadline(time.Now().Add(pvpTurnTimeout))
+	return peer.encoder.Encode(message)
+}
+
+// the next message, an error if the other player disconnects or takes too long
+func (peer *pvpPeer) receive() (PvPMessage, error) {
+	var message PvPMessage
+	peer.conn.SetReadDeadline(time.Now().Add(pvpTurnTimeout))
+	line, err := peer.reader.ReadBytes('\n')
+	if err != nil {
+		return message, err
+	}
+	err = json.Unmarshal(line, &message)
+	return message, err
+}
+
+// the next message, which has to be of a kind
+func (peer *pvpPeer) expect(kind string) (PvPMessage, error) {
+	message, err := peer.receive()
+	if err != nil {
+		return message, err
+	}
+	if message.Kind != kind {
+		return message, fmt.Errorf("expected %q from the other player, got %q", kind, message.Kind)
+	}
+	return message, nil
+}
+
+// swap hellos, checking the other player speaks the same protocol
+func (peer *pvpPeer) hello(name string, rating int, team []PvPPokemon, first bool) (PvPMessage, error) {
+	if first {
+		err := peer.send(PvPMessage{Kind: "hello", Version: pvpVersion, Name: name, Team: team, Rating: rating})
+		if err != nil {
+			return PvPMessage{}, err
+		}
+	}
+	message, err := peer.expect("hello")
+	if err != nil {
+		return message, err
+	}
+	if message.Version != pvpVersion {
+		return message, fmt.Errorf("the other player uses pvp version %d, this is version %d", message.Version, pvpVersion)
+	}
+	if !first {
+		err = peer.send(PvPMessage{Kind: "hello", Version: pvpVersion, Name: name, Team: team, Rating: rating})
+	}
+	return message, err
+}
+
+// the battle's state for the other side, who won from their point of view
+func otherSideState(state string) string {
+	switch state {
+	case battleWon:
+		return battleLost
+	case battleLost:
+		return battleWon
+	}
+	return state
+}
+
+// a battle event worded for the guest, the engine words "Go, X!" and the start of the battle for the host
+func guestMessage(event BattleEvent, host, guest string) string {
+	switch event.Kind {
+	case "start":
+		return fmt.Sprintf("%s wants to battle!", host)
+	case "switch":
+		if strings.HasPrefix(event.Message, guest+" sent out ") || strings.HasPrefix(event.Message, guest+" withdrew ") {
+			return fmt.Sprintf("Go, %s!", event.Actor)
+		}
+		if strings.HasPrefix(event.Message, "Go, ") || strings.HasPrefix(event.Message, "Come back, ") {
+			return fmt.Sprintf("%s sent out %s", host, event.Actor)
+		}
+	}
+	return event.Message
+}
+
+func pvpEndMessage(state string) string {
+	if state == battleWon {
+		return "You won the battle!"
+	}
+	return "You lost the battle..."
+}
+
+// wait for another player to connect and battle them, this side runs the battle
+func (session *Session) hostBattle(port string, explain bool, log io.Writer) error {
+	player, err := session.playerSide()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	if tcp, ok := listener.(*net.TCPListener); ok {
+		tcp.SetDeadline(time.Now().Add(pvpConnectTimeout))
+	}
+	fmt.Printf("Waiting for a challenger on port %s, they can join with battle --connect [your address]:%s\n", port, port)
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("nobody connected: %w", err)
+	}
+	defer conn.Close()
+	peer := newPvPPeer(conn)
+
+	hello, err := peer.hello(player.Name, session.profile.Rating, nil, false)
+	if err != nil {
+		return err
+	}
+	team, err := session.client.pvpTeam(hello.Team)
+	if err != nil {
+		peer.send(PvPMessage{Kind: "reject", Reason: err.Error()})
+		return fmt.Errorf("turned down %s's team: %w", hello.Name, err)
+	}
+	// a guest with the same name is told apart so the battle messages make sense, their rating goes under their own name
+	rival := hello.Name
+	if hello.Name == player.Name {
+		hello.Name += " (guest)"
+	}
+	opponent := &BattleSide{Name: hello.Name, Team: team}
+
+	battle := NewBattle(player, opponent, false, rand.New(rand.NewSource(time.Now().UnixNano())))
+	battle.Explain = explain
+	chart, err := session.client.GetTypeChart(battle.MoveTypes())
+	if err != nil {
+		return err
+	}
+	battle.Chart = chart
+	// a guest that stops listening is noticed when their action doesn't come
+	battle.OnEvent = func(event BattleEvent) {
+		printBattleEvent(event)
+		peer.send(PvPMessage{Kind: "event", Event: &event})
+	}
+	if log != nil {
+		logBattle(battle, log)
+	}
+	battle.Start()
+
+	for !battle.Over() {
+		err = peer.send(PvPMessage{Kind: "choose", Battler: opponent.Current(), Opponent: player.Current(), Weather: battle.FieldStatus()})
+		hostAction := session.chooseMove(player.Current(), opponent.Current(), battle.FieldStatus(), "forfeit", false)
+		var guest PvPMessage
+		if err == nil {
+			fmt.Println("Waiting for", opponent.Name+"...")
+			guest, err = peer.expect("action")
+		}
+		if err != nil || guest.Action == nil {
+			fmt.Println(opponent.Name, "disconnected")
+			battle.Forfeit(opponentSide)
+			break
+		}
+		switch {
+		case hostAction.Kind == "flee":
+			battle.Forfeit(playerSide)
+		case guest.Action.Kind == "flee":
+			battle.Forfeit(opponentSide)
+		default:
+			battle.Step([2]BattleAction{hostAction, *guest.Action})
+		}
+	}
+	peer.send(PvPMessage{Kind: "end", State: battle.State})
+	session.saveReplay(Replay{Player: player.Name, Opponent: opponent.Name, Outcome: battle.State, Events: battle.Events})
+	err = session.Record(HistoryEvent{Kind: "pvp", Pokemon: opponent.Name, Outcome: battle.State})
+	if err != nil {
+		return err
+	}
+	return session.ratePvP(rival, hello.Rating, battle.State)
+}
+
+// connect to a player hosting a battle and fight them, the host runs the battle
+func (session *Session) joinBattle(address string, log io.Writer) error {
+	player, err := session.playerSide()
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", address, pvpDialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	peer := newPvPPeer(conn)
+
+	// the host rebuilds the team from what each pokemon is, not from the battlers built here
+	team := []PvPPokemon{}
+	for _, battler := range player.Team {
+		team = append(team, newPvPPokemon(battler.Caught))
+	}
+	host, err := peer.hello(player.Name, session.profile.Rating, team, true)
+	if err != nil {
+		return err
+	}
+	guest := player.Name
+	if host.Name == guest {
+		guest += " (guest)"
+	}
+
+	// the guest keeps the events as it saw them for its replay and log
+	events := []BattleEvent{}
+	var encoder *json.Encoder
+	if log != nil {
+		encoder = json.NewEncoder(log)
+	}
+	started := time.Now().Format(time.RFC3339)
+	state := ""
+	for state == "" {
+		message, err := peer.receive()
+		if err != nil {
+			fmt.Println(host.Name, "disconnected")
+			state = battleWon
+			break
+		}
+		switch message.Kind {
+		case "event":
+			if message.Event != nil && message.Event.Kind != "end" {
+				event := *message.Event
+				event.Message = guestMessage(event, host.Name, guest)
+				fmt.Println(event.Message)
+				events = append(events, event)
+				if encoder != nil {
+					encoder.Encode(newBattleLogLine(started, event))
+				}
+			}
+		case "choose":
+			if message.Battler == nil || message.Opponent == nil {
+				return fmt.Errorf("the host sent a turn without pokemon")
+			}
+			action := session.chooseMove(message.Battler, message.Opponent, message.Weather, "forfeit", false)
+			err = peer.send(PvPMessage{Kind: "action", Action: &action})
+			if err != nil {
+				fmt.Println(host.Name, "disconnected")
+				state = battleWon
+			}
+		case "end":
+			state = otherSideState(message.State)
+		case "reject":
+			return fmt.Errorf("%s turned down your team: %s", host.Name, message.Reason)
+		}
+	}
+	fmt.Println(pvpEndMessage(state))
+	turn := 0
+	if len(events) > 0 {
+		turn = events[len(events)-1].Turn
+	}
+	events = append(events, BattleEvent{Turn: turn, Kind: "end", Message: pvpEndMessage(state)})
+	session.saveReplay(Replay{Player: player.Name, Opponent: host.Name, Outcome: state, Events: events})
+	err = session.Record(HistoryEvent{Kind: "pvp", Pokemon: host.Name, Outcome: state})
+	if err != nil {
+		return err
+	}
+	return session.ratePvP(host.Name, host.Rating, state)
+}
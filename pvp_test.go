@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvPHello(t *testing.T) {
+	hostConn, guestConn := net.Pipe()
+	defer hostConn.Close()
+	defer guestConn.Close()
+	host, guest := newPvPPeer(hostConn), newPvPPeer(guestConn)
+
+	team := []PvPPokemon{{Name: "pikachu", Level: 50, Moves: []string{"tackle"}}}
+	done := make(chan error)
+	go func() {
+		hello, err := host.hello("Red", 1200, nil, false)
+		if err == nil && (hello.Name != "Blue" || hello.Rating != 950 || len(hello.Team) != 1 || hello.Team[0].Name != "pikachu" || hello.Team[0].Level != 50) {
+			t.Errorf("expected Blue's pikachu, got %+v", hello)
+		}
+		done <- err
+	}()
+	hello, err := guest.hello("Blue", 950, team, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hello.Name != "Red" || hello.Rating != 1200 {
+		t.Errorf("expected the host to be Red rated 1200, got %q rated %d", hello.Name, hello.Rating)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPvPPokemonCheck(t *testing.T) {
+	cases := []struct {
+		pokemon PvPPokemon
+		valid   bool
+	}{
+		{PvPPokemon{Name: "pikachu", Level: 50, IVs: map[string]int{"speed": 31}, EVs: map[string]int{"speed": 252, "attack": 252}}, true},
+		{PvPPokemon{Level: 50}, false},
+		{PvPPokemon{Name: "pikachu", Level: 0}, false},
+		{PvPPokemon{Name: "pikachu", Level: 500}, false},
+		{PvPPokemon{Name: "pikachu", Level: 50, IVs: map[string]int{"hp": 99}}, false},
+		{PvPPokemon{Name: "pikachu", Level: 50, EVs: map[string]int{"hp": -1}}, false},
+		{PvPPokemon{Name: "pikachu", Level: 50, EVs: map[string]int{"hp": 252, "attack": 252, "speed": 252}}, false},
+		{PvPPokemon{Name: "pikachu", Level: 50, Moves: []string{"tackle", "growl", "thunder", "surf", "fly"}}, false},
+		{PvPPokemon{Name: "pikachu", Level: 50, Item: "master-sword"}, false},
+	}
+	for _, c := range cases {
+		if err := c.pokemon.Check(); (err == nil) != c.valid {
+			t.Errorf("%+v: expected valid %v, got %v", c.pokemon, c.valid, err)
+		}
+	}
+}
+
+func TestPvPTeamSize(t *testing.T) {
+	client := NewClient(NewCache(time.Minute))
+	for _, size := range []int{0, maxPartySize + 1} {
+		team := make([]PvPPokemon, size)
+		if _, err := client.pvpTeam(team); err == nil {
+			t.Errorf("expected a team of %d to be turned down", size)
+		}
+	}
+	// checked before anything is fetched
+	if _, err := client.pvpTeam([]PvPPokemon{{Name: "pikachu", Level: 1000}}); err == nil || !strings.Contains(err.Error(), "level 1000") {
+		t.Errorf("expected the level to be turned down, got %v", err)
+	}
+}
+
+func TestGuestMessage(t *testing.T) {
+	cases := []struct {
+		event    BattleEvent
+		expected string
+	}{
+		{BattleEvent{Kind: "start", Actor: "Blue", Message: "Blue wants to battle!"}, "Red wants to battle!"},
+		{BattleEvent{Kind: "switch", Actor: "eevee", Message: "Blue sent out eevee"}, "Go, eevee!"},
+		{BattleEvent{Kind: "switch", Actor: "pikachu", Message: "Go, pikachu!"}, "Red sent out pikachu"},
+		{BattleEvent{Kind: "move", Message: "pikachu used tackle!"}, "pikachu used tackle!"},
+	}
+	for _, c := range cases {
+		if message := guestMessage(c.event, "Red", "Blue"); message != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, message)
+		}
+	}
+	if otherSideState(battleWon) != battleLost || otherSideState(battleLost) != battleWon {
+		t.Errorf("expected won and lost to swap")
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// random [--type type] [--gen n] - show a random pokemon and offer to catch it
+func randomCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	_, flags := parseFlags(args[1].([]string))
+	client := session.client
+
+	// candidates are pokemon when filtering by type and species otherwise,
+	// the names only differ for species with several forms, like deoxys and deoxys-normal
+	var candidates []NamedResource
+	if types, ok := flags["type"]; ok {
+		var err error
+		candidates, err = client.SearchByType(types)
+		if err != nil {
+			return err
+		}
+	}
+	if gen, ok := flagValue(flags, "gen"); ok {
+		generation, err := client.GetGeneration(gen)
+		if err != nil {
+			return err
+		}
+		if candidates == nil {
+			candidates = generation.Pokemon_species
+		} else {
+			inGeneration := make(map[string]bool)
+			for _, species := range generation.Pokemon_species {
+				inGeneration[species.Name] = true
+			}
+			kept := []NamedResource{}
+			for _, candidate := range candidates {
+				if inGeneration[candidate.Name] {
+					kept = append(kept, candidate)
+				}
+			}
+			candidates = kept
+		}
+	}
+	if candidates == nil {
+		index, err := session.SpeciesIndex()
+		if err != nil {
+			return err
+		}
+		candidates = index.Species
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No pokemon match those filters")
+		return nil
+	}
+
+	pick := candidates[rand.Intn(len(candidates))]
+	name := pick.Name
+	species, err := client.GetSpecies(name)
+	if err == nil {
+		name = species.DefaultPokemon()
+	}
+	pokemon, err := client.GetPokemon(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("A wild %s appeared! (#%d)\n", pokemon.Name, pokemon.Id)
+	if genus := species.Genus(); genus != "" {
+		fmt.Println("Genus:", genus)
+	}
+	fmt.Println("Types:", pokemon.TypeNames())
+	total := 0
+	for _, stat := range statOrder {
+		total += pokemon.BaseStat(stat)
+	}
+	fmt.Println("Base stat total:", total)
+
+	if count := session.pokedex.Count(pokemon.Name); count > 0 {
+		fmt.Println("You already have", count, "of them")
+	}
+	if session.Confirm("Try to catch it?") {
+		return catchCommand(session, []string{pokemon.Name})
+	}
+	return nil
+}
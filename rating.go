@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// every profile starts at this elo rating, and a battle moves it by at most ratingK points
+const (
+	startingRating = 1000
+	ratingK        = 32
+)
+
+// someone the player has battled over the network, with their rating the last time
+type RatedOpponent struct {
+	Name   string `json:"name"`
+	Rating int    `json:"rating"`
+	// the player's record against them
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	LastPlayed string `json:"last_played"`
+}
+
+// the chance a player rated rating beats one rated opponent, from the elo formula
+func expectedScore(rating, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-rating)/400))
+}
+
+// the new rating after a battle against opponent
+func newRating(rating, opponent int, won bool) int {
+	score := 0.0
+	if won {
+		score = 1
+	}
+	return rating + int(math.Round(ratingK*(score-expectedScore(rating, opponent))))
+}
+
+// update the player's rating and the opponent's record after a pvp battle, the state from the player's side
+func (session *Session) ratePvP(opponent string, opponentRating int, state string) error {
+	profile := session.profile
+	if opponentRating == 0 {
+		opponentRating = startingRating
+	}
+	won := state == battleWon
+	before := profile.Rating
+	profile.Rating = newRating(profile.Rating, opponentRating, won)
+
+	if profile.Opponents == nil {
+		profile.Opponents = make(map[string]*RatedOpponent)
+	}
+	record, ok := profile.Opponents[opponent]
+	if !ok {
+		record = &RatedOpponent{Name: opponent}
+		profile.Opponents[opponent] = record
+	}
+	// their side of the same battle
+	record.Rating = newRating(opponentRating, before, !won)
+	record.LastPlayed = time.Now().Format(dateLayout)
+	if won {
+		record.Wins++
+	} else {
+		record.Losses++
+	}
+	fmt.Printf("Your rating went from %d to %d\n", before, profile.Rating)
+	return session.Save()
+}
+
+// the player with their overall record and everyone they've battled, highest rated first
+func (profile *Profile) Leaderboard() []RatedOpponent {
+	board := []RatedOpponent{{Name: profile.Name + " (you)", Rating: profile.Rating}}
+	for _, opponent := range profile.Opponents {
+		board = append(board, *opponent)
+		board[0].Wins += opponent.Wins
+		board[0].Losses += opponent.Losses
+	}
+	sort.SliceStable(board, func(i, j int) bool {
+		if board[i].Rating != board[j].Rating {
+			return board[i].Rating > board[j].Rating
+		}
+		return board[i].Name < board[j].Name
+	})
+	return board
+}
+
+// rating - show your pvp rating and a leaderboard of the players you've battled
+func ratingCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	profile := session.profile
+	fmt.Printf("Your rating: %d\n", profile.Rating)
+	if len(profile.Opponents) == 0 {
+		fmt.Println("Battle someone with battle --host or battle --connect to get on the leaderboard")
+		return nil
+	}
+	for i, entry := range profile.Leaderboard() {
+		if entry.LastPlayed == "" {
+			fmt.Printf("%d. %s %d, %d-%d overall\n", i+1, entry.Name, entry.Rating, entry.Wins, entry.Losses)
+		} else {
+			fmt.Printf("%d. %s %d, you're %d-%d against them, last battled %s\n", i+1, entry.Name, entry.Rating, entry.Wins, entry.Losses, entry.LastPlayed)
+		}
+	}
+	return nil
+}
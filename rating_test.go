@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestNewRating(t *testing.T) {
+	if got := newRating(1000, 1000, true); got != 1016 {
+		t.Errorf("expected an even win to be worth 16 points, got %d", got)
+	}
+	if got := newRating(1000, 1000, false); got != 984 {
+		t.Errorf("expected an even loss to cost 16 points, got %d", got)
+	}
+	// beating someone much stronger is worth more than beating someone much weaker
+	if upset, expected := newRating(1000, 1400, true), newRating(1400, 1000, true); upset-1000 <= expected-1400 {
+		t.Errorf("expected an upset to be worth more, got %d and %d", upset, expected)
+	}
+}
+
+func TestRatePvP(t *testing.T) {
+	session := &Session{profile: NewProfile()}
+	if err := session.ratePvP("Blue", 1100, battleWon); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.ratePvP("Green", 0, battleLost); err != nil {
+		t.Fatal(err)
+	}
+	profile := session.profile
+	blue := profile.Opponents["Blue"]
+	if blue.Wins != 1 || blue.Losses != 0 || blue.Rating >= 1100 || profile.Opponents["Green"].Rating <= startingRating {
+		t.Errorf("unexpected opponents %+v and %+v", blue, profile.Opponents["Green"])
+	}
+	board := profile.Leaderboard()
+	if len(board) != 3 || board[0].Name != "Blue" {
+		t.Errorf("expected Blue on top of three, got %+v", board)
+	}
+	for _, entry := range board {
+		if entry.Name == "Trainer (you)" && (entry.Wins != 1 || entry.Losses != 1 || entry.Rating != profile.Rating) {
+			t.Errorf("unexpected own entry %+v", entry)
+		}
+	}
+}
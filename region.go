@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Region struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	Names           []LocalizedName `json:"names"`
+	Locations       []NamedResource `json:"locations"`
+	Main_generation NamedResource   `json:"main_generation"`
+	Pokedexes       []NamedResource `json:"pokedexes"`
+	Version_groups  []NamedResource `json:"version_groups"`
+}
+
+type Generation struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	Names           []LocalizedName `json:"names"`
+	Main_region     NamedResource   `json:"main_region"`
+	Pokemon_species []NamedResource `json:"pokemon_species"`
+	Version_groups  []NamedResource `json:"version_groups"`
+	Types           []NamedResource `json:"types"`
+}
+
+// fetch a region by name
+func (client *Client) GetRegion(name string) (Region, error) {
+	var region Region
+	err := client.GetJSON(client.ResourceURL("region", name), &region)
+	return region, err
+}
+
+// fetch a generation by number ("1") or name ("generation-i")
+func (client *Client) GetGeneration(name string) (Generation, error) {
+	var generation Generation
+	err := client.GetJSON(client.ResourceURL("generation", name), &generation)
+	return generation, err
+}
+
+// list every region
+func regionsCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	client := session.client
+
+	fmt.Println("Regions:")
+	return client.StreamResults(client.ListURL("region", 0, 100), func(region NamedResource) error {
+		fmt.Println("-", region.Name)
+		return nil
+	})
+}
+
+// show a region's generation, games and locations
+func regionCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a region")
+		return nil
+	}
+
+	region, err := session.client.GetRegion(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Region:", englishName(region.Names, region.Name))
+	fmt.Println("Generation:", region.Main_generation.Name)
+	games := []string{}
+	for _, versionGroup := range region.Version_groups {
+		games = append(games, versionGroup.Name)
+	}
+	fmt.Println("Games:", strings.Join(games, ", "))
+	fmt.Printf("Locations (%d):\n", len(region.Locations))
+	for _, location := range region.Locations {
+		fmt.Println("-", location.Name)
+	}
+	return nil
+}
+
+// show a generation's region, games and the species it introduced
+func generationCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a generation, like 1 or generation-i")
+		return nil
+	}
+
+	generation, err := session.client.GetGeneration(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Generation:", englishName(generation.Names, generation.Name))
+	fmt.Println("Region:", generation.Main_region.Name)
+	games := []string{}
+	for _, versionGroup := range generation.Version_groups {
+		games = append(games, versionGroup.Name)
+	}
+	fmt.Println("Games:", strings.Join(games, ", "))
+
+	fmt.Printf("Pokemon introduced (%d):\n", len(generation.Pokemon_species))
+	printSpeciesList(generation.Pokemon_species)
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import "fmt"
+
+// release [pokemon] - let a caught pokemon go for good
+func releaseCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	name := params[0]
+
+	caught, err := session.pokedex.Find(name)
+	if err != nil {
+		return err
+	}
+	if caught.Daycare {
+		return fmt.Errorf("%s is at the daycare, collect it first", caught.DisplayName())
+	}
+	if !session.Confirm(fmt.Sprintf("Release %s (#%d, level %d)? You won't get it back", caught.DisplayName(), caught.InstanceID, caught.Level)) {
+		fmt.Println(caught.DisplayName(), "stays with you")
+		return nil
+	}
+
+	session.pokedex.Remove(caught)
+	session.leaveTeams(caught)
+	fmt.Println("You released", caught.DisplayName()+". Bye,", caught.DisplayName()+"!")
+	err = session.Record(HistoryEvent{Kind: "release", Pokemon: caught.Name})
+	if err != nil {
+		return err
+	}
+	return session.Save()
+}
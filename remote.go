@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Warren-Wang-OG/pokedexcli/pokedexpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// how long a call to a remote pokedex can take, a catch may have the server fetch from the api first
+const remoteTimeout = 30 * time.Second
+
+// a pokedex someone else serves with serve --grpc, played from a REPL of its own
+type RemotePokedex struct {
+	address string
+	conn    *grpc.ClientConn
+	client  pokedexpb.PokedexClient
+}
+
+// connect to the pokedex served at address, like localhost:50051
+func DialRemote(address string) (*RemotePokedex, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &RemotePokedex{address: address, conn: conn, client: pokedexpb.NewPokedexClient(conn)}, nil
+}
+
+func (remote *RemotePokedex) Close() error {
+	return remote.conn.Close()
+}
+
+// the commands a remote pokedex answers, everything else needs a pokedex of your own
+var remoteCommands = map[string]Command{
+	"catch":   {name: "catch", description: "throw a ball at a wild pokemon", callback: ParamFunc(remoteCatchCommand)},
+	"inspect": {name: "inspect", description: "inspect a caught pokemon", callback: ParamFunc(remoteInspectCommand)},
+	"pokedex": {name: "pokedex", description: "list the caught pokemon", callback: ParamFunc(remotePokedexCommand)},
+	"explore": {name: "explore", description: "show the pokemon in a location", callback: ParamFunc(remoteExploreCommand)},
+}
+
+// run commands against the remote pokedex until exit or the end of input
+func (remote *RemotePokedex) REPL(input *bufio.Scanner) {
+	fmt.Printf("Playing on the pokedex at %s, help lists the commands\n", remote.address)
+	for {
+		fmt.Print("pokedex@" + remote.address + " > ")
+		if !input.Scan() {
+			fmt.Println()
+			return
+		}
+		params := strings.Fields(input.Text())
+		if len(params) == 0 {
+			continue
+		}
+		switch params[0] {
+		case "exit":
+			return
+		case "help":
+			fmt.Println("catch [pokemon] [--ball name] - throw one ball at a wild pokemon")
+			fmt.Println("inspect [pokemon] - show a caught pokemon by id, nickname or name")
+			fmt.Println("pokedex - list the caught pokemon")
+			fmt.Println("explore [location] [--version v] - show the pokemon met in a location area")
+			fmt.Println("exit - stop playing")
+			continue
+		}
+		command, ok := remoteCommands[params[0]]
+		if !ok {
+			fmt.Println("Command not found, a remote pokedex only does catch, inspect, pokedex and explore")
+			continue
+		}
+		err := command.callback.Execute(remote, params[1:])
+		if err != nil {
+			// the server's own message, without the grpc code in front
+			fmt.Println(status.Convert(err).Message())
+		}
+	}
+}
+
+// play on the pokedex served at address instead of your own
+func runRemote(address string, input *bufio.Scanner) error {
+	remote, err := DialRemote(address)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+	remote.REPL(input)
+	return nil
+}
+
+// "#3 Sparky (pikachu) level 12 ✨"
+func remotePokemonLine(pokemon *pokedexpb.Pokemon) string {
+	line := fmt.Sprintf("#%d %s level %d", pokemon.Id, pokemon.Name, pokemon.Level)
+	if pokemon.Nickname != "" {
+		line = fmt.Sprintf("#%d %s (%s) level %d", pokemon.Id, pokemon.Nickname, pokemon.Name, pokemon.Level)
+	}
+	if pokemon.Shiny {
+		line += " " + shinyMarker
+	}
+	return line
+}
+
+// catch [pokemon] [--ball name] - one ball at a wild pokemon on the remote pokedex
+func remoteCatchCommand(args ...interface{}) error {
+	remote := args[0].(*RemotePokedex)
+	params, flags := parseFlags(args[1].([]string))
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	ball, _ := flagValue(flags, "ball")
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	result, err := remote.client.Catch(ctx, &pokedexpb.CatchRequest{Pokemon: params[0], Ball: ball})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Throwing a %s ball at %s, chance of success %.1f%%\n", result.Ball, result.Pokemon, 100*result.Chance)
+	if result.Critical {
+		fmt.Println("A critical capture!")
+	}
+	for i := int32(0); i < result.Shakes && i < 3; i++ {
+		fmt.Println("...the ball shakes")
+	}
+	if result.Caught != nil {
+		fmt.Println("Caught", remotePokemonLine(result.Caught))
+	} else {
+		fmt.Println(result.Pokemon, "broke free!")
+	}
+	fmt.Printf("%s balls left: %d\n", result.Ball, result.BallsLeft)
+	return nil
+}
+
+// inspect [pokemon] - a caught pokemon on the remote pokedex
+func remoteInspectCommand(args ...interface{}) error {
+	remote := args[0].(*RemotePokedex)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	details, err := remote.client.Inspect(ctx, &pokedexpb.InspectRequest{Pokemon: params[0]})
+	if err != nil {
+		return err
+	}
+	fmt.Println(remotePokemonLine(details.Pokemon))
+	fmt.Println("Types:", strings.Join(details.Pokemon.Types, ", "))
+	if details.Nature != "" {
+		fmt.Println("Nature:", details.Nature)
+	}
+	fmt.Printf("Experience: %d, happiness: %d\n", details.Experience, details.Happiness)
+	fmt.Println("Stats:")
+	for _, stat := range statOrder {
+		fmt.Printf("- %s: %d (iv %d, ev %d)\n", stat, details.Stats[stat], details.Ivs[stat], details.Evs[stat])
+	}
+	fmt.Println("Moves:", strings.Join(details.Moves, ", "))
+	return nil
+}
+
+// pokedex - every caught pokemon on the remote pokedex, by id
+func remotePokedexCommand(args ...interface{}) error {
+	remote := args[0].(*RemotePokedex)
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	list, err := remote.client.ListCaught(ctx, &pokedexpb.ListCaughtRequest{})
+	if err != nil {
+		return err
+	}
+	pokemon := list.Pokemon
+	sort.SliceStable(pokemon, func(i, j int) bool {
+		return pokemon[i].Id < pokemon[j].Id
+	})
+	for _, caught := range pokemon {
+		fmt.Println(remotePokemonLine(caught))
+	}
+	fmt.Printf("%d caught\n", len(pokemon))
+	return nil
+}
+
+// explore [location] [--version v] - the pokemon met in a location area, asked of the remote pokedex
+func remoteExploreCommand(args ...interface{}) error {
+	remote := args[0].(*RemotePokedex)
+	params, flags := parseFlags(args[1].([]string))
+	if len(params) == 0 {
+		fmt.Println("Please enter a location")
+		return nil
+	}
+	version, _ := flagValue(flags, "version")
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	area, err := remote.client.Explore(ctx, &pokedexpb.ExploreRequest{Area: params[0], Version: strings.ToLower(version)})
+	if err != nil {
+		return err
+	}
+	if area.Version != "" {
+		fmt.Println("Exploring", area.Area, "in", area.Version)
+	} else {
+		fmt.Println("Exploring", area.Area)
+	}
+	fmt.Println("Pokemon encounters:")
+	for _, encounter := range area.Pokemon {
+		fmt.Printf("- %s (%d%%)\n", encounter.Name, encounter.Rate)
+	}
+	return nil
+}
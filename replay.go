@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pauses between events when a replay plays at normal speed, a little longer when a new turn starts
+const (
+	replayEventDelay = 700 * time.Millisecond
+	replayTurnDelay  = 1500 * time.Millisecond
+)
+
+// a battle's events, saved so it can be watched again or shared
+type Replay struct {
+	Time     time.Time `json:"time"`
+	Player   string    `json:"player"`
+	Opponent string    `json:"opponent"`
+	// "won", "lost" or "fled" for the player
+	Outcome string        `json:"outcome"`
+	Events  []BattleEvent `json:"events"`
+}
+
+// where replays are saved
+func replayDir() string {
+	return filepath.Join(pokedexDir(), "replays")
+}
+
+// write a replay into dir, named after when it happened and who it was against, returns the file's path
+func writeReplay(dir string, replay Replay) (string, error) {
+	data, err := json.MarshalIndent(replay, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+	name := replay.Time.Format("2006-01-02-150405") + "-" + apiName(strings.Fields(replay.Opponent)) + ".json"
+	path := filepath.Join(dir, name)
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+func readReplay(path string) (Replay, error) {
+	var replay Replay
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return replay, err
+	}
+	err = json.Unmarshal(data, &replay)
+	if err != nil {
+		return replay, fmt.Errorf("%s isn't a replay: %w", path, err)
+	}
+	return replay, nil
+}
+
+// save a finished battle's replay, a replay that can't be written shouldn't spoil the battle
+func (session *Session) saveReplay(replay Replay) {
+	if session.replayDir == "" {
+		return
+	}
+	replay.Time = time.Now()
+	path, err := writeReplay(session.replayDir, replay)
+	if err != nil {
+		fmt.Println("Couldn't save the replay:", err)
+		return
+	}
+	fmt.Println("Replay saved, watch it again with replay", filepath.Base(path))
+}
+
+// play the events back, sleep waits between them and speed 2 plays twice as fast
+func playReplay(replay Replay, speed float64, sleep func(time.Duration)) {
+	fmt.Printf("%s vs %s, %s\n", replay.Player, replay.Opponent, replay.Time.Format("2006-01-02 15:04"))
+	turn := 0
+	for i, event := range replay.Events {
+		if i > 0 {
+			delay := replayEventDelay
+			if event.Turn != turn {
+				delay = replayTurnDelay
+			}
+			sleep(time.Duration(float64(delay) / speed))
+		}
+		if event.Turn != turn {
+			turn = event.Turn
+			fmt.Printf("\n-- turn %d --\n", turn)
+		}
+		fmt.Println(event.Message)
+	}
+}
+
+// replay [file] [--speed x] - list saved battles or watch one again
+func replayCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+
+	if len(params) == 0 {
+		entries, err := os.ReadDir(session.replayDir)
+		if errors.Is(err, os.ErrNotExist) {
+			entries, err = nil, nil
+		}
+		if err != nil {
+			return err
+		}
+		names := []string{}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".json") {
+				names = append(names, entry.Name())
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println("No replays yet, every battle saves one")
+			return nil
+		}
+		sort.Strings(names)
+		fmt.Println("Replays:")
+		for _, name := range names {
+			fmt.Println("-", name)
+		}
+		return nil
+	}
+
+	speed := 1.0
+	if value, ok := flagValue(flags, "speed"); ok {
+		var err error
+		speed, err = strconv.ParseFloat(value, 64)
+		if err != nil || speed <= 0 {
+			return fmt.Errorf("--speed takes a number above 0, like 2 for twice as fast")
+		}
+	}
+	// a file in the replays directory can be given by name
+	path := params[0]
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) && session.replayDir != "" {
+		path = filepath.Join(session.replayDir, params[0])
+	}
+	replay, err := readReplay(path)
+	if err != nil {
+		return err
+	}
+	playReplay(replay, speed, time.Sleep)
+	return nil
+}
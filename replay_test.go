@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	replay := Replay{
+		Time:     time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC),
+		Player:   "Red",
+		Opponent: "Brock",
+		Outcome:  battleWon,
+		Events: []BattleEvent{
+			{Turn: 0, Kind: "start", Actor: "Brock", Message: "Brock wants to battle!"},
+			{Turn: 1, Kind: "move", Actor: "pikachu", Target: "onix", Move: "tackle", Message: "pikachu used tackle!"},
+			{Turn: 1, Kind: "damage", Target: "onix", Damage: 5, HP: 30, Message: "onix took 5 damage (30/35 HP left)"},
+			{Turn: 2, Kind: "end", Message: "You won the battle!"},
+		},
+	}
+	path, err := writeReplay(dir, replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "2024-03-01-150405-brock.json" {
+		t.Errorf("unexpected replay name %s", filepath.Base(path))
+	}
+	read, err := readReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read.Events, replay.Events) || read.Outcome != replay.Outcome {
+		t.Errorf("expected the replay back, got %+v", read)
+	}
+
+	delays := []time.Duration{}
+	playReplay(read, 2, func(d time.Duration) { delays = append(delays, d) })
+	expected := []time.Duration{replayTurnDelay / 2, replayEventDelay / 2, replayTurnDelay / 2}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Errorf("expected delays %v, got %v", expected, delays)
+	}
+}
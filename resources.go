@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// effect text of a move, ability or item in one language
+type EffectEntry struct {
+	Effect       string        `json:"effect"`
+	Short_effect string        `json:"short_effect"`
+	Language     NamedResource `json:"language"`
+}
+
+// a pokedex or item description as shown in one game version
+type FlavorTextEntry struct {
+	Flavor_text   string        `json:"flavor_text"`
+	Language      NamedResource `json:"language"`
+	Version       NamedResource `json:"version"`
+	Version_group NamedResource `json:"version_group"`
+}
+
+// a name in one language
+type LocalizedName struct {
+	Name     string        `json:"name"`
+	Language NamedResource `json:"language"`
+}
+
+// the english effect and short effect, empty if there is no english entry
+func englishEffect(entries []EffectEntry) (string, string) {
+	for _, entry := range entries {
+		if entry.Language.Name == "en" {
+			return cleanFlavorText(entry.Effect), cleanFlavorText(entry.Short_effect)
+		}
+	}
+	return "", ""
+}
+
+// the english name from a list of localized names, or fallback if there is none
+func englishName(names []LocalizedName, fallback string) string {
+	for _, name := range names {
+		if name.Language.Name == "en" {
+			return name.Name
+		}
+	}
+	return fallback
+}
+
+// the api's text comes straight from the games, with form feeds, hard line breaks and soft hyphens in it
+func cleanFlavorText(text string) string {
+	replacer := strings.NewReplacer(
+		// a soft hyphen at a line break joins the two halves of a word
+		"\u00ad\n", "",
+		"\u00ad", "",
+		"-\f", "-",
+		"\f", " ",
+		"\r", " ",
+		"\n", " ",
+	)
+	return strings.Join(strings.Fields(replacer.Replace(text)), " ")
+}
+
+// turn what a user typed into an api name: "Thunder Punch" -> "thunder-punch"
+func apiName(words []string) string {
+	return strings.ToLower(strings.Join(words, "-"))
+}
+
+// the id at the end of a resource url, like 25 for .../pokemon-species/25/, or 0 if there is none
+func resourceID(url string) int {
+	trimmed := strings.TrimSuffix(url, "/")
+	id, err := strconv.Atoi(trimmed[strings.LastIndex(trimmed, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// print species or pokemon links in pokedex order, the api lists them in no particular order
+func printSpeciesList(species []NamedResource) {
+	sorted := append([]NamedResource{}, species...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return resourceID(sorted[i].Url) < resourceID(sorted[j].Url)
+	})
+	for _, s := range sorted {
+		fmt.Printf("- #%d %s\n", resourceID(s.Url), s.Name)
+	}
+}
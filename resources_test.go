@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestCleanFlavorText(t *testing.T) {
+	cases := map[string]string{
+		"When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.": "When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+		"It is a super\u00ad\nsonic move.": "It is a supersonic move.",
+	}
+	for raw, expected := range cases {
+		if cleaned := cleanFlavorText(raw); cleaned != expected {
+			t.Errorf("cleanFlavorText(%q) = %q, expected %q", raw, cleaned, expected)
+		}
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	if id := resourceID("https://pokeapi.co/api/v2/pokemon-species/25/"); id != 25 {
+		t.Errorf("expected 25, got %v", id)
+	}
+	if id := resourceID("https://pokeapi.co/api/v2/pokemon-species/pikachu/"); id != 0 {
+		t.Errorf("expected 0, got %v", id)
+	}
+}
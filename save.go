@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// the save format's version, a save from an older one goes through the migrations after it up to this one
+var saveVersion = len(saveMigrations)
+
+// the steps that bring a save up a version, the one at i takes a version i save to version i+1
+// a format change that old saves need fixing for adds a step here
+var saveMigrations = []func(save *SaveData){
+	migrateUnversioned,
+}
+
+// everything about the player that outlives a session
+type SaveData struct {
+	// the format the save was written in, 0 for saves from before versions
+	Version   int        `json:"version"`
+	Pokedex   *Pokedex   `json:"pokedex"`
+	Inventory *Inventory `json:"inventory"`
+	Profile   *Profile   `json:"profile"`
+	// teams by lowercased name
+	Teams map[string]*Team `json:"teams"`
+}
+
+// path of the save file
+func savePath() string {
+	return filepath.Join(pokedexDir(), "save.json")
+}
+
+// a save for someone who has never played
+func NewSaveData() *SaveData {
+	return &SaveData{
+		Version:   saveVersion,
+		Pokedex:   NewPokedex(),
+		Inventory: NewInventory(),
+		Profile:   NewProfile(),
+		Teams:     make(map[string]*Team),
+	}
+}
+
+// read the save at path, a missing file is a new game
+func LoadSave(path string) (*SaveData, error) {
+	save := NewSaveData()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return save, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	save, err = decodeSave(data)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return save, nil
+}
+
+// a save from its json, migrated up to the current version
+func decodeSave(data []byte) (*SaveData, error) {
+	save := NewSaveData()
+	// a save without a version is from before them
+	save.Version = 0
+	err := json.Unmarshal(data, save)
+	if err != nil {
+		return nil, err
+	}
+	if save.Version > saveVersion {
+		return nil, fmt.Errorf("the save is version %d but this pokedex only reads up to version %d, update it first", save.Version, saveVersion)
+	}
+	// saves from before a field existed leave it nil
+	if save.Pokedex == nil {
+		save.Pokedex = NewPokedex()
+	}
+	if save.Inventory == nil {
+		save.Inventory = NewInventory()
+	}
+	if save.Profile == nil {
+		save.Profile = NewProfile()
+	}
+	if save.Teams == nil {
+		save.Teams = make(map[string]*Team)
+	}
+	if save.Inventory.Berries == nil {
+		save.Inventory.Berries = make(map[string]int)
+	}
+	for ; save.Version < saveVersion; save.Version++ {
+		saveMigrations[save.Version](save)
+	}
+	return save, nil
+}
+
+// pokemon caught before levels, ivs and happiness existed, and profiles from before ratings
+func migrateUnversioned(save *SaveData) {
+	if save.Profile.Rating == 0 {
+		save.Profile.Rating = startingRating
+	}
+	for _, caught := range save.Pokedex.Pokemon {
+		if caught.Level == 0 {
+			caught.Level = defaultLevelRange.Min
+		}
+		if caught.IVs == nil {
+			caught.IVs = rollIVs()
+		}
+		if caught.Happiness == 0 {
+			caught.Happiness = defaultHappiness
+		}
+	}
+}
+
+// write the save to path, through a temp file so a crash never leaves half a save behind
+func (save *SaveData) Write(path string) error {
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "save-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// write the session's pokedex, inventory, profile and teams to the save file
+// commands that change any of them call this, so quitting any way keeps progress
+func (session *Session) Save() error {
+	if session.savePath == "" {
+		return nil
+	}
+	return session.saveData().Write(session.savePath)
+}
+
+// the session as a save in the current format
+func (session *Session) saveData() *SaveData {
+	return &SaveData{
+		Version:   saveVersion,
+		Pokedex:   session.pokedex,
+		Inventory: session.inventory,
+		Profile:   session.profile,
+		Teams:     session.teams,
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	save, err := LoadSave(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if save.Pokedex.Len() != 0 || save.Inventory.Balls["poke"] != 20 {
+		t.Errorf("expected a new game for a missing save, got %+v", save)
+	}
+
+	save.Pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 25, Name: "pikachu"}, Nature: "timid"})
+	save.Inventory.Balls["poke"]--
+	err = save.Write(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSave(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caught, err := loaded.Pokedex.Find("pikachu"); err != nil || caught.Id != 25 || caught.Nature != "timid" || caught.InstanceID != 1 {
+		t.Errorf("expected pikachu to be saved, got %+v", loaded.Pokedex)
+	}
+	if loaded.Inventory.Balls["poke"] != 19 {
+		t.Errorf("expected 19 poke balls, got %v", loaded.Inventory.Balls["poke"])
+	}
+
+	err = os.WriteFile(path, []byte("{not json"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSave(path); err == nil {
+		t.Errorf("expected an error for a corrupt save")
+	}
+}
+
+func TestLoadSaveFromNameKeyedPokedex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	err := os.WriteFile(path, []byte(`{"pokedex": {
+		"pikachu": {"id": 25, "name": "pikachu", "level": 12},
+		"eevee": {"id": 133, "name": "eevee", "shiny": true}
+	}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	save, err := LoadSave(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if save.Pokedex.Len() != 2 {
+		t.Fatalf("expected 2 pokemon, got %d", save.Pokedex.Len())
+	}
+	// numbered in name order
+	eevee, pikachu := save.Pokedex.Pokemon[0], save.Pokedex.Pokemon[1]
+	if eevee.Name != "eevee" || eevee.InstanceID != 1 || !eevee.Shiny {
+		t.Errorf("unexpected eevee %+v", eevee)
+	}
+	if pikachu.Name != "pikachu" || pikachu.InstanceID != 2 || pikachu.Level != 12 {
+		t.Errorf("unexpected pikachu %+v", pikachu)
+	}
+	if save.Pokedex.NextID != 3 {
+		t.Errorf("expected the next id to be 3, got %d", save.Pokedex.NextID)
+	}
+}
+
+func TestSaveVersions(t *testing.T) {
+	// a save from before versions gets the unversioned migration
+	save, err := decodeSave([]byte(`{"pokedex": {"pokemon": [{"id": 25, "name": "pikachu", "instance_id": 1}]}, "profile": {"name": "Ash"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caught := save.Pokedex.Pokemon[0]
+	if save.Version != saveVersion || save.Profile.Rating != startingRating || caught.Level != defaultLevelRange.Min || caught.IVs == nil {
+		t.Errorf("expected the save to be migrated, got version %d, rating %d and %+v", save.Version, save.Profile.Rating, caught)
+	}
+
+	// a current save is taken as it is
+	save, err = decodeSave([]byte(fmt.Sprintf(`{"version": %d, "profile": {"name": "Ash", "rating": 0}}`, saveVersion)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if save.Profile.Rating != 0 || save.Inventory == nil || save.Teams == nil {
+		t.Errorf("expected no migration for a current save, got %+v", save.Profile)
+	}
+
+	_, err = decodeSave([]byte(fmt.Sprintf(`{"version": %d}`, saveVersion+1)))
+	if err == nil {
+		t.Errorf("expected an error for a save from a newer version")
+	}
+}
+
+func TestExportImportJSON(t *testing.T) {
+	session := &Session{pokedex: NewPokedex(), inventory: NewInventory(), profile: NewProfile(), teams: map[string]*Team{}}
+	session.profile.Name = "Ash"
+	session.pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 25, Name: "pikachu"}, Level: 30, Shiny: true})
+	session.teams["sparks"] = &Team{Name: "Sparks", Members: []int{1}}
+	session.inventory.Money = 1234
+	var out bytes.Buffer
+	err := session.exportJSON(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), fmt.Sprintf(`"version": %d`, saveVersion)) {
+		t.Errorf("expected the export to have a version, got %s", out.String())
+	}
+	path := filepath.Join(t.TempDir(), "export.json")
+	err = os.WriteFile(path, out.Bytes(), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := &Session{pokedex: NewPokedex(), inventory: NewInventory(), profile: NewProfile(), teams: map[string]*Team{},
+		input: bufio.NewScanner(strings.NewReader("n\ny\n"))}
+	err = importCommand(other, []string{path})
+	if err != nil || other.pokedex.Len() != 0 {
+		t.Fatalf("expected declining to keep the save, got %v and %d pokemon", err, other.pokedex.Len())
+	}
+	err = importCommand(other, []string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	caught, err := other.pokedex.Find("pikachu")
+	if err != nil || caught.Level != 30 || !caught.Shiny || other.inventory.Money != 1234 || other.profile.Name != "Ash" || other.teams["sparks"] == nil {
+		t.Errorf("expected the save to be imported, got %+v and %+v", other.pokedex, other.profile)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import "sort"
+
+// switching out goes before any move, even the highest priority ones
+const switchPriority = 7
+
+// one side's action for the turn with what the scheduler orders it by
+type TurnEntry struct {
+	Side   int
+	Action BattleAction
+	// the pokemon acting and the move it uses, nil for switches
+	Battler *Battler
+	Move    *BattleMove
+	// switchPriority for switches, the move's priority for moves
+	Priority int
+	Speed    int
+}
+
+// the order a turn's actions happen in: by priority bracket, then the faster pokemon first,
+// with pokemon tied on both going in a random order
+func scheduleTurn(entries []TurnEntry, shuffle func(n int, swap func(i, j int))) []TurnEntry {
+	ordered := append([]TurnEntry{}, entries...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].Speed > ordered[j].Speed
+	})
+	for start := 0; start < len(ordered); {
+		end := start + 1
+		for end < len(ordered) && ordered[end].Priority == ordered[start].Priority && ordered[end].Speed == ordered[start].Speed {
+			end++
+		}
+		if end-start > 1 {
+			tied := ordered[start:end]
+			shuffle(len(tied), func(i, j int) { tied[i], tied[j] = tied[j], tied[i] })
+		}
+		start = end
+	}
+	return ordered
+}
+
+// what each side does this turn, switches that can't happen become moves
+func (battle *Battle) turnEntries(actions [2]BattleAction) []TurnEntry {
+	entries := []TurnEntry{}
+	for side, action := range actions {
+		// a ball thrown or a failed try to run already took the side's action
+		if action.Kind == "catch" || (action.Kind == "flee" && battle.Wild) {
+			continue
+		}
+		battler := battle.Sides[side].Current()
+		entry := TurnEntry{Side: side, Action: action, Speed: battler.Speed()}
+		if action.Kind == "switch" && battle.canSwitch(side, action.Switch) {
+			entry.Priority = switchPriority
+		} else {
+			entry.Action = BattleAction{Kind: "move", Move: action.Move}
+			entry.Battler = battler
+			entry.Move = battler.moveFor(entry.Action)
+			entry.Priority = entry.Move.Priority
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
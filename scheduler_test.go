@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func sides(entries []TurnEntry) []int {
+	order := []int{}
+	for _, entry := range entries {
+		order = append(order, entry.Side)
+	}
+	return order
+}
+
+func TestScheduleTurn(t *testing.T) {
+	noShuffle := func(n int, swap func(i, j int)) {}
+	tests := []struct {
+		name    string
+		entries []TurnEntry
+		first   int
+	}{
+		{"faster first", []TurnEntry{{Side: 0, Speed: 50}, {Side: 1, Speed: 90}}, 1},
+		{"priority beats speed", []TurnEntry{{Side: 0, Speed: 50, Priority: 1}, {Side: 1, Speed: 90}}, 0},
+		{"negative priority goes last", []TurnEntry{{Side: 0, Speed: 90, Priority: -6}, {Side: 1, Speed: 10}}, 1},
+		{"switches beat any move", []TurnEntry{{Side: 0, Speed: 90, Priority: 5}, {Side: 1, Speed: 10, Priority: switchPriority}}, 1},
+	}
+	for _, test := range tests {
+		if order := sides(scheduleTurn(test.entries, noShuffle)); order[0] != test.first {
+			t.Errorf("%s: expected side %d first, got %v", test.name, test.first, order)
+		}
+	}
+
+	// ties go either way depending on the random source
+	tied := []TurnEntry{{Side: 0, Speed: 70}, {Side: 1, Speed: 70}}
+	firsts := map[int]bool{}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		firsts[scheduleTurn(tied, random.Shuffle)[0].Side] = true
+	}
+	if !firsts[0] || !firsts[1] {
+		t.Errorf("expected speed ties to be random, got %v", firsts)
+	}
+	if tied[0].Side != 0 {
+		t.Errorf("expected the entries not to be reordered in place")
+	}
+}
+
+func TestTurnEntries(t *testing.T) {
+	quickAttack := &BattleMove{Name: "quick-attack", Type: "normal", Class: "physical", Power: 40, Accuracy: 100, Priority: 1, PP: 30, MaxPP: 30}
+	slow := testBattler("rattata", 10, 20, quickAttack)
+	fast := testBattler("jolteon", 10, 130, tackle())
+	fainted := testBattler("pidgey", 10, 50, tackle())
+	fainted.HP = 0
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{slow, fainted}}, &BattleSide{Name: "Gary", Team: []*Battler{fast}}, false, rand.New(rand.NewSource(1)))
+
+	entries := battle.turnEntries([2]BattleAction{{Kind: "switch", Switch: 1}, {Kind: "move"}})
+	if entries[0].Action.Kind != "move" || entries[0].Move != quickAttack || entries[0].Priority != 1 {
+		t.Errorf("expected a switch to a fainted pokemon to become a move, got %+v", entries[0])
+	}
+	if entries[1].Speed != 130 || entries[1].Priority != 0 {
+		t.Errorf("unexpected entry %+v", entries[1])
+	}
+
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if battle.Events[0].Actor != "rattata" {
+		t.Errorf("expected quick attack to go before the faster jolteon, got %+v", battle.Events[0])
+	}
+}
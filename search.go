@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// every species name and url, saved to disk the first time search needs it
+type SpeciesIndex struct {
+	Species []NamedResource `json:"species"`
+}
+
+// path of the saved species index
+func speciesIndexPath() string {
+	return filepath.Join(pokedexDir(), "species-index.json")
+}
+
+// read the species index from path, downloading and saving it if it isn't there yet
+func loadSpeciesIndex(client *Client, path string) (*SpeciesIndex, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		index := &SpeciesIndex{}
+		err = json.Unmarshal(data, index)
+		if err == nil && len(index.Species) > 0 {
+			return index, nil
+		}
+		// a broken index is downloaded again
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	fmt.Println("Downloading the species index, this only happens once...")
+	index := &SpeciesIndex{}
+	err = client.StreamResults(client.ListURL("pokemon-species", 0, 100000), func(species NamedResource) error {
+		index.Species = append(index.Species, species)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+	return index, os.WriteFile(path, data, 0o644)
+}
+
+// the species index, loaded the first time a command needs it
+func (session *Session) SpeciesIndex() (*SpeciesIndex, error) {
+	if session.speciesIndex == nil {
+		index, err := loadSpeciesIndex(session.client, speciesIndexPath())
+		if err != nil {
+			return nil, err
+		}
+		session.speciesIndex = index
+	}
+	return session.speciesIndex, nil
+}
+
+// species whose name contains query, in pokedex order
+func (index *SpeciesIndex) Search(query string) []NamedResource {
+	query = strings.ToLower(query)
+	matches := []NamedResource{}
+	for _, species := range index.Species {
+		if strings.Contains(species.Name, query) {
+			matches = append(matches, species)
+		}
+	}
+	return matches
+}
+
+// pokemon that have every one of the types, in the order the first type lists them
+// type responses go through the client cache, so repeated searches don't download them again
+func (client *Client) SearchByType(types []string) ([]NamedResource, error) {
+	var matches []NamedResource
+	for i, typeName := range types {
+		typeInfo, err := client.GetType(strings.ToLower(typeName))
+		if err != nil {
+			return nil, err
+		}
+		members := make(map[string]bool)
+		for _, pokemon := range typeInfo.Pokemon {
+			members[pokemon.Pokemon.Name] = true
+			if i == 0 {
+				matches = append(matches, pokemon.Pokemon)
+			}
+		}
+		kept := []NamedResource{}
+		for _, pokemon := range matches {
+			if members[pokemon.Name] {
+				kept = append(kept, pokemon)
+			}
+		}
+		matches = kept
+	}
+	return matches, nil
+}
+
+// comparisons a --stat filter can use, longest first so ">=" isn't read as ">"
+var statOps = []string{">=", "<=", ">", "<", "="}
+
+// parse a filter like "speed>=120"
+func parseStatFilter(text string) (StatFilter, error) {
+	i := strings.IndexAny(text, "<>=")
+	if i > 0 {
+		for _, op := range statOps {
+			if !strings.HasPrefix(text[i:], op) {
+				continue
+			}
+			value, err := strconv.Atoi(text[i+len(op):])
+			if err != nil {
+				return StatFilter{}, fmt.Errorf("%q: %v is not a number", text, text[i+len(op):])
+			}
+			stat := strings.ToLower(text[:i])
+			known := false
+			for _, name := range statOrder {
+				known = known || name == stat
+			}
+			if !known {
+				return StatFilter{}, fmt.Errorf("%q: %s is not a stat, use one of %s", text, stat, strings.Join(statOrder, ", "))
+			}
+			return StatFilter{Stat: stat, Op: op, Value: value}, nil
+		}
+	}
+	return StatFilter{}, fmt.Errorf("%q: expected a stat, a comparison and a number, like speed>=120", text)
+}
+
+// whether a base stat passes the filter
+func (filter StatFilter) Matches(value int) bool {
+	switch filter.Op {
+	case ">=":
+		return value >= filter.Value
+	case "<=":
+		return value <= filter.Value
+	case ">":
+		return value > filter.Value
+	case "<":
+		return value < filter.Value
+	}
+	return value == filter.Value
+}
+
+// find pokemon matching a query with stat filters
+// the graphql endpoint answers in one request, without it every candidate is fetched over rest:
+// the pokemon of the --type, or without one every species in the local index, each fetched once and then cached
+func searchByStats(session *Session, query PokemonQuery) ([]PokemonSummary, error) {
+	client := session.client
+	if session.config.GraphQL.Enabled {
+		return client.SearchPokemonGraphQL(session.config.GraphQL.URL, query)
+	}
+
+	names := []string{}
+	if len(query.Types) > 0 {
+		candidates, err := client.SearchByType(query.Types)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if strings.Contains(candidate.Name, query.NameContains) {
+				names = append(names, candidate.Name)
+			}
+		}
+	} else {
+		index, err := session.SpeciesIndex()
+		if err != nil {
+			return nil, err
+		}
+		for _, species := range index.Search(query.NameContains) {
+			// a species shares its id with its default pokemon, whose name can differ, like deoxys and deoxys-normal
+			if id := resourceID(species.Url); id > 0 {
+				names = append(names, strconv.Itoa(id))
+			}
+		}
+		if len(names) > 100 {
+			fmt.Printf("Checking the stats of %d pokemon, this is slow the first time...\n", len(names))
+		}
+	}
+
+	pokemonList, errs := client.GetPokemonBatch(names, detailWorkers)
+	results := []PokemonSummary{}
+	for i, pokemon := range pokemonList {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		matches := true
+		for _, filter := range query.Stats {
+			if !filter.Matches(pokemon.BaseStat(filter.Stat)) {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		summary := PokemonSummary{Id: pokemon.Id, Name: pokemon.Name, Stats: make(map[string]int)}
+		for _, pokemonType := range pokemon.Types {
+			summary.Types = append(summary.Types, pokemonType.Type.Name)
+		}
+		for _, stat := range pokemon.Stats {
+			summary.Stats[stat.Stat.Name] = stat.Base_stat
+		}
+		results = append(results, summary)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Id < results[j].Id
+	})
+	return results, nil
+}
+
+// search [name] [--type type]... [--stat filter]... - find pokemon by part of their name, their types and their stats
+func searchCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	types := flags["type"]
+	if len(params) == 0 && len(types) == 0 && len(flags["stat"]) == 0 {
+		fmt.Println("Please enter part of a pokemon's name, a --type or a --stat")
+		return nil
+	}
+
+	if len(flags["stat"]) > 0 {
+		query := PokemonQuery{Types: types, NameContains: apiName(params)}
+		for _, text := range flags["stat"] {
+			filter, err := parseStatFilter(text)
+			if err != nil {
+				return err
+			}
+			query.Stats = append(query.Stats, filter)
+		}
+		results, err := searchByStats(session, query)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("No pokemon found")
+			return nil
+		}
+		fmt.Printf("Found %d pokemon:\n", len(results))
+		for _, result := range results {
+			stats := []string{}
+			for _, filter := range query.Stats {
+				stats = append(stats, fmt.Sprintf("%s %d", filter.Stat, result.Stats[filter.Stat]))
+			}
+			fmt.Printf("- #%d %s (%s)\n", result.Id, result.Name, strings.Join(stats, ", "))
+		}
+		return nil
+	}
+
+	var matches []NamedResource
+	if len(types) > 0 {
+		var err error
+		matches, err = session.client.SearchByType(types)
+		if err != nil {
+			return err
+		}
+		if len(params) > 0 {
+			query := apiName(params)
+			kept := []NamedResource{}
+			for _, pokemon := range matches {
+				if strings.Contains(pokemon.Name, query) {
+					kept = append(kept, pokemon)
+				}
+			}
+			matches = kept
+		}
+	} else {
+		index, err := session.SpeciesIndex()
+		if err != nil {
+			return err
+		}
+		matches = index.Search(apiName(params))
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No pokemon found")
+		return nil
+	}
+	fmt.Printf("Found %d pokemon:\n", len(matches))
+	printSpeciesList(matches)
+	return nil
+}
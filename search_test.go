@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpeciesIndexDownloadedOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"count": 3, "next": null, "results": [
+			{"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"},
+			{"name": "raichu", "url": "https://pokeapi.co/api/v2/pokemon-species/26/"},
+			{"name": "pichu", "url": "https://pokeapi.co/api/v2/pokemon-species/172/"}
+		]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "species-index.json")
+	for i := 0; i < 2; i++ {
+		// a fresh client each time so only the file on disk can save the second request
+		client := NewClient(NewCache(time.Minute))
+		client.SetBaseURL(server.URL)
+		index, err := loadSpeciesIndex(client, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matches := index.Search("CHU")
+		if len(matches) != 3 {
+			t.Errorf("expected 3 matches, got %v", matches)
+		}
+		if matches := index.Search("rai"); len(matches) != 1 || matches[0].Name != "raichu" {
+			t.Errorf("expected raichu, got %v", matches)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the index to be downloaded once, got %d requests", requests)
+	}
+}
+
+func TestSearchByTypeIntersects(t *testing.T) {
+	members := map[string]string{
+		"water":  `[{"pokemon": {"name": "squirtle", "url": "/pokemon/7/"}}, {"pokemon": {"name": "gyarados", "url": "/pokemon/130/"}}, {"pokemon": {"name": "pelipper", "url": "/pokemon/279/"}}]`,
+		"flying": `[{"pokemon": {"name": "pidgey", "url": "/pokemon/16/"}}, {"pokemon": {"name": "pelipper", "url": "/pokemon/279/"}}, {"pokemon": {"name": "gyarados", "url": "/pokemon/130/"}}]`,
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/type/"), "/")
+		fmt.Fprintf(w, `{"name": %q, "pokemon": %s}`, name, members[name])
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+	for i := 0; i < 2; i++ {
+		matches, err := client.SearchByType([]string{"Water", "flying"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, match := range matches {
+			names = append(names, match.Name)
+		}
+		if strings.Join(names, ",") != "gyarados,pelipper" {
+			t.Errorf("expected gyarados,pelipper, got %v", names)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected each type list to be downloaded once, got %d requests", requests)
+	}
+}
+
+func TestParseStatFilter(t *testing.T) {
+	cases := map[string]StatFilter{
+		"speed>=120":         {Stat: "speed", Op: ">=", Value: 120},
+		"Attack>100":         {Stat: "attack", Op: ">", Value: 100},
+		"special-defense<50": {Stat: "special-defense", Op: "<", Value: 50},
+		"hp=255":             {Stat: "hp", Op: "=", Value: 255},
+	}
+	for text, expected := range cases {
+		filter, err := parseStatFilter(text)
+		if err != nil {
+			t.Errorf("%v: %v", text, err)
+			continue
+		}
+		if filter != expected {
+			t.Errorf("%v: expected %+v, got %+v", text, expected, filter)
+		}
+	}
+	for _, text := range []string{"speed", ">=120", "speed>=fast", "spd>=100", "sp-atk<=100"} {
+		if _, err := parseStatFilter(text); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+
+	if _, err := parseStatFilter("spd<=100"); err == nil || !strings.Contains(err.Error(), "special-attack, special-defense, speed") {
+		t.Errorf("expected the error to list the stats, got %v", err)
+	}
+
+	filter := StatFilter{Stat: "speed", Op: ">=", Value: 120}
+	if !filter.Matches(120) || filter.Matches(119) {
+		t.Errorf("speed>=120 should match 120 but not 119")
+	}
+}
+
+func TestSearchByStatsWithoutGraphQL(t *testing.T) {
+	speeds := map[string]int{"25": 90, "26": 110, "386": 150}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pokemon/"), "/")
+		names := map[string]string{"25": "pikachu", "26": "raichu", "386": "deoxys-normal"}
+		fmt.Fprintf(w, `{"id": %s, "name": %q, "base_experience": 100, "types": [{"type": {"name": "electric"}}],
+			"stats": [{"base_stat": 60, "stat": {"name": "hp"}}, {"base_stat": %d, "stat": {"name": "speed"}}]}`, id, names[id], speeds[id])
+	}))
+	defer server.Close()
+
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+	session := &Session{config: DefaultConfig(), client: client, speciesIndex: &SpeciesIndex{Species: []NamedResource{
+		{Name: "pikachu", Url: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		{Name: "raichu", Url: "https://pokeapi.co/api/v2/pokemon-species/26/"},
+		{Name: "deoxys", Url: "https://pokeapi.co/api/v2/pokemon-species/386/"},
+	}}}
+
+	// no --type, so the candidates come from the species index
+	results, err := searchByStats(session, PokemonQuery{Stats: []StatFilter{{Stat: "speed", Op: ">=", Value: 100}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Name != "raichu" || results[1].Name != "deoxys-normal" || results[1].Stats["speed"] != 150 {
+		t.Errorf("expected raichu and deoxys-normal, got %+v", results)
+	}
+	results, err = searchByStats(session, PokemonQuery{NameContains: "chu", Stats: []StatFilter{{Stat: "speed", Op: "<", Value: 100}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Name != "pikachu" {
+		t.Errorf("expected pikachu, got %+v", results)
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// serve listens here unless --host and --port say otherwise, only this machine can reach localhost
+const (
+	defaultServeHost = "localhost"
+	defaultServePort = "8080"
+	defaultGRPCPort  = "50051"
+)
+
+// a caught pokemon as the api lists it
+type APIPokemon struct {
+	ID       int      `json:"id"`
+	Number   int      `json:"number"`
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Form     string   `json:"form,omitempty"`
+	Types    []string `json:"types"`
+	Level    int      `json:"level"`
+	Shiny    bool     `json:"shiny"`
+	Favorite bool     `json:"favorite"`
+	Party    bool     `json:"party"`
+	Item     string   `json:"item,omitempty"`
+	CaughtOn string   `json:"caught_on,omitempty"`
+}
+
+// everything inspect shows about a caught pokemon
+type APIPokemonDetails struct {
+	APIPokemon
+	Nature     string         `json:"nature,omitempty"`
+	Experience int            `json:"experience"`
+	Happiness  int            `json:"happiness"`
+	Stats      map[string]int `json:"stats"`
+	IVs        map[string]int `json:"ivs"`
+	EVs        map[string]int `json:"evs"`
+	Moves      []string       `json:"moves"`
+	Sprite     string         `json:"sprite,omitempty"`
+}
+
+// a ball to throw at a wild pokemon, the selected ball when Ball is ""
+type APICatchRequest struct {
+	Pokemon string `json:"pokemon"`
+	Ball    string `json:"ball"`
+}
+
+// how a throw went, Caught is the pokemon when it worked
+type APICatchResult struct {
+	Pokemon   string      `json:"pokemon"`
+	Ball      string      `json:"ball"`
+	Chance    float64     `json:"chance"`
+	Shakes    int         `json:"shakes"`
+	Critical  bool        `json:"critical"`
+	Outcome   string      `json:"outcome"`
+	BallsLeft int         `json:"balls_left"`
+	Caught    *APIPokemon `json:"caught,omitempty"`
+}
+
+// a location area and the pokemon met there
+type APIArea struct {
+	Area    string         `json:"area"`
+	Version string         `json:"version,omitempty"`
+	Pokemon []APIEncounter `json:"pokemon"`
+}
+
+type APIEncounter struct {
+	Name string `json:"name"`
+	// chance out of 100 of meeting it
+	Rate int `json:"rate"`
+}
+
+// an error the api answers with, and the status it goes with
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newAPIError(status int, format string, args ...interface{}) error {
+	return &apiError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// the session's pokedex, catching and exploring as a json api
+// one request is handled at a time since they share the session
+type APIServer struct {
+	session *Session
+	mutex   sync.Mutex
+}
+
+func newAPIPokemon(caught *CaughtPokemon, pokedex *Pokedex) APIPokemon {
+	return APIPokemon{ID: caught.InstanceID, Number: caught.Id, Name: caught.Name, Nickname: caught.Nickname, Form: caught.Form,
+		Types: caught.TypeList(), Level: caught.Level, Shiny: caught.Shiny, Favorite: caught.Favorite, Party: pokedex.InParty(caught),
+		Item: caught.Item, CaughtOn: caught.CaughtOn}
+}
+
+// the routes:
+// GET /api/pokedex lists the caught pokemon, GET /api/pokedex/{pokemon} inspects one by id, nickname or name,
+// POST /api/catch throws a ball at a wild pokemon and GET /api/explore/{area}?version= lists the pokemon in an area
+func (server *APIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/pokedex", server.handle(http.MethodGet, server.listPokedex))
+	mux.HandleFunc("/api/pokedex/", server.handle(http.MethodGet, server.inspect))
+	mux.HandleFunc("/api/catch", server.handle(http.MethodPost, server.catch))
+	mux.HandleFunc("/api/explore/", server.handle(http.MethodGet, server.explore))
+	return mux
+}
+
+// wrap a route that answers with json, errors become {"error": "..."} with their status
+func (server *APIServer) handle(method string, route func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body interface{}
+		status := http.StatusOK
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			status, body = http.StatusMethodNotAllowed, map[string]string{"error": "use " + method}
+		} else {
+			server.mutex.Lock()
+			result, err := route(r)
+			server.mutex.Unlock()
+			body = result
+			if err != nil {
+				status = apiStatus(err)
+				body = map[string]string{"error": err.Error()}
+			}
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// the http status an error from one of the calls goes with
+func apiStatus(err error) int {
+	var apiErr *apiError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.status
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// the last part of the path after prefix, unescaped
+func pathParam(r *http.Request, prefix string) (string, error) {
+	param, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), prefix))
+	if err != nil || param == "" || strings.Contains(param, "/") {
+		return "", newAPIError(http.StatusNotFound, "nothing at %s", r.URL.Path)
+	}
+	return param, nil
+}
+
+func (server *APIServer) listPokedex(r *http.Request) (interface{}, error) {
+	return server.caughtList(), nil
+}
+
+func (server *APIServer) inspect(r *http.Request) (interface{}, error) {
+	ref, err := pathParam(r, "/api/pokedex/")
+	if err != nil {
+		return nil, err
+	}
+	return server.details(ref)
+}
+
+func (server *APIServer) catch(r *http.Request) (interface{}, error) {
+	var request APICatchRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil || request.Pokemon == "" {
+		return nil, newAPIError(http.StatusBadRequest, `send {"pokemon": "name", "ball": "poke"}`)
+	}
+	return server.throw(request)
+}
+
+func (server *APIServer) explore(r *http.Request) (interface{}, error) {
+	location, err := pathParam(r, "/api/explore/")
+	if err != nil {
+		return nil, err
+	}
+	return server.area(location, strings.ToLower(r.URL.Query().Get("version")))
+}
+
+// the calls below are shared by the json api and the grpc service, callers hold the mutex
+
+// every caught pokemon
+func (server *APIServer) caughtList() []APIPokemon {
+	pokedex := server.session.pokedex
+	pokemon := []APIPokemon{}
+	for _, caught := range pokedex.Pokemon {
+		pokemon = append(pokemon, newAPIPokemon(caught, pokedex))
+	}
+	return pokemon
+}
+
+// everything inspect shows about the caught pokemon ref names
+func (server *APIServer) details(ref string) (APIPokemonDetails, error) {
+	session := server.session
+	caught, err := session.pokedex.Find(ref)
+	if err != nil {
+		return APIPokemonDetails{}, newAPIError(http.StatusNotFound, "%v", err)
+	}
+	stats, err := session.caughtStats(caught)
+	if err != nil {
+		return APIPokemonDetails{}, err
+	}
+	moves, err := session.client.Moveset(caught)
+	if err != nil {
+		return APIPokemonDetails{}, err
+	}
+	details := APIPokemonDetails{APIPokemon: newAPIPokemon(caught, session.pokedex), Nature: caught.Nature, Experience: caught.Experience,
+		Happiness: caught.Happiness, Stats: stats, IVs: caught.IVs, EVs: caught.EVs, Moves: moves, Sprite: caught.Sprites.Front_default}
+	if caught.Shiny && caught.Sprites.Front_shiny != "" {
+		details.Sprite = caught.Sprites.Front_shiny
+	}
+	return details, nil
+}
+
+// one ball per request, a pokemon that breaks free ends the encounter and with it any catch chain
+func (server *APIServer) throw(request APICatchRequest) (APICatchResult, error) {
+	session := server.session
+	if session.profile.Nuzlocke.Active() {
+		return APICatchResult{}, newAPIError(http.StatusConflict, "during a nuzlocke run pokemon can only be caught with encounter")
+	}
+	ballName := request.Ball
+	if ballName == "" {
+		ballName = session.inventory.Selected
+	}
+	ball, ok := findBall(ballName)
+	if !ok {
+		return APICatchResult{}, newAPIError(http.StatusBadRequest, "unknown ball, choose one of: %s", strings.Join(ballNames(), ", "))
+	}
+	if session.inventory.Balls[ball.Name] <= 0 {
+		return APICatchResult{}, newAPIError(http.StatusConflict, "you have no %s balls left", ball.Name)
+	}
+
+	pokemon, err := session.client.ResolvePokemon(strings.ToLower(request.Pokemon))
+	if err != nil {
+		return APICatchResult{}, err
+	}
+	species, err := session.client.GetSpecies(pokemon.Species.Name)
+	if err != nil {
+		return APICatchResult{}, err
+	}
+	record, success, err := session.throwBall(pokemon, species, session.catchAttempt(species, ball))
+	if err != nil {
+		return APICatchResult{}, err
+	}
+	result := APICatchResult{Pokemon: pokemon.Name, Ball: ball.Name, Chance: record.Chance, Shakes: record.Shakes, Critical: record.Critical}
+	if success {
+		caught := session.keepCatch(pokemon, species, session.client.RollWildLevel(pokemon), 1, nil)
+		apiPokemon := newAPIPokemon(caught, session.pokedex)
+		result.Caught = &apiPokemon
+		record.Outcome = "caught"
+	} else {
+		record.Outcome = "broke free"
+		session.profile.Chain.Break()
+	}
+	result.Outcome = record.Outcome
+	result.BallsLeft = session.inventory.Balls[ball.Name]
+	session.recordThrow(record)
+	return result, session.Save()
+}
+
+// the pokemon met in the location area, only the ones in version unless it's ""
+func (server *APIServer) area(location, version string) (APIArea, error) {
+	area, encounters, err := server.session.client.Explore(location, version)
+	if err != nil {
+		return APIArea{}, err
+	}
+	result := APIArea{Area: area.Name, Version: version, Pokemon: []APIEncounter{}}
+	for _, encounter := range encounters {
+		result.Pokemon = append(result.Pokemon, APIEncounter{Name: encounter.Pokemon.Name, Rate: encounter.Rate(version)})
+	}
+	server.session.explored(area)
+	return result, server.session.Save()
+}
+
+// serve [--grpc] [--port n] [--host h] - answer the json api, or the grpc service with --grpc, until enter is pressed
+func serveCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	_, flags := parseFlags(args[1].([]string), "grpc")
+	_, useGRPC := flags["grpc"]
+	port, ok := flagValue(flags, "port")
+	if !ok {
+		port = defaultServePort
+		if useGRPC {
+			port = defaultGRPCPort
+		}
+	}
+	host, ok := flagValue(flags, "host")
+	if !ok {
+		host = defaultServeHost
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return err
+	}
+	api := &APIServer{session: session}
+	done := make(chan error, 1)
+	var stop func()
+	if useGRPC {
+		server := newGRPCServer(api)
+		go func() {
+			done <- server.Serve(listener)
+		}()
+		stop = server.Stop
+		fmt.Printf("Serving the pokedex over grpc on %s, play on it with pokedexcli --remote %s\n", listener.Addr(), listener.Addr())
+	} else {
+		server := &http.Server{Handler: api.Handler()}
+		go func() {
+			done <- server.Serve(listener)
+		}()
+		stop = func() { server.Close() }
+		fmt.Printf("Serving the pokedex api on http://%s/api/pokedex\n", listener.Addr())
+	}
+
+	session.Prompt("Press enter to stop")
+	stop()
+	// a stopped grpc server returns nil
+	err = <-done
+	if err == nil || errors.Is(err, http.ErrServerClosed) {
+		fmt.Println("Stopped serving")
+		return nil
+	}
+	return err
+}
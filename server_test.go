@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// an api server on a pokedex with Sparky the pikachu and a master ball, backed by a fake pokeapi that knows mew
+// and the viridian forest
+func testAPIServer(t *testing.T) *APIServer {
+	pokeapi := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/pokemon/mew":
+			w.Write([]byte(`{"id": 151, "name": "mew", "species": {"name": "mew"}, "types": [{"type": {"name": "psychic"}}]}`))
+		case "/pokemon-species/mew":
+			w.Write([]byte(`{"id": 151, "name": "mew", "capture_rate": 45}`))
+		case "/pokemon/mew/encounters":
+			w.Write([]byte(`[]`))
+		case "/location-area/viridian-forest-area":
+			w.Write([]byte(`{"name": "viridian-forest-area", "location": {"name": "viridian-forest"}, "pokemon_encounters": [
+				{"pokemon": {"name": "caterpie"}, "version_details": [{"max_chance": 40, "version": {"name": "red"}}]},
+				{"pokemon": {"name": "pikachu"}, "version_details": [{"max_chance": 5, "version": {"name": "yellow"}}]}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(pokeapi.Close)
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(pokeapi.URL)
+
+	session := &Session{config: DefaultConfig(), client: client, pokedex: NewPokedex(), inventory: NewInventory(), profile: NewProfile()}
+	session.inventory.Balls["master"] = 1
+	session.pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Id: 25, Name: "pikachu"}, Nickname: "Sparky", Level: 12, Moves: []string{"thunder-shock"}})
+	return &APIServer{session: session}
+}
+
+func TestAPIServer(t *testing.T) {
+	api := testAPIServer(t)
+	session := api.session
+	server := httptest.NewServer(api.Handler())
+	defer server.Close()
+
+	get := func(path string, status int, v interface{}) {
+		t.Helper()
+		response, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != status {
+			t.Fatalf("GET %s: expected %d, got %s", path, status, response.Status)
+		}
+		if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var list []APIPokemon
+	get("/api/pokedex", http.StatusOK, &list)
+	if len(list) != 1 || list[0].Nickname != "Sparky" || !list[0].Party {
+		t.Errorf("unexpected pokedex %+v", list)
+	}
+	var details APIPokemonDetails
+	get("/api/pokedex/sparky", http.StatusOK, &details)
+	if details.ID != 1 || details.Level != 12 || len(details.Moves) != 1 || details.Stats == nil {
+		t.Errorf("unexpected details %+v", details)
+	}
+	var problem map[string]string
+	get("/api/pokedex/mew", http.StatusNotFound, &problem)
+	if problem["error"] != "you have not caught mew" {
+		t.Errorf("unexpected error %v", problem)
+	}
+
+	var area APIArea
+	get("/api/explore/viridian-forest-area?version=red", http.StatusOK, &area)
+	if area.Area != "viridian-forest-area" || len(area.Pokemon) != 1 || area.Pokemon[0] != (APIEncounter{Name: "caterpie", Rate: 40}) {
+		t.Errorf("unexpected area %+v", area)
+	}
+	get("/api/explore/nowhere", http.StatusNotFound, &problem)
+
+	post := func(body string, status int, v interface{}) {
+		t.Helper()
+		response, err := http.Post(server.URL+"/api/catch", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != status {
+			t.Fatalf("POST %s: expected %d, got %s", body, status, response.Status)
+		}
+		if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var result APICatchResult
+	post(`{"pokemon": "mew", "ball": "master"}`, http.StatusOK, &result)
+	if result.Outcome != "caught" || result.Caught == nil || result.Caught.ID != 2 || result.BallsLeft != 0 || session.pokedex.Len() != 2 {
+		t.Errorf("unexpected catch %+v", result)
+	}
+	post(`{"pokemon": "mew", "ball": "master"}`, http.StatusConflict, &problem)
+	post(`{"ball": "poke"}`, http.StatusBadRequest, &problem)
+
+	response, err := http.Get(server.URL + "/api/catch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed || response.Header.Get("Allow") != http.MethodPost {
+		t.Errorf("expected GET /api/catch not to be allowed, got %s", response.Status)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// what kind of thing a shop item is, which decides where it goes in the bag
+const (
+	shopBall  = "ball"
+	shopBerry = "berry"
+	shopOther = "item"
+)
+
+// something the shop sells: a ball, a catch berry or an item like an evolution stone
+type ShopItem struct {
+	// the name the bag uses, like "great", "razz" or "fire-stone"
+	Name string
+	Kind string
+}
+
+// the name of the item in the api, like "great-ball"
+func (shopItem ShopItem) ItemName() string {
+	switch shopItem.Kind {
+	case shopBall:
+		return shopItem.Name + "-ball"
+	case shopBerry:
+		return shopItem.Name + "-berry"
+	}
+	return shopItem.Name
+}
+
+// everything the shop stocks, balls from weakest to strongest, then berries, evolution stones and held items
+func shopItems() []ShopItem {
+	items := []ShopItem{}
+	for _, name := range ballNames() {
+		items = append(items, ShopItem{Name: name, Kind: shopBall})
+	}
+	berries := []string{}
+	for name := range catchBerries {
+		berries = append(berries, name)
+	}
+	sort.Strings(berries)
+	for _, name := range berries {
+		items = append(items, ShopItem{Name: name, Kind: shopBerry})
+	}
+	for _, name := range evolutionStones {
+		items = append(items, ShopItem{Name: name, Kind: shopOther})
+	}
+	for _, name := range battleItems {
+		items = append(items, ShopItem{Name: name, Kind: shopOther})
+	}
+	return items
+}
+
+// accept the same ball names catch does, berries with or without "berry", and stones and held items by api name
+func findShopItem(name string) (ShopItem, bool) {
+	if ball, ok := findBall(name); ok {
+		return ShopItem{Name: ball.Name, Kind: shopBall}, true
+	}
+	berry := berryName([]string{name})
+	if _, ok := catchBerries[berry]; ok {
+		return ShopItem{Name: berry, Kind: shopBerry}, true
+	}
+	for _, stone := range evolutionStones {
+		if stone == strings.ToLower(name) {
+			return ShopItem{Name: stone, Kind: shopOther}, true
+		}
+	}
+	for _, item := range battleItems {
+		if item == strings.ToLower(name) {
+			return ShopItem{Name: item, Kind: shopOther}, true
+		}
+	}
+	return ShopItem{}, false
+}
+
+// the item's price from the api, 0 if it can't be bought
+func (client *Client) Price(shopItem ShopItem) (int, error) {
+	item, err := client.GetItem(shopItem.ItemName())
+	if err != nil {
+		return 0, err
+	}
+	return item.Cost, nil
+}
+
+// put bought items in the bag
+func (inventory *Inventory) AddItem(shopItem ShopItem, count int) {
+	switch shopItem.Kind {
+	case shopBall:
+		inventory.AddBalls(balls[shopItem.Name], count)
+	case shopBerry:
+		inventory.AddBerries(shopItem.Name, count)
+	default:
+		inventory.AddItems(shopItem.Name, count)
+	}
+}
+
+// money for a catch, higher level pokemon are worth more and shinies much more
+func catchMoney(caught *CaughtPokemon) int {
+	money := 50 + 10*caught.Level
+	if caught.Shiny {
+		money *= 5
+	}
+	return money
+}
+
+// pay the player for catches and achievements, the command that published the event saves
+func (session *Session) earnMoney(event GameEvent) {
+	money := 0
+	switch event.Kind {
+	case "catch":
+		money = catchMoney(event.Caught)
+	case "achievement":
+		money = event.Achievement.Reward
+	}
+	if money > 0 {
+		session.inventory.Money += money
+		fmt.Printf("You earned $%d\n", money)
+	}
+}
+
+// shop [buy item [count]] - see the prices, or buy balls and berries
+func shopCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	client := session.client
+
+	if len(params) == 0 {
+		fmt.Printf("You have $%d\n", session.inventory.Money)
+		for _, shopItem := range shopItems() {
+			price, err := client.Price(shopItem)
+			if err != nil {
+				fmt.Printf("- %s (%v)\n", shopItem.ItemName(), err)
+			} else if price == 0 {
+				fmt.Printf("- %s: not for sale\n", shopItem.ItemName())
+			} else {
+				fmt.Printf("- %s: $%d\n", shopItem.ItemName(), price)
+			}
+		}
+		return nil
+	}
+	if params[0] != "buy" || len(params) < 2 {
+		fmt.Println("Use shop buy [item] [count] to buy something")
+		return nil
+	}
+
+	shopItem, ok := findShopItem(params[1])
+	if !ok {
+		return fmt.Errorf("the shop doesn't sell %s", params[1])
+	}
+	count := 1
+	if len(params) > 2 {
+		var err error
+		count, err = strconv.Atoi(params[2])
+		if err != nil || count < 1 {
+			return fmt.Errorf("%q isn't a number of items", params[2])
+		}
+	}
+	price, err := client.Price(shopItem)
+	if err != nil {
+		return err
+	}
+	if price == 0 {
+		return fmt.Errorf("%s isn't for sale", shopItem.ItemName())
+	}
+	total := price * count
+	if total > session.inventory.Money {
+		return fmt.Errorf("%d %s cost $%d, you only have $%d", count, shopItem.ItemName(), total, session.inventory.Money)
+	}
+
+	session.inventory.Money -= total
+	session.inventory.AddItem(shopItem, count)
+	fmt.Printf("You bought %d %s for $%d, $%d left\n", count, shopItem.ItemName(), total, session.inventory.Money)
+	return session.Save()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShopBuy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prices := map[string]int{"great-ball": 600, "razz-berry": 100, "master-ball": 0}
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/item/"), "/")
+		fmt.Fprintf(w, `{"name": %q, "cost": %d}`, name, prices[name])
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	session := &Session{client: client, inventory: NewInventory()}
+	session.inventory.Money = 1500
+	buy := func(params ...string) error {
+		return shopCommand(session, append([]string{"buy"}, params...))
+	}
+
+	if err := buy("greatball", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := buy("razz-berry"); err != nil {
+		t.Fatal(err)
+	}
+	if session.inventory.Money != 200 || session.inventory.Balls["great"] != 7 || session.inventory.Berries["razz"] != 6 {
+		t.Errorf("unexpected bag after shopping: %+v", session.inventory)
+	}
+	if err := buy("great", "1"); err == nil {
+		t.Errorf("expected an error buying without enough money")
+	}
+	if err := buy("master"); err == nil {
+		t.Errorf("expected an error buying something that isn't for sale")
+	}
+	if err := buy("rare-candy"); err == nil {
+		t.Errorf("expected an error buying something the shop doesn't stock")
+	}
+	if session.inventory.Money != 200 {
+		t.Errorf("expected failed purchases to cost nothing, have $%d", session.inventory.Money)
+	}
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// how showdown abbreviates stats in EVs and IVs lines
+var showdownStats = map[string]string{
+	"hp":              "HP",
+	"attack":          "Atk",
+	"defense":         "Def",
+	"special-attack":  "SpA",
+	"special-defense": "SpD",
+	"speed":           "Spe",
+}
+
+// one pokemon in a showdown paste, names are showdown's like "Raichu-Alola" and "Thunder Shock"
+type ShowdownSet struct {
+	Nickname  string
+	Species   string
+	Item      string
+	Ability   string
+	Shiny     bool
+	Level     int
+	Happiness int
+	Nature    string
+	EVs       map[string]int
+	IVs       map[string]int
+	Moves     []string
+}
+
+// an api name the way showdown writes it, "thunder-shock" is "Thunder Shock" and "raichu-alola" is "Raichu-Alola"
+func showdownName(name string, separator string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, separator)
+}
+
+// an ability a pokemon can have, every species has one hidden ability that's harder to come by
+type PokemonAbility struct {
+	Ability   NamedResource `json:"ability"`
+	Is_hidden bool          `json:"is_hidden"`
+}
+
+// the first ability a pokemon can have that isn't its hidden ability, "" if it has none
+func (pokemon Pokemon) Ability() string {
+	for _, ability := range pokemon.Abilities {
+		if !ability.Is_hidden {
+			return ability.Ability.Name
+		}
+	}
+	return ""
+}
+
+// a caught pokemon as a showdown set, with the moves it knows
+func newShowdownSet(caught *CaughtPokemon, moves []string) ShowdownSet {
+	set := ShowdownSet{
+		Nickname:  caught.Nickname,
+		Species:   showdownName(caught.Name, "-"),
+		Ability:   showdownName(caught.Ability(), " "),
+		Item:      showdownName(caught.Item, " "),
+		Shiny:     caught.Shiny,
+		Level:     caught.Level,
+		Happiness: caught.Happiness,
+		Nature:    showdownName(caught.Nature, " "),
+		EVs:       caught.EVs,
+		IVs:       caught.IVs,
+	}
+	for _, move := range moves {
+		set.Moves = append(set.Moves, showdownName(move, " "))
+	}
+	return set
+}
+
+// "252 Atk / 4 Def", skipping stats at the value showdown assumes
+func showdownSpread(values map[string]int, skip int) string {
+	parts := []string{}
+	for _, stat := range statOrder {
+		if value, ok := values[stat]; ok && value != skip {
+			parts = append(parts, fmt.Sprintf("%d %s", value, showdownStats[stat]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// the set in showdown's paste format, leaving out what showdown would assume anyway
+func (set ShowdownSet) String() string {
+	lines := []string{}
+	first := set.Species
+	if set.Nickname != "" && set.Nickname != set.Species {
+		first = fmt.Sprintf("%s (%s)", set.Nickname, set.Species)
+	}
+	if set.Item != "" {
+		first += " @ " + set.Item
+	}
+	lines = append(lines, first)
+	if set.Ability != "" {
+		lines = append(lines, "Ability: "+set.Ability)
+	}
+	if set.Shiny {
+		lines = append(lines, "Shiny: Yes")
+	}
+	if set.Level != 0 && set.Level != maxLevel {
+		lines = append(lines, fmt.Sprintf("Level: %d", set.Level))
+	}
+	if set.Happiness != 0 && set.Happiness != maxHappiness {
+		lines = append(lines, fmt.Sprintf("Happiness: %d", set.Happiness))
+	}
+	if evs := showdownSpread(set.EVs, 0); evs != "" {
+		lines = append(lines, "EVs: "+evs)
+	}
+	if set.Nature != "" {
+		lines = append(lines, set.Nature+" Nature")
+	}
+	if ivs := showdownSpread(set.IVs, maxIV); ivs != "" {
+		lines = append(lines, "IVs: "+ivs)
+	}
+	for _, move := range set.Moves {
+		lines = append(lines, "- "+move)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// a team as a showdown paste, sets separated by blank lines
+func (session *Session) showdownPaste(team *Team) (string, error) {
+	sets := []string{}
+	for _, caught := range team.Pokemon(session.pokedex) {
+		moves, err := session.client.Moveset(caught)
+		if err != nil {
+			return "", err
+		}
+		sets = append(sets, newShowdownSet(caught, moves).String())
+	}
+	return strings.Join(sets, "\n\n") + "\n", nil
+}
+
+// write a team out in a format other tools read, printing it unless --out names a file
+func (session *Session) exportTeam(team *Team, flags map[string][]string) error {
+	format, ok := flagValue(flags, "format")
+	if !ok {
+		format = "showdown"
+	}
+	if strings.ToLower(format) != "showdown" {
+		return fmt.Errorf("unknown format %q, teams can be exported as showdown", format)
+	}
+	paste, err := session.showdownPaste(team)
+	if err != nil {
+		return err
+	}
+	if path, ok := flagValue(flags, "out"); ok {
+		err = os.WriteFile(path, []byte(paste), 0o644)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Exported", team.Name, "to", path)
+		return nil
+	}
+	fmt.Print(paste)
+	return nil
+}
+
+// a showdown name as the api writes it, "Mr. Mime" is "mr-mime" and "Thunder Shock" is "thunder-shock"
+func showdownAPIName(name string) string {
+	name = strings.NewReplacer(".", "", "'", "", "’", "", ":", "").Replace(name)
+	return apiName(strings.Fields(name))
+}
+
+// "252 Atk / 4 Def" as values by stat, stats showdown doesn't know are an error
+func parseShowdownSpread(text string) (map[string]int, error) {
+	values := make(map[string]int)
+	for _, part := range strings.Split(text, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("can't read %q", strings.TrimSpace(part))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("can't read %q", strings.TrimSpace(part))
+		}
+		found := false
+		for stat, short := range showdownStats {
+			if strings.EqualFold(short, fields[1]) {
+				values[stat] = value
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown stat %q", fields[1])
+		}
+	}
+	return values, nil
+}
+
+// read the sets in a showdown paste, with a problem for each line that couldn't be read
+// lines showdown has but the game doesn't use, like tera types, are skipped
+func parseShowdown(text string) ([]ShowdownSet, []string) {
+	sets := []ShowdownSet{}
+	problems := []string{}
+	var set *ShowdownSet
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			set = nil
+			continue
+		}
+		if set == nil {
+			sets = append(sets, parseShowdownHeader(line))
+			set = &sets[len(sets)-1]
+			continue
+		}
+		key, value, hasValue := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(line, "-"):
+			move := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+			// hidden power's type is written after it, like "Hidden Power [Fire]"
+			if bracket := strings.Index(move, "["); bracket >= 0 {
+				move = strings.TrimSpace(move[:bracket])
+			}
+			set.Moves = append(set.Moves, move)
+		case strings.HasSuffix(line, " Nature"):
+			set.Nature = strings.TrimSuffix(line, " Nature")
+		case !hasValue:
+			problems = append(problems, fmt.Sprintf("%s: can't read %q", set.Species, line))
+		case key == "Ability":
+			set.Ability = value
+		case key == "Shiny":
+			set.Shiny = strings.EqualFold(value, "yes")
+		case key == "Level" || key == "Happiness":
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: can't read %q", set.Species, line))
+			} else if key == "Level" {
+				set.Level = number
+			} else {
+				set.Happiness = number
+			}
+		case key == "EVs" || key == "IVs":
+			values, err := parseShowdownSpread(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s %v", set.Species, key, err))
+			} else if key == "EVs" {
+				set.EVs = values
+			} else {
+				set.IVs = values
+			}
+		}
+	}
+	return sets, problems
+}
+
+// "Nickname (Species) (M) @ Item", everything but the species can be left out
+func parseShowdownHeader(line string) ShowdownSet {
+	set := ShowdownSet{}
+	if at := strings.LastIndex(line, " @ "); at >= 0 {
+		set.Item = strings.TrimSpace(line[at+3:])
+		line = strings.TrimSpace(line[:at])
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, " (M)"), " (F)")
+	if open := strings.LastIndex(line, " ("); open >= 0 && strings.HasSuffix(line, ")") {
+		set.Nickname = strings.TrimSpace(line[:open])
+		set.Species = line[open+2 : len(line)-1]
+	} else {
+		set.Species = line
+	}
+	return set
+}
+
+// a showdown set as a new caught pokemon, with whatever couldn't be resolved against the api
+func (client *Client) importShowdownSet(set ShowdownSet) (*CaughtPokemon, []string, error) {
+	problems := []string{}
+	pokemon, err := client.ResolvePokemon(showdownAPIName(set.Species))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown species %s", set.Species)
+	}
+	caught := &CaughtPokemon{
+		Pokemon:   pokemon,
+		Form:      pokemon.FormName(),
+		Nickname:  set.Nickname,
+		Shiny:     set.Shiny,
+		Level:     maxLevel,
+		Happiness: maxHappiness,
+		IVs:       make(map[string]int),
+	}
+	// showdown assumes level 100, perfect ivs and full happiness for whatever the paste leaves out
+	if set.Level > 0 && set.Level <= maxLevel {
+		caught.Level = set.Level
+	}
+	if set.Happiness > 0 && set.Happiness <= maxHappiness {
+		caught.Happiness = set.Happiness
+	}
+	for _, stat := range statOrder {
+		caught.IVs[stat] = maxIV
+		if iv, ok := set.IVs[stat]; ok && iv >= 0 && iv <= maxIV {
+			caught.IVs[stat] = iv
+		}
+	}
+	caught.GainEVs(set.EVs)
+
+	if set.Nature != "" {
+		nature, err := client.GetNature(showdownAPIName(set.Nature))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: unknown nature %s", set.Species, set.Nature))
+		} else {
+			caught.Nature = nature.Name
+		}
+	}
+	if item := showdownAPIName(set.Item); item != "" {
+		if knowsItem(item) {
+			caught.Item = item
+		} else {
+			problems = append(problems, fmt.Sprintf("%s: %s does nothing in battle here, left out", set.Species, set.Item))
+		}
+	}
+	for _, name := range set.Moves {
+		if len(caught.Moves) == maxMoves {
+			problems = append(problems, fmt.Sprintf("%s: more than %d moves, left out %s", set.Species, maxMoves, name))
+			continue
+		}
+		move, err := client.GetMove(showdownAPIName(name))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: unknown move %s", set.Species, name))
+			continue
+		}
+		if !knowsMove(caught.Moves, move.Name) {
+			caught.Moves = append(caught.Moves, move.Name)
+		}
+	}
+	return caught, problems, nil
+}
+
+// make a team out of a showdown paste, every pokemon in it joins the pokedex
+func (session *Session) importTeam(path string, flags map[string][]string) error {
+	if session.profile.Nuzlocke.Active() {
+		return fmt.Errorf("pokemon can't be imported during a nuzlocke run")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	name, ok := flagValue(flags, "name")
+	if !ok {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if _, ok := session.teams[strings.ToLower(name)]; ok {
+		return fmt.Errorf("you already have a team called %s, choose another with --name", name)
+	}
+
+	sets, problems := parseShowdown(string(data))
+	if len(sets) == 0 {
+		return fmt.Errorf("%s has no pokemon in it", path)
+	}
+	team := &Team{Name: name, Members: []int{}}
+	for _, set := range sets {
+		caught, setProblems, err := session.client.importShowdownSet(set)
+		problems = append(problems, setProblems...)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		err = validateTeam(append(team.Pokemon(session.pokedex), caught))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", set.Species, err))
+			continue
+		}
+		if !session.pokedex.Add(caught) {
+			fmt.Println("Your party is full,", caught.DisplayName(), "was sent to the pc")
+		}
+		team.Members = append(team.Members, caught.InstanceID)
+		err = session.Record(HistoryEvent{Kind: "import", Pokemon: caught.Name})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(team.Members) > 0 {
+		session.teams[strings.ToLower(name)] = team
+		printTeam(team, session.pokedex)
+	}
+	if len(problems) > 0 {
+		fmt.Println("Some of the paste couldn't be imported:")
+		for _, problem := range problems {
+			fmt.Println("-", problem)
+		}
+	}
+	return session.Save()
+}
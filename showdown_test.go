@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShowdownSet(t *testing.T) {
+	caught := &CaughtPokemon{
+		Pokemon:   Pokemon{Name: "raichu-alola"},
+		Nickname:  "Sparky",
+		Nature:    "timid",
+		Shiny:     true,
+		Level:     50,
+		Happiness: maxHappiness,
+		EVs:       map[string]int{"special-attack": 252, "speed": 252, "hp": 4},
+		IVs:       map[string]int{"hp": 31, "attack": 0, "defense": 31, "special-attack": 31, "special-defense": 31, "speed": 31},
+	}
+	caught.Abilities = []PokemonAbility{
+		{Ability: NamedResource{Name: "surge-surfer"}, Is_hidden: true},
+		{Ability: NamedResource{Name: "static"}},
+	}
+
+	expected := `Sparky (Raichu-Alola)
+Ability: Static
+Shiny: Yes
+Level: 50
+EVs: 4 HP / 252 SpA / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunder Shock
+- Psychic`
+	if paste := newShowdownSet(caught, []string{"thunder-shock", "psychic"}).String(); paste != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, paste)
+	}
+}
+
+func TestParseShowdown(t *testing.T) {
+	paste := `Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+Tera Type: Electric
+EVs: 252 SpA / 4 SpD / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunderbolt
+- Hidden Power [Ice]
+
+Mr. Mime
+Happiness: lots
+EVs: 252 Spooky
+- Psychic
+`
+	sets, problems := parseShowdown(paste)
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 sets, got %+v", sets)
+	}
+	pikachu := sets[0]
+	if pikachu.Nickname != "Sparky" || pikachu.Species != "Pikachu" || pikachu.Item != "Light Ball" || pikachu.Level != 50 || pikachu.Nature != "Timid" {
+		t.Errorf("unexpected pikachu %+v", pikachu)
+	}
+	if pikachu.EVs["special-attack"] != 252 || pikachu.EVs["special-defense"] != 4 || pikachu.IVs["attack"] != 0 || len(pikachu.IVs) != 1 {
+		t.Errorf("unexpected spreads %v and %v", pikachu.EVs, pikachu.IVs)
+	}
+	if strings.Join(pikachu.Moves, ",") != "Thunderbolt,Hidden Power" {
+		t.Errorf("unexpected moves %v", pikachu.Moves)
+	}
+	if sets[1].Species != "Mr. Mime" || showdownAPIName(sets[1].Species) != "mr-mime" {
+		t.Errorf("unexpected species %q", sets[1].Species)
+	}
+	if len(problems) != 2 {
+		t.Errorf("expected the happiness and evs of mr mime to be problems, got %v", problems)
+	}
+}
+
+func TestImportTeam(t *testing.T) {
+	known := map[string]string{
+		"/pokemon/pikachu":  `{"id": 25, "name": "pikachu", "is_default": true, "species": {"name": "pikachu"}}`,
+		"/nature/timid":     `{"name": "timid"}`,
+		"/move/thunderbolt": `{"name": "thunderbolt"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := known[strings.TrimSuffix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	path := filepath.Join(t.TempDir(), "rain team.txt")
+	paste := "Pikachu\nTimid Nature\n- Thunderbolt\n- Made Up Move\n\nMissingno\n- Psychic\n\nPikachu\n"
+	if err := os.WriteFile(path, []byte(paste), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	session := &Session{client: client, pokedex: NewPokedex(), profile: NewProfile(), teams: map[string]*Team{}}
+	if err := session.importTeam(path, nil); err != nil {
+		t.Fatal(err)
+	}
+	team, ok := session.teams["rain team"]
+	if !ok || len(team.Members) != 1 {
+		t.Fatalf("expected a rain team with one pikachu, got %+v", session.teams)
+	}
+	caught := session.pokedex.Pokemon[0]
+	if caught.Nature != "timid" || strings.Join(caught.Moves, ",") != "thunderbolt" || caught.Level != maxLevel || caught.IVs["speed"] != maxIV {
+		t.Errorf("unexpected pikachu %+v", caught)
+	}
+	if session.pokedex.Len() != 1 {
+		t.Errorf("expected the second pikachu to be left out by the species clause, got %d pokemon", session.pokedex.Len())
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// simulate runs this many battles unless --n says otherwise, and calls a battle a draw once it reaches the turn limit
+const (
+	defaultSimulations = 1000
+	maxSimulations     = 100000
+	simulationTurns    = 500
+)
+
+// how a batch of simulated battles went for the first side
+type SimulationResult struct {
+	Battles int
+	Wins    int
+	Losses  int
+	// battles that hit the turn limit
+	Draws int
+	// turns over every battle
+	Turns int
+}
+
+func (result SimulationResult) WinRate() float64 {
+	if result.Battles == 0 {
+		return 0
+	}
+	return float64(result.Wins) / float64(result.Battles)
+}
+
+// the margin of the win rate at 95% confidence
+func (result SimulationResult) Margin() float64 {
+	if result.Battles == 0 {
+		return 0
+	}
+	rate := result.WinRate()
+	return 1.96 * math.Sqrt(rate*(1-rate)/float64(result.Battles))
+}
+
+func (result SimulationResult) AverageTurns() float64 {
+	if result.Battles == 0 {
+		return 0
+	}
+	return float64(result.Turns) / float64(result.Battles)
+}
+
+// a fresh copy of a battler, at full health with full pp, so every simulated battle starts the same
+func cloneBattler(battler *Battler) *Battler {
+	clone := &Battler{Name: battler.Name, Level: battler.Level, Types: battler.Types, Stats: battler.Stats, HP: battler.Stats["hp"],
+		Effort: battler.Effort, BaseExperience: battler.BaseExperience, Item: battler.Item, Ability: battler.Ability}
+	for _, move := range battler.Moves {
+		copied := *move
+		copied.PP = copied.MaxPP
+		clone.Moves = append(clone.Moves, &copied)
+	}
+	return clone
+}
+
+func cloneSide(side *BattleSide) *BattleSide {
+	clone := &BattleSide{Name: side.Name}
+	for _, battler := range side.Team {
+		clone.Team = append(clone.Team, cloneBattler(battler))
+	}
+	return clone
+}
+
+// one battle with both sides played by the ai, the state from the first side's view and how many turns it took
+func simulateBattle(player, opponent *BattleSide, chart TypeChart, ai BattleAI, random *rand.Rand) (string, int) {
+	battle := NewBattle(cloneSide(player), cloneSide(opponent), false, random)
+	battle.Chart = chart
+	battle.Start()
+	for !battle.Over() && battle.Turn < simulationTurns {
+		battle.Step([2]BattleAction{ai(battle, playerSide), ai(battle, opponentSide)})
+	}
+	return battle.State, battle.Turn
+}
+
+// play n battles between the sides on workers goroutines, battle i is seeded with seed+i so a seed gives the same result
+// however the battles are spread over the workers
+func simulate(player, opponent *BattleSide, chart TypeChart, ai BattleAI, n, workers int, seed int64) SimulationResult {
+	results := make(chan SimulationResult)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := SimulationResult{}
+			for i := range indexes {
+				state, turns := simulateBattle(player, opponent, chart, ai, rand.New(rand.NewSource(seed+int64(i))))
+				result.Battles++
+				result.Turns += turns
+				switch state {
+				case battleWon:
+					result.Wins++
+				case battleLost:
+					result.Losses++
+				default:
+					result.Draws++
+				}
+			}
+			results <- result
+		}()
+	}
+	go func() {
+		for i := 0; i < n; i++ {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(results)
+	}()
+
+	total := SimulationResult{}
+	for result := range results {
+		total.Battles += result.Battles
+		total.Wins += result.Wins
+		total.Losses += result.Losses
+		total.Draws += result.Draws
+		total.Turns += result.Turns
+	}
+	return total
+}
+
+// the battlers of one of the player's teams, or the party
+func (session *Session) teamSide(name string) (*BattleSide, error) {
+	if strings.ToLower(name) == "party" {
+		return session.playerSide()
+	}
+	team, err := session.findTeam(name)
+	if err != nil {
+		return nil, err
+	}
+	side := &BattleSide{Name: team.Name}
+	for _, caught := range team.Pokemon(session.pokedex) {
+		battler, err := session.client.CaughtBattler(caught)
+		if err != nil {
+			return nil, err
+		}
+		side.Team = append(side.Team, battler)
+	}
+	if len(side.Team) == 0 {
+		return nil, fmt.Errorf("%s has no pokemon", team.Name)
+	}
+	return side, nil
+}
+
+// simulate <team|party> vs <team|pokemon...> [--n battles] [--level n] [--difficulty d] - estimate how often a team wins
+func simulateCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	split := -1
+	for i, param := range params {
+		if strings.ToLower(param) == "vs" {
+			split = i
+			break
+		}
+	}
+	if split != 1 || len(params) < 3 {
+		fmt.Println("Use simulate <team|party> vs <team|pokemon...>, like simulate party vs onix geodude")
+		return nil
+	}
+	n := defaultSimulations
+	if value, ok := flagValue(flags, "n"); ok {
+		var err error
+		n, err = strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSimulations {
+			return fmt.Errorf("--n takes a number of battles from 1 to %d", maxSimulations)
+		}
+	}
+	// both sides play as well as the ai can unless asked otherwise
+	ai, err := battleAIFor(flags, "hard")
+	if err != nil {
+		return err
+	}
+
+	player, err := session.teamSide(params[0])
+	if err != nil {
+		return err
+	}
+	opponents := params[split+1:]
+	var opponent *BattleSide
+	if _, ok := session.teams[strings.ToLower(opponents[0])]; ok && len(opponents) == 1 {
+		opponent, err = session.teamSide(opponents[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		level := 0
+		for _, battler := range player.Team {
+			if battler.Level > level {
+				level = battler.Level
+			}
+		}
+		if value, ok := flagValue(flags, "level"); ok {
+			level, err = strconv.Atoi(value)
+			if err != nil || level < 1 || level > maxLevel {
+				return fmt.Errorf("--level takes a level from 1 to %d", maxLevel)
+			}
+		}
+		roster := []GymPokemon{}
+		for _, name := range opponents {
+			roster = append(roster, GymPokemon{Name: name, Level: level})
+		}
+		opponent, err = session.client.rosterSide(strings.Join(opponents, ", "), roster)
+		if err != nil {
+			return err
+		}
+	}
+	chart, err := session.client.GetTypeChart(NewBattle(player, opponent, false, nil).MoveTypes())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Simulating %d battles of %s against %s...\n", n, player.Name, opponent.Name)
+	result := simulate(player, opponent, chart, ai, n, runtime.NumCPU(), time.Now().UnixNano())
+	fmt.Printf("Win chance: %.1f%% (±%.1f%%)\n", 100*result.WinRate(), 100*result.Margin())
+	fmt.Printf("%d won, %d lost", result.Wins, result.Losses)
+	if result.Draws > 0 {
+		fmt.Printf(", %d went past %d turns", result.Draws, simulationTurns)
+	}
+	fmt.Printf("\nBattles took %.1f turns on average\n", result.AverageTurns())
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestSimulate(t *testing.T) {
+	strong := &BattleSide{Name: "Ash", Team: []*Battler{testBattler("pikachu", 30, 90, tackle()), testBattler("pidgey", 20, 50, tackle())}}
+	weak := &BattleSide{Name: "rattata", Team: []*Battler{testBattler("rattata", 5, 50, tackle())}}
+
+	result := simulate(strong, weak, nil, greedyAction, 200, 4, 1)
+	if result.Battles != 200 || result.Wins != 200 || result.WinRate() != 1 {
+		t.Errorf("expected the stronger team to win every battle, got %+v", result)
+	}
+	// the sides themselves are only ever copied
+	if strong.Team[0].HP != 30 || strong.Team[0].Moves[0].PP != 35 || weak.Team[0].HP != 30 {
+		t.Errorf("expected the sides to be untouched, got %d hp and %d pp", strong.Team[0].HP, strong.Team[0].Moves[0].PP)
+	}
+
+	// evenly matched, and the same seed gives the same result on any number of workers
+	even := &BattleSide{Name: "Gary", Team: []*Battler{testBattler("eevee", 20, 50, tackle())}}
+	mirror := &BattleSide{Name: "Red", Team: []*Battler{testBattler("eevee", 20, 50, tackle())}}
+	first := simulate(even, mirror, nil, greedyAction, 400, 1, 7)
+	second := simulate(even, mirror, nil, greedyAction, 400, 8, 7)
+	if first != second {
+		t.Errorf("expected the same result from the same seed, got %+v and %+v", first, second)
+	}
+	if first.WinRate() < 0.3 || first.WinRate() > 0.7 || first.AverageTurns() < 2 || first.Margin() <= 0 {
+		t.Errorf("expected about even odds, got %+v", first)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PokemonSpecies struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	Capture_rate    int             `json:"capture_rate"`
+	Base_happiness  *int            `json:"base_happiness"`
+	Hatch_counter   int             `json:"hatch_counter"`
+	Gender_rate     int             `json:"gender_rate"`
+	Is_legendary    bool            `json:"is_legendary"`
+	Is_mythical     bool            `json:"is_mythical"`
+	Growth_rate     NamedResource   `json:"growth_rate"`
+	Generation      NamedResource   `json:"generation"`
+	Egg_groups      []NamedResource `json:"egg_groups"`
+	Evolution_chain struct {
+		Url string `json:"url"`
+	} `json:"evolution_chain"`
+	Names               []LocalizedName   `json:"names"`
+	Flavor_text_entries []FlavorTextEntry `json:"flavor_text_entries"`
+	Varieties           []struct {
+		Is_default bool          `json:"is_default"`
+		Pokemon    NamedResource `json:"pokemon"`
+	} `json:"varieties"`
+	Genera []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+}
+
+// fetch a species by name or id
+func (client *Client) GetSpecies(name string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+	err := client.GetJSON(client.ResourceURL("pokemon-species", name), &species)
+	return species, err
+}
+
+// name of the pokemon the species normally appears as, "deoxys-normal" for deoxys
+func (species PokemonSpecies) DefaultPokemon() string {
+	for _, variety := range species.Varieties {
+		if variety.Is_default {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
+
+// the english genus, like "Mouse Pokémon"
+func (species PokemonSpecies) Genus() string {
+	for _, genus := range species.Genera {
+		if genus.Language.Name == "en" {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+// the most recent english pokedex entry and the version it's from
+func (species PokemonSpecies) LatestFlavorText() (string, string) {
+	text, version := "", ""
+	for _, entry := range species.Flavor_text_entries {
+		if entry.Language.Name == "en" {
+			text, version = cleanFlavorText(entry.Flavor_text), entry.Version.Name
+		}
+	}
+	return text, version
+}
+
+// the english pokedex entry from one version, like "red" or "x"
+func (species PokemonSpecies) FlavorText(version string) (string, bool) {
+	for _, entry := range species.Flavor_text_entries {
+		if entry.Language.Name == "en" && entry.Version.Name == version {
+			return cleanFlavorText(entry.Flavor_text), true
+		}
+	}
+	return "", false
+}
+
+// versions with an english pokedex entry, oldest first
+func (species PokemonSpecies) FlavorTextVersions() []string {
+	versions := []string{}
+	for _, entry := range species.Flavor_text_entries {
+		if entry.Language.Name == "en" {
+			versions = append(versions, entry.Version.Name)
+		}
+	}
+	return versions
+}
+
+// show the pokedex entry of a species, from one version or the latest one
+func dexEntryCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	species, err := session.client.GetSpeciesOf(strings.ToLower(params[0]))
+	if err != nil {
+		return err
+	}
+	name := englishName(species.Names, species.Name)
+
+	if len(params) < 2 {
+		text, version := species.LatestFlavorText()
+		if text == "" {
+			fmt.Println("No pokedex entry for", name)
+			return nil
+		}
+		fmt.Printf("%s (%s): %s\n", name, version, text)
+		return nil
+	}
+
+	version := apiName(params[1:])
+	text, ok := species.FlavorText(version)
+	if !ok {
+		fmt.Println("No pokedex entry for", name, "in", version)
+		fmt.Println("Entries exist for:", strings.Join(species.FlavorTextVersions(), ", "))
+		return nil
+	}
+	fmt.Printf("%s (%s): %s\n", name, version, text)
+	return nil
+}
+
+// show pokedex text, genus, capture rate, base happiness and growth rate of a species
+func speciesCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	species, err := session.client.GetSpeciesOf(apiName(params))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Species:", englishName(species.Names, species.Name))
+	if forms := species.Forms(); len(forms) > 1 {
+		fmt.Println("Forms:", strings.Join(forms, ", "))
+	}
+	if genus := species.Genus(); genus != "" {
+		fmt.Println("Genus:", genus)
+	}
+	if species.Is_legendary {
+		fmt.Println("Legendary pokemon")
+	}
+	if species.Is_mythical {
+		fmt.Println("Mythical pokemon")
+	}
+	fmt.Println("Introduced in:", species.Generation.Name)
+	fmt.Printf("Capture rate: %d/255\n", species.Capture_rate)
+	fmt.Println("Base happiness:", optionalInt(species.Base_happiness))
+	fmt.Println("Growth rate:", species.Growth_rate.Name)
+	if len(species.Egg_groups) > 0 {
+		groups := []string{}
+		for _, group := range species.Egg_groups {
+			groups = append(groups, group.Name)
+		}
+		fmt.Println("Egg groups:", strings.Join(groups, ", "))
+	}
+	if text, version := species.LatestFlavorText(); text != "" {
+		fmt.Printf("Pokedex entry (%s): %s\n", version, text)
+	}
+	return nil
+}
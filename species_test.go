@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSpeciesFlavorText(t *testing.T) {
+	raw := `{"name": "pikachu", "flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Il se sert de sa queue", "language": {"name": "fr"}, "version": {"name": "x"}},
+		{"flavor_text": "It keeps its tail\nraised", "language": {"name": "en"}, "version": {"name": "yellow"}}
+	]}`
+	var species PokemonSpecies
+	err := json.Unmarshal([]byte(raw), &species)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text, ok := species.FlavorText("red"); !ok || text != "When several of these POKéMON gather" {
+		t.Errorf("unexpected red entry %q", text)
+	}
+	if _, ok := species.FlavorText("x"); ok {
+		t.Errorf("expected no english entry for x")
+	}
+	if versions := strings.Join(species.FlavorTextVersions(), ","); versions != "red,yellow" {
+		t.Errorf("expected red,yellow, got %v", versions)
+	}
+	if text, version := species.LatestFlavorText(); version != "yellow" || text != "It keeps its tail raised" {
+		t.Errorf("unexpected latest entry %q from %v", text, version)
+	}
+}
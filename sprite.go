@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"os"
+	"strings"
+)
+
+// widest a sprite is drawn, in terminal columns
+const spriteWidth = 48
+
+// whether the terminal says it can show 24 bit color, otherwise the 256 color palette is used
+func supportsTrueColor() bool {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// the smallest rectangle holding every visible pixel, sprites have a lot of empty space around them
+func visibleBounds(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	visible := image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a > 0 {
+				visible = visible.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return visible
+}
+
+// ansi escape for a foreground (38) or background (48) color
+func ansiColor(layer int, c color.Color, trueColor bool) string {
+	r, g, b, _ := c.RGBA()
+	r, g, b = r>>8, g>>8, b>>8
+	if trueColor {
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, r, g, b)
+	}
+	// the 6x6x6 color cube of the 256 color palette starts at 16
+	index := 16 + 36*(r*5/255) + 6*(g*5/255) + b*5/255
+	return fmt.Sprintf("\x1b[%d;5;%dm", layer, index)
+}
+
+// draw an image with half block characters, every character cell shows two pixels stacked on top of each other
+func renderSprite(img image.Image, maxWidth int, trueColor bool) string {
+	bounds := visibleBounds(img)
+	if bounds.Empty() {
+		return ""
+	}
+	// nearest neighbour scaling keeps pixel art sharp
+	scale := 1
+	for bounds.Dx()/scale > maxWidth {
+		scale++
+	}
+
+	visible := func(x, y int) (color.Color, bool) {
+		if y >= bounds.Max.Y {
+			return nil, false
+		}
+		c := img.At(x, y)
+		_, _, _, a := c.RGBA()
+		return c, a > 0x7fff
+	}
+
+	var out strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 * scale {
+		for x := bounds.Min.X; x < bounds.Max.X; x += scale {
+			top, topVisible := visible(x, y)
+			bottom, bottomVisible := visible(x, y+scale)
+			switch {
+			case topVisible && bottomVisible:
+				out.WriteString(ansiColor(38, top, trueColor) + ansiColor(48, bottom, trueColor) + "▀")
+			case topVisible:
+				out.WriteString("\x1b[49m" + ansiColor(38, top, trueColor) + "▀")
+			case bottomVisible:
+				out.WriteString("\x1b[49m" + ansiColor(38, bottom, trueColor) + "▄")
+			default:
+				out.WriteString("\x1b[0m ")
+			}
+		}
+		out.WriteString("\x1b[0m\n")
+	}
+	return out.String()
+}
+
+// download a sprite png and draw it in the terminal
+func printSprite(client *Client, url string) error {
+	if url == "" {
+		fmt.Println("No sprite available")
+		return nil
+	}
+	data, err := client.GetAsset(url)
+	if err != nil {
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("couldn't read sprite: %w", err)
+	}
+	fmt.Print(renderSprite(img, spriteWidth, supportsTrueColor()))
+	return nil
+}
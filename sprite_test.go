@@ -0,0 +1,41 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestRenderSprite(t *testing.T) {
+	// a 2x2 red and blue square in the middle of an empty 6x6 sprite
+	img := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	img.Set(2, 2, red)
+	img.Set(3, 2, red)
+	img.Set(2, 3, blue)
+	img.Set(3, 3, blue)
+
+	if bounds := visibleBounds(img); bounds != image.Rect(2, 2, 4, 4) {
+		t.Errorf("expected the empty border to be cropped, got %v", bounds)
+	}
+
+	out := renderSprite(img, spriteWidth, true)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected two pixel rows in one line, got %q", out)
+	}
+	if strings.Count(lines[0], "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀") != 2 {
+		t.Errorf("expected red over blue half blocks, got %q", lines[0])
+	}
+
+	out = renderSprite(img, spriteWidth, false)
+	if !strings.Contains(out, "\x1b[38;5;196m\x1b[48;5;21m▀") {
+		t.Errorf("expected 256 color escapes, got %q", out)
+	}
+
+	if renderSprite(image.NewNRGBA(image.Rect(0, 0, 4, 4)), spriteWidth, true) != "" {
+		t.Errorf("expected nothing for an empty image")
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stat stages go from -6 to +6, switching out resets them
+const maxStage = 6
+
+// the stats moves can raise and lower in battle, in the order the status line shows them
+var stageStats = []string{"attack", "defense", "special-attack", "special-defense", "speed", "accuracy", "evasion"}
+
+// how the status line shows each stage
+var stageAbbreviations = map[string]string{
+	"attack":          "Atk",
+	"defense":         "Def",
+	"special-attack":  "SpA",
+	"special-defense": "SpD",
+	"speed":           "Spe",
+	"accuracy":        "Acc",
+	"evasion":         "Eva",
+}
+
+// one stat a move changes, and by how many stages
+type StatChange struct {
+	Change int           `json:"change"`
+	Stat   NamedResource `json:"stat"`
+}
+
+// the multiplier for a stage: +1 is 3/2, +6 is 4, -1 is 2/3 and -6 is 1/4
+func stageMultiplier(stage int) float64 {
+	if stage >= 0 {
+		return float64(2+stage) / 2
+	}
+	return 2 / float64(2-stage)
+}
+
+// accuracy and evasion stages are gentler: +1 is 4/3, +6 is 3
+func accuracyMultiplier(stage int) float64 {
+	if stage > maxStage {
+		stage = maxStage
+	} else if stage < -maxStage {
+		stage = -maxStage
+	}
+	if stage >= 0 {
+		return float64(3+stage) / 3
+	}
+	return 3 / float64(3-stage)
+}
+
+// a stat scaled by the battler's stage in it
+func (battler *Battler) staged(stat string) int {
+	return int(float64(battler.Stats[stat]) * stageMultiplier(battler.Stages[stat]))
+}
+
+// the stat a battler attacks with for a damage class after stages, a choice band or specs adds half again and a burn halves attack
+func (battler *Battler) attackStat(class string) int {
+	if class == "special" {
+		if battler.Item == choiceSpecs {
+			return battler.staged("special-attack") * 3 / 2
+		}
+		return battler.staged("special-attack")
+	}
+	attack := battler.staged("attack")
+	if battler.Item == choiceBand {
+		attack = attack * 3 / 2
+	}
+	if battler.Condition == statusBurn {
+		attack /= 2
+	}
+	return attack
+}
+
+// the stat a battler defends with against a damage class after stages
+func (battler *Battler) defenseStat(class string) int {
+	if class == "special" {
+		return battler.staged("special-defense")
+	}
+	return battler.staged("defense")
+}
+
+// the battler's speed after stages, a choice scarf adds half again and paralysis halves it
+func (battler *Battler) Speed() int {
+	speed := battler.staged("speed")
+	if battler.Item == choiceScarf {
+		speed = speed * 3 / 2
+	}
+	if battler.Condition == statusParalysis {
+		speed /= 2
+	}
+	return speed
+}
+
+// the chance in percent a move hits, the attacker's accuracy stage against the defender's evasion
+func hitChance(move *BattleMove, attacker, defender *Battler) int {
+	return int(float64(move.Accuracy) * accuracyMultiplier(attacker.Stages["accuracy"]-defender.Stages["evasion"]))
+}
+
+// "+2 Atk -1 Def", "" when every stage is 0
+func (battler *Battler) StagesText() string {
+	parts := []string{}
+	for _, stat := range stageStats {
+		if stage := battler.Stages[stat]; stage != 0 {
+			parts = append(parts, fmt.Sprintf("%+d %s", stage, stageAbbreviations[stat]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// raise or lower a battler's stat by change stages, never past ±6
+func (battle *Battle) changeStage(battler *Battler, stat string, change int) {
+	if battler.Stages == nil {
+		battler.Stages = make(map[string]int)
+	}
+	name := strings.ReplaceAll(stat, "-", " ")
+	current := battler.Stages[stat]
+	if (change > 0 && current == maxStage) || (change < 0 && current == -maxStage) {
+		direction := "higher"
+		if change < 0 {
+			direction = "lower"
+		}
+		battle.emit(BattleEvent{Kind: "stage", Target: battler.Name, Move: stat,
+			Message: fmt.Sprintf("%s's %s won't go any %s!", battler.Name, name, direction)})
+		return
+	}
+	stage := current + change
+	if stage > maxStage {
+		stage = maxStage
+	} else if stage < -maxStage {
+		stage = -maxStage
+	}
+	battler.Stages[stat] = stage
+
+	words := map[int]string{1: "rose", 2: "rose sharply", -1: "fell", -2: "harshly fell"}
+	word, ok := words[change]
+	if !ok && change > 0 {
+		word = "rose drastically"
+	} else if !ok {
+		word = "severely fell"
+	}
+	battle.emit(BattleEvent{Kind: "stage", Target: battler.Name, Move: stat, Damage: stage - current,
+		Message: fmt.Sprintf("%s's %s %s!", battler.Name, name, word)})
+}
+
+// the move's stat changes, on its user or its target: always for status moves, by chance for damaging ones
+func (battle *Battle) moveStages(move *BattleMove, attacker, defender *Battler) {
+	if len(move.StatChanges) == 0 {
+		return
+	}
+	if move.Power > 0 && move.StatChance > 0 && battle.rand.Intn(100) >= move.StatChance {
+		return
+	}
+	target := defender
+	if move.StagesOnUser {
+		target = attacker
+	}
+	if target.Fainted() {
+		return
+	}
+	for _, stat := range stageStats {
+		if change, ok := move.StatChanges[stat]; ok {
+			battle.changeStage(target, stat, change)
+		}
+	}
+}
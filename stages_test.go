@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestStageMultipliers(t *testing.T) {
+	tests := []struct {
+		stage    int
+		stat     float64
+		accuracy float64
+	}{
+		{0, 1, 1},
+		{1, 1.5, 4.0 / 3},
+		{6, 4, 3},
+		{-1, 2.0 / 3, 0.75},
+		{-6, 0.25, 1.0 / 3},
+	}
+	for _, test := range tests {
+		if got := stageMultiplier(test.stage); got != test.stat {
+			t.Errorf("stage %d: expected %g, got %g", test.stage, test.stat, got)
+		}
+		if got := accuracyMultiplier(test.stage); got != test.accuracy {
+			t.Errorf("accuracy stage %d: expected %g, got %g", test.stage, test.accuracy, got)
+		}
+	}
+}
+
+func TestNewBattleMoveStatChanges(t *testing.T) {
+	var swordsDance, psychic Move
+	if err := json.Unmarshal([]byte(`{"name": "swords-dance", "pp": 20, "target": {"name": "user"},
+		"stat_changes": [{"change": 2, "stat": {"name": "attack"}}]}`), &swordsDance); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"name": "psychic", "power": 90, "pp": 10, "target": {"name": "selected-pokemon"},
+		"meta": {"category": {"name": "damage+lower"}, "stat_chance": 10},
+		"stat_changes": [{"change": -1, "stat": {"name": "special-defense"}}]}`), &psychic); err != nil {
+		t.Fatal(err)
+	}
+	if move := newBattleMove(swordsDance); move.StatChanges["attack"] != 2 || !move.StagesOnUser {
+		t.Errorf("unexpected swords dance %+v", move)
+	}
+	if move := newBattleMove(psychic); move.StatChanges["special-defense"] != -1 || move.StagesOnUser || move.StatChance != 10 {
+		t.Errorf("unexpected psychic %+v", move)
+	}
+}
+
+func TestStatStages(t *testing.T) {
+	swordsDance := &BattleMove{Name: "swords-dance", Type: "normal", Class: "status", PP: 20, MaxPP: 20,
+		StatChanges: map[string]int{"attack": 2}, StagesOnUser: true}
+	growl := &BattleMove{Name: "growl", Type: "normal", Class: "status", Accuracy: 100, PP: 40, MaxPP: 40,
+		StatChanges: map[string]int{"attack": -1}}
+	scyther := testBattler("scyther", 20, 90, swordsDance, tackle())
+	scyther.Stats["hp"], scyther.HP = 200, 200
+	splash := &BattleMove{Name: "splash", Type: "normal", Class: "status", PP: 40, MaxPP: 40}
+	pidgey := testBattler("pidgey", 20, 50, splash, growl)
+	pidgey.Stats["hp"], pidgey.HP = 200, 200
+	spare := testBattler("rattata", 20, 10, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{scyther, spare}}, &BattleSide{Name: "wild pidgey", Team: []*Battler{pidgey}}, true, rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 4; i++ {
+		battle.Step([2]BattleAction{{Kind: "move", Move: 0}, {Kind: "move", Move: 0}})
+	}
+	battle.Step([2]BattleAction{{Kind: "move", Move: 1}, {Kind: "move", Move: 1}})
+	// +8 from swords dance is capped at +6, then growl takes one away
+	if scyther.Stages["attack"] != 5 || scyther.attackStat("physical") != 70 {
+		t.Errorf("expected scyther at +5 attack, got %+d and %d", scyther.Stages["attack"], scyther.attackStat("physical"))
+	}
+	if scyther.Status() != "scyther lv.20 HP 200/200 [+5 Atk]" {
+		t.Errorf("unexpected status %q", scyther.Status())
+	}
+	capped := false
+	for _, event := range battle.Events {
+		if event.Message == "scyther's attack won't go any higher!" {
+			capped = true
+		}
+	}
+	if !capped {
+		t.Errorf("expected a message when attack couldn't go higher")
+	}
+
+	battle.Step([2]BattleAction{{Kind: "switch", Switch: 1}, {Kind: "move"}})
+	if scyther.Stages != nil {
+		t.Errorf("expected switching out to reset stages, got %v", scyther.Stages)
+	}
+}
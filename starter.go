@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the three pokemon each generation's professor offers, grass, fire and water
+var starters = [][]string{
+	{"bulbasaur", "charmander", "squirtle"},
+	{"chikorita", "cyndaquil", "totodile"},
+	{"treecko", "torchic", "mudkip"},
+	{"turtwig", "chimchar", "piplup"},
+	{"snivy", "tepig", "oshawott"},
+	{"chespin", "fennekin", "froakie"},
+	{"rowlet", "litten", "popplio"},
+	{"grookey", "scorbunny", "sobble"},
+	{"sprigatito", "fuecoco", "quaxly"},
+}
+
+// starters are met at the level the games hand them out at
+const starterLevel = 5
+
+// a starter by number (1-3) or name from the generation's three
+func pickStarter(choices []string, answer string) (string, bool) {
+	answer = strings.ToLower(answer)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+		return choices[n-1], true
+	}
+	for _, choice := range choices {
+		if choice == answer {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// walk a new player through choosing their first pokemon, returns false if they didn't get one
+func (session *Session) chooseStarter() bool {
+	fmt.Println("Welcome to the world of pokemon! Every trainer starts with a partner.")
+	generation := 1
+	for {
+		answer, ok := session.Prompt(fmt.Sprintf("Which generation's starters would you like? [1-%d, enter for 1]", len(starters)))
+		if !ok {
+			return false
+		}
+		if answer == "" {
+			break
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(starters) {
+			generation = n
+			break
+		}
+		fmt.Println("Please enter a number from 1 to", len(starters))
+	}
+
+	choices := starters[generation-1]
+	for i, choice := range choices {
+		fmt.Printf("%d. %s\n", i+1, choice)
+	}
+	name := ""
+	for {
+		answer, ok := session.Prompt("Who will be your partner?")
+		if !ok {
+			return false
+		}
+		if choice, ok := pickStarter(choices, answer); ok {
+			name = choice
+			break
+		}
+		fmt.Println("Choose one of:", strings.Join(choices, ", "))
+	}
+
+	pokemon, err := session.client.ResolvePokemon(name)
+	if err != nil {
+		fmt.Println("Couldn't reach the professor's lab:", err)
+		return false
+	}
+	caught := &CaughtPokemon{Pokemon: pokemon, Form: pokemon.FormName(), Level: starterLevel, IVs: rollIVs(), Happiness: defaultHappiness,
+		CaughtOn: time.Now().Format(dateLayout)}
+	session.pokedex.Add(caught)
+	fmt.Printf("You and %s are ready for an adventure!\n", pokemon.Name)
+
+	err = session.Record(HistoryEvent{Kind: "starter", Pokemon: pokemon.Name})
+	if err == nil {
+		err = session.Save()
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+	return true
+}
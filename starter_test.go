@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChooseStarter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pokemon/"), "/")
+		fmt.Fprintf(w, `{"id": 1, "name": %q, "is_default": true, "species": {"name": %q}}`, name, name)
+	}))
+	defer server.Close()
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL)
+
+	// a bad generation and a bad choice are asked again
+	input := "12\n4\nmew\n2\n"
+	session := &Session{client: client, pokedex: NewPokedex(), input: bufio.NewScanner(strings.NewReader(input))}
+	if !session.chooseStarter() {
+		t.Fatal("expected a starter")
+	}
+	if session.pokedex.Len() != 1 || session.pokedex.Pokemon[0].Name != "chimchar" || session.pokedex.Pokemon[0].Level != starterLevel {
+		t.Errorf("expected a level %d chimchar, got %+v", starterLevel, session.pokedex.Pokemon)
+	}
+
+	session = &Session{client: client, pokedex: NewPokedex(), input: bufio.NewScanner(strings.NewReader("\n"))}
+	if session.chooseStarter() || session.pokedex.Len() != 0 {
+		t.Errorf("expected no starter when input ends")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// stats [api] - usage statistics
+func statsCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter what to show stats for: api")
+		return nil
+	}
+
+	switch params[0] {
+	case "api":
+		return apiStatsCommand(session)
+	default:
+		return fmt.Errorf("unknown stats command %q", params[0])
+	}
+}
+
+// requests, bytes, cache hit ratio and latency per endpoint
+func apiStatsCommand(session *Session) error {
+	endpoints := session.client.metrics.Snapshot()
+	if len(endpoints) == 0 {
+		fmt.Println("No api calls yet")
+		return nil
+	}
+
+	var total EndpointMetrics
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ENDPOINT\tREQUESTS\tBYTES\tCACHE HITS\tHIT RATIO\tAVG LATENCY")
+	for _, endpoint := range endpoints {
+		printEndpointMetrics(table, endpoint)
+		total.Requests += endpoint.Requests
+		total.Bytes += endpoint.Bytes
+		total.CacheHits += endpoint.CacheHits
+		total.CacheMisses += endpoint.CacheMisses
+		total.Latency += endpoint.Latency
+	}
+	total.Endpoint = "total"
+	printEndpointMetrics(table, total)
+	return table.Flush()
+}
+
+func printEndpointMetrics(table *tabwriter.Writer, endpoint EndpointMetrics) {
+	ratio := "-"
+	lookups := endpoint.CacheHits + endpoint.CacheMisses
+	if lookups > 0 {
+		ratio = fmt.Sprintf("%.1f%%", 100*float64(endpoint.CacheHits)/float64(lookups))
+	}
+	fmt.Fprintf(table, "%s\t%d\t%d\t%d/%d\t%s\t%v\n", endpoint.Endpoint, endpoint.Requests, endpoint.Bytes,
+		endpoint.CacheHits, lookups, ratio, endpoint.AverageLatency().Round(time.Millisecond))
+}
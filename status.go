@@ -0,0 +1,161 @@
+package main
+
+import "fmt"
+
+// status conditions a battler can have, one at a time, named like the api's move ailments and the keys of statusCatchBonus
+const (
+	statusBurn      = "burn"
+	statusParalysis = "paralysis"
+	statusSleep     = "sleep"
+	statusPoison    = "poison"
+	statusFreeze    = "freeze"
+)
+
+// how a condition shows in the battle status line
+var statusAbbreviations = map[string]string{
+	statusBurn:      "BRN",
+	statusParalysis: "PAR",
+	statusSleep:     "SLP",
+	statusPoison:    "PSN",
+	statusFreeze:    "FRZ",
+}
+
+// the type that can't get each condition
+var statusImmunities = map[string][]string{
+	statusBurn:      {"fire"},
+	statusParalysis: {"electric"},
+	statusPoison:    {"poison", "steel"},
+	statusFreeze:    {"ice"},
+}
+
+// what a move's additional effects do, from the move's meta in the api
+type MoveMeta struct {
+	Ailment NamedResource `json:"ailment"`
+	// percent chance for damaging moves, 0 for status moves that always cause it
+	Ailment_chance int `json:"ailment_chance"`
+	// like "damage+raise" for damaging moves that raise the user's stats
+	Category NamedResource `json:"category"`
+	// percent chance of the stat changes for damaging moves
+	Stat_chance int `json:"stat_chance"`
+}
+
+// the status condition a move causes, "" for moves that don't cause one the engine knows, toxic counts as poison
+func (move Move) Condition() string {
+	if move.Meta == nil {
+		return ""
+	}
+	if _, ok := statusAbbreviations[move.Meta.Ailment.Name]; ok {
+		return move.Meta.Ailment.Name
+	}
+	return ""
+}
+
+// whether a battler's types keep it from getting a condition
+func statusImmune(battler *Battler, condition string) bool {
+	for _, immune := range statusImmunities[condition] {
+		for _, t := range battler.Types {
+			if t == immune {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// give a battler a condition, a battler that already has one, is immune or is kept safe by the terrain keeps what it has
+func (battle *Battle) inflict(battler *Battler, condition string, always bool) {
+	if battler.Fainted() || battler.Condition != "" || statusImmune(battler, condition) {
+		if always {
+			battle.emit(BattleEvent{Kind: "no-effect", Target: battler.Name, Message: fmt.Sprintf("It doesn't affect %s...", battler.Name)})
+		}
+		return
+	}
+	if message := battle.terrainProtects(battler, condition); message != "" {
+		if always {
+			battle.emit(BattleEvent{Kind: "no-effect", Target: battler.Name, Message: message})
+		}
+		return
+	}
+	messages := map[string]string{
+		statusBurn:      "%s was burned!",
+		statusParalysis: "%s is paralyzed! It may be unable to move!",
+		statusSleep:     "%s fell asleep!",
+		statusPoison:    "%s was poisoned!",
+		statusFreeze:    "%s was frozen solid!",
+	}
+	battler.Condition = condition
+	if condition == statusSleep {
+		battler.sleepTurns = 1 + battle.rand.Intn(3)
+	}
+	battle.emit(BattleEvent{Kind: "status", Target: battler.Name, Move: condition, Message: fmt.Sprintf(messages[condition], battler.Name)})
+}
+
+// the move's condition on its target: always for status moves, by chance for damaging ones
+func (battle *Battle) moveCondition(move *BattleMove, target *Battler) {
+	if move.Condition == "" {
+		return
+	}
+	always := move.Power == 0 || move.ConditionChance == 0
+	if always || battle.rand.Intn(100) < move.ConditionChance {
+		battle.inflict(target, move.Condition, move.Power == 0)
+	}
+}
+
+// whether a battler's condition lets it move this turn: sleep lasts a few turns, freeze thaws one time in five
+// and paralysis stops it one time in four
+func (battle *Battle) canAct(battler *Battler) bool {
+	switch battler.Condition {
+	case statusSleep:
+		if battler.sleepTurns > 0 {
+			battler.sleepTurns--
+			battle.emit(BattleEvent{Kind: "status", Actor: battler.Name, Move: statusSleep, Message: fmt.Sprintf("%s is fast asleep.", battler.Name)})
+			return false
+		}
+		battler.Condition = ""
+		battle.emit(BattleEvent{Kind: "status", Actor: battler.Name, Message: fmt.Sprintf("%s woke up!", battler.Name)})
+	case statusFreeze:
+		if battle.rand.Intn(5) != 0 {
+			battle.emit(BattleEvent{Kind: "status", Actor: battler.Name, Move: statusFreeze, Message: fmt.Sprintf("%s is frozen solid!", battler.Name)})
+			return false
+		}
+		battler.Condition = ""
+		battle.emit(BattleEvent{Kind: "status", Actor: battler.Name, Message: fmt.Sprintf("%s thawed out!", battler.Name)})
+	case statusParalysis:
+		if battle.rand.Intn(4) == 0 {
+			battle.emit(BattleEvent{Kind: "status", Actor: battler.Name, Move: statusParalysis, Message: fmt.Sprintf("%s is paralyzed! It can't move!", battler.Name)})
+			return false
+		}
+	}
+	return true
+}
+
+// burns take a sixteenth of max hp after every turn and poison an eighth
+func (battle *Battle) statusEndOfTurn() {
+	for _, side := range battle.Sides {
+		battler := side.Current()
+		if battler.Fainted() {
+			continue
+		}
+		switch battler.Condition {
+		case statusBurn:
+			battle.emit(BattleEvent{Kind: "status", Target: battler.Name, Move: statusBurn, Message: fmt.Sprintf("%s is hurt by its burn!", battler.Name)})
+			battle.hurt(side, battler, max1(battler.Stats["hp"]/16), statusBurn)
+		case statusPoison:
+			battle.emit(BattleEvent{Kind: "status", Target: battler.Name, Move: statusPoison, Message: fmt.Sprintf("%s is hurt by poison!", battler.Name)})
+			battle.hurt(side, battler, max1(battler.Stats["hp"]/8), statusPoison)
+		}
+		if battle.Over() {
+			return
+		}
+	}
+}
+
+// a ball thrown at a battler, its hp and condition make it easier to catch like in the games
+func (battler *Battler) CatchAttempt(captureRate int, ball Ball) CatchAttempt {
+	return CatchAttempt{
+		CaptureRate: captureRate,
+		Ball:        ball,
+		HPFraction:  float64(battler.HP) / float64(battler.Stats["hp"]),
+		Status:      battler.Condition,
+	}
+}
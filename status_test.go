@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestMoveCondition(t *testing.T) {
+	var toxic, growl Move
+	if err := json.Unmarshal([]byte(`{"name": "toxic", "pp": 10, "meta": {"ailment": {"name": "poison"}, "ailment_chance": 0}}`), &toxic); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"name": "growl", "pp": 40, "meta": {"ailment": {"name": "none"}}}`), &growl); err != nil {
+		t.Fatal(err)
+	}
+	if toxic.Condition() != statusPoison || growl.Condition() != "" || (Move{}).Condition() != "" {
+		t.Errorf("unexpected conditions %q, %q", toxic.Condition(), growl.Condition())
+	}
+}
+
+func TestStatusConditions(t *testing.T) {
+	thunderwave := &BattleMove{Name: "thunder-wave", Type: "electric", Class: "status", Accuracy: 100, PP: 20, MaxPP: 20, Condition: statusParalysis}
+	pikachu := testBattler("pikachu", 20, 90, thunderwave)
+	raichu := testBattler("raichu", 20, 100, tackle())
+	raichu.Types = []string{"electric"}
+	rattata := testBattler("rattata", 20, 70, tackle())
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "Gary", Team: []*Battler{raichu, rattata}}, false, rand.New(rand.NewSource(1)))
+
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if raichu.Condition != "" {
+		t.Errorf("expected an electric type not to be paralyzed, got %q", raichu.Condition)
+	}
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "switch", Switch: 1}})
+	if rattata.Condition != statusParalysis || rattata.Speed() != 35 || rattata.Status() != "rattata lv.20 HP 30/30 PAR" {
+		t.Errorf("expected rattata to be paralyzed at half speed, got %q", rattata.Status())
+	}
+
+	burned := testBattler("charmander", 20, 60, tackle())
+	burned.Condition = statusBurn
+	if burned.attackStat("physical") != 10 || burned.attackStat("special") != 20 {
+		t.Errorf("expected a burn to halve only attack, got %d and %d", burned.attackStat("physical"), burned.attackStat("special"))
+	}
+	asleep := testBattler("snorlax", 20, 30, tackle())
+	battle = NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{burned}}, &BattleSide{Name: "wild snorlax", Team: []*Battler{asleep}}, true, rand.New(rand.NewSource(1)))
+	battle.inflict(asleep, statusSleep, true)
+	turns := asleep.sleepTurns
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if asleep.Moves[0].PP != 35 || asleep.sleepTurns != turns-1 {
+		t.Errorf("expected snorlax to sleep through the turn, got %d pp and %d turns left", asleep.Moves[0].PP, asleep.sleepTurns)
+	}
+	// snorlax didn't attack, so the burn is all charmander lost
+	if burned.HP != 29 {
+		t.Errorf("expected the burn to take 1 hp after the turn, got %d", burned.HP)
+	}
+
+	attempt := asleep.CatchAttempt(45, balls["poke"])
+	if attempt.Status != statusSleep || attempt.Probability() <= burned.CatchAttempt(45, balls["poke"]).Probability() {
+		t.Errorf("expected a sleeping pokemon to be easier to catch, got %+v", attempt)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// whether the pokemon has a tag, tags ignore case
+func (caught *CaughtPokemon) HasTag(tag string) bool {
+	for _, t := range caught.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// fav [pokemon] - mark a caught pokemon as a favorite, or unmark it
+func favCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	caught.Favorite = !caught.Favorite
+	if caught.Favorite {
+		fmt.Println(caught.DisplayName(), "is now a favorite")
+	} else {
+		fmt.Println(caught.DisplayName(), "is no longer a favorite")
+	}
+	return session.Save()
+}
+
+// tag [pokemon] [tag]... - label a caught pokemon, tag with only a pokemon lists its tags
+func tagCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a pokemon and a tag")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	if len(params) == 1 {
+		if len(caught.Tags) == 0 {
+			fmt.Println(caught.DisplayName(), "has no tags")
+		} else {
+			fmt.Println(caught.DisplayName()+":", strings.Join(caught.Tags, ", "))
+		}
+		return nil
+	}
+
+	for _, tag := range params[1:] {
+		tag = strings.ToLower(tag)
+		if !caught.HasTag(tag) {
+			caught.Tags = append(caught.Tags, tag)
+		}
+	}
+	sort.Strings(caught.Tags)
+	fmt.Println(caught.DisplayName()+":", strings.Join(caught.Tags, ", "))
+	return session.Save()
+}
+
+// untag [pokemon] [tag]... - remove labels from a caught pokemon
+func untagCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) < 2 {
+		fmt.Println("Please enter a pokemon and a tag")
+		return nil
+	}
+
+	caught, err := session.pokedex.Find(params[0])
+	if err != nil {
+		return err
+	}
+	kept := []string{}
+	for _, tag := range caught.Tags {
+		remove := false
+		for _, param := range params[1:] {
+			if strings.EqualFold(tag, param) {
+				remove = true
+			}
+		}
+		if !remove {
+			kept = append(kept, tag)
+		}
+	}
+	caught.Tags = kept
+	if len(kept) == 0 {
+		fmt.Println(caught.DisplayName(), "has no tags")
+	} else {
+		fmt.Println(caught.DisplayName()+":", strings.Join(kept, ", "))
+	}
+	return session.Save()
+}
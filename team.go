@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// a named lineup of caught pokemon, saved for battles and exports
+type Team struct {
+	Name string `json:"name"`
+	// instance ids of the members in order
+	Members []int `json:"members"`
+}
+
+// the species a caught pokemon counts as for the species clause, forms of one species share it
+func (caught *CaughtPokemon) SpeciesName() string {
+	if caught.Species.Name != "" {
+		return caught.Species.Name
+	}
+	return caught.Name
+}
+
+// the team's members that are still in the pokedex, in team order
+func (team *Team) Pokemon(pokedex *Pokedex) []*CaughtPokemon {
+	members := []*CaughtPokemon{}
+	for _, id := range team.Members {
+		caught, err := pokedex.Find(fmt.Sprint(id))
+		if err == nil {
+			members = append(members, caught)
+		}
+	}
+	return members
+}
+
+// a team is at most a party's worth of pokemon with no species twice
+func validateTeam(members []*CaughtPokemon) error {
+	if len(members) > maxPartySize {
+		return fmt.Errorf("a team can't have more than %d pokemon", maxPartySize)
+	}
+	seen := make(map[string]*CaughtPokemon)
+	for _, caught := range members {
+		if other, ok := seen[caught.SpeciesName()]; ok {
+			if other == caught {
+				return fmt.Errorf("%s is already on the team", caught.DisplayName())
+			}
+			return fmt.Errorf("species clause: %s and %s are both %s", other.DisplayName(), caught.DisplayName(), caught.SpeciesName())
+		}
+		seen[caught.SpeciesName()] = caught
+	}
+	return nil
+}
+
+// add a pokemon to the end of the team if the team stays valid
+func (team *Team) Add(pokedex *Pokedex, caught *CaughtPokemon) error {
+	err := validateTeam(append(team.Pokemon(pokedex), caught))
+	if err != nil {
+		return err
+	}
+	team.Members = append(team.Members, caught.InstanceID)
+	return nil
+}
+
+// take a pokemon off the team, false if it wasn't on it
+func (team *Team) Remove(caught *CaughtPokemon) bool {
+	for i, id := range team.Members {
+		if id == caught.InstanceID {
+			team.Members = append(team.Members[:i], team.Members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// take a released pokemon off every team
+func (session *Session) leaveTeams(caught *CaughtPokemon) {
+	for _, team := range session.teams {
+		team.Remove(caught)
+	}
+}
+
+// the team called name, names ignore case
+func (session *Session) findTeam(name string) (*Team, error) {
+	team, ok := session.teams[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("you don't have a team called %s", name)
+	}
+	return team, nil
+}
+
+// the team's members as the party, everyone else goes to the pc
+func (session *Session) useTeam(team *Team) error {
+	members := team.Pokemon(session.pokedex)
+	if len(members) == 0 {
+		return fmt.Errorf("%s has no pokemon", team.Name)
+	}
+	party := []int{}
+	for _, caught := range members {
+		if caught.Dead {
+			return fmt.Errorf("%s fainted in your nuzlocke run and can't come back", caught.DisplayName())
+		}
+		if caught.Daycare {
+			return fmt.Errorf("%s is at the daycare, collect it first", caught.DisplayName())
+		}
+		party = append(party, caught.InstanceID)
+	}
+	session.pokedex.Party = party
+	return nil
+}
+
+func printTeam(team *Team, pokedex *Pokedex) {
+	members := team.Pokemon(pokedex)
+	fmt.Printf("%s (%d/%d):\n", team.Name, len(members), maxPartySize)
+	for i, caught := range members {
+		fmt.Printf("%d. %s\n", i+1, caughtSummary(caught))
+	}
+}
+
+// team list|show|create|delete|add|remove|use|export|import - build named teams from caught pokemon
+func teamCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string))
+	if len(params) == 0 {
+		params = []string{"list"}
+	}
+
+	switch params[0] {
+	case "list":
+		if len(session.teams) == 0 {
+			fmt.Println("You have no teams, make one with team create [name]")
+			return nil
+		}
+		names := []string{}
+		for name := range session.teams {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			team := session.teams[name]
+			fmt.Printf("- %s (%d pokemon)\n", team.Name, len(team.Pokemon(session.pokedex)))
+		}
+		return nil
+	case "import":
+		if len(params) < 2 {
+			fmt.Println("Please enter a file")
+			return nil
+		}
+		return session.importTeam(params[1], flags)
+	case "show", "create", "delete", "use", "export":
+		if len(params) < 2 {
+			fmt.Println("Please enter a team name")
+			return nil
+		}
+	case "add", "remove":
+		if len(params) < 3 {
+			fmt.Println("Please enter a team name and a pokemon")
+			return nil
+		}
+	default:
+		fmt.Println("Use team list, show, create, delete, add, remove, use, export or import")
+		return nil
+	}
+
+	name := params[1]
+	if params[0] == "create" {
+		if _, ok := session.teams[strings.ToLower(name)]; ok {
+			return fmt.Errorf("you already have a team called %s", name)
+		}
+		session.teams[strings.ToLower(name)] = &Team{Name: name, Members: []int{}}
+		fmt.Println("Created team", name)
+		return session.Save()
+	}
+
+	team, err := session.findTeam(name)
+	if err != nil {
+		return err
+	}
+	switch params[0] {
+	case "show":
+		printTeam(team, session.pokedex)
+		return nil
+	case "delete":
+		delete(session.teams, strings.ToLower(name))
+		fmt.Println("Deleted team", team.Name)
+		return session.Save()
+	case "export":
+		if len(team.Members) == 0 {
+			return fmt.Errorf("%s has no pokemon", team.Name)
+		}
+		return session.exportTeam(team, flags)
+	case "use":
+		err = session.useTeam(team)
+		if err != nil {
+			return err
+		}
+		fmt.Println(team.Name, "is now your party")
+		return session.Save()
+	}
+
+	caught, err := session.pokedex.Find(params[2])
+	if err != nil {
+		return err
+	}
+	if params[0] == "add" {
+		err = team.Add(session.pokedex, caught)
+		if err != nil {
+			return err
+		}
+		fmt.Println(caught.DisplayName(), "joined", team.Name)
+	} else {
+		if !team.Remove(caught) {
+			return fmt.Errorf("%s isn't on %s", caught.DisplayName(), team.Name)
+		}
+		fmt.Println(caught.DisplayName(), "left", team.Name)
+	}
+	return session.Save()
+}
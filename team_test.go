@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestTeamValidation(t *testing.T) {
+	pokedex := NewPokedex()
+	pikachu := &CaughtPokemon{Pokemon: Pokemon{Name: "pikachu", Species: NamedResource{Name: "pikachu"}}}
+	alolan := &CaughtPokemon{Pokemon: Pokemon{Name: "raichu-alola", Species: NamedResource{Name: "raichu"}}}
+	raichu := &CaughtPokemon{Pokemon: Pokemon{Name: "raichu", Species: NamedResource{Name: "raichu"}}}
+	for _, caught := range []*CaughtPokemon{pikachu, alolan, raichu} {
+		pokedex.Add(caught)
+	}
+
+	team := &Team{Name: "Sparks"}
+	if err := team.Add(pokedex, pikachu); err != nil {
+		t.Fatal(err)
+	}
+	if err := team.Add(pokedex, pikachu); err == nil {
+		t.Errorf("expected an error adding the same pokemon twice")
+	}
+	if err := team.Add(pokedex, alolan); err != nil {
+		t.Fatal(err)
+	}
+	// a different form of a species already on the team still breaks the species clause
+	if err := team.Add(pokedex, raichu); err == nil {
+		t.Errorf("expected a species clause error")
+	}
+
+	for i := 0; i < maxPartySize-2; i++ {
+		caught := &CaughtPokemon{Pokemon: Pokemon{Name: "filler", Species: NamedResource{Name: string(rune('a' + i))}}}
+		pokedex.Add(caught)
+		if err := team.Add(pokedex, caught); err != nil {
+			t.Fatal(err)
+		}
+	}
+	extra := &CaughtPokemon{Pokemon: Pokemon{Name: "mew", Species: NamedResource{Name: "mew"}}}
+	pokedex.Add(extra)
+	if err := team.Add(pokedex, extra); err == nil {
+		t.Errorf("expected an error adding a seventh pokemon")
+	}
+
+	// released pokemon drop out of the team
+	pokedex.Remove(alolan)
+	if members := team.Pokemon(pokedex); len(members) != maxPartySize-1 || members[0] != pikachu {
+		t.Errorf("expected the team without raichu-alola, got %d members", len(members))
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the terrain in a battle, "" for none, it only affects pokemon on the ground
+const (
+	terrainElectric = "electric"
+	terrainGrassy   = "grassy"
+	terrainMisty    = "misty"
+	terrainPsychic  = "psychic"
+)
+
+// turns terrain lasts once a move or ability starts it
+const terrainTurns = 5
+
+// moves that change the terrain instead of dealing damage
+var terrainMoves = map[string]string{
+	"electric-terrain": terrainElectric,
+	"grassy-terrain":   terrainGrassy,
+	"misty-terrain":    terrainMisty,
+	"psychic-terrain":  terrainPsychic,
+}
+
+// abilities that change the terrain when their pokemon comes in
+var terrainAbilities = map[string]string{
+	"electric-surge": terrainElectric,
+	"grassy-surge":   terrainGrassy,
+	"misty-surge":    terrainMisty,
+	"psychic-surge":  terrainPsychic,
+}
+
+// flying pokemon and ones that levitate are out of the terrain's reach
+func (battler *Battler) Grounded() bool {
+	if battler.Ability == "levitate" {
+		return false
+	}
+	for _, t := range battler.Types {
+		if t == "flying" {
+			return false
+		}
+	}
+	return true
+}
+
+// how the terrain changes the damage of a move: electric, grassy and psychic terrain boost their type's moves from
+// a grounded attacker, misty terrain halves dragon moves and grassy terrain earthquakes against a grounded target
+func terrainMultiplier(terrain string, move *BattleMove, attacker, defender *Battler) float64 {
+	boosted := map[string]string{terrainElectric: "electric", terrainGrassy: "grass", terrainPsychic: "psychic"}
+	switch {
+	case terrain == "":
+		return 1
+	case boosted[terrain] != "" && boosted[terrain] == move.Type && attacker.Grounded():
+		return 1.3
+	case terrain == terrainMisty && move.Type == "dragon" && defender.Grounded():
+		return 0.5
+	case terrain == terrainGrassy && (move.Name == "earthquake" || move.Name == "bulldoze" || move.Name == "magnitude") && defender.Grounded():
+		return 0.5
+	}
+	return 1
+}
+
+// the message when the terrain keeps a grounded battler from getting a condition, "" when it doesn't:
+// electric terrain keeps it awake and misty terrain keeps every condition away
+func (battle *Battle) terrainProtects(battler *Battler, condition string) string {
+	if !battler.Grounded() {
+		return ""
+	}
+	switch {
+	case battle.Terrain == terrainElectric && condition == statusSleep:
+		return fmt.Sprintf("%s surrounds itself with electrified terrain!", battler.Name)
+	case battle.Terrain == terrainMisty:
+		return fmt.Sprintf("%s surrounds itself with a protective mist!", battler.Name)
+	}
+	return ""
+}
+
+// psychic terrain stops priority moves aimed at a grounded pokemon
+func (battle *Battle) terrainBlocks(move *BattleMove, defender *Battler) bool {
+	return battle.Terrain == terrainPsychic && move.Priority > 0 && defender.Grounded()
+}
+
+// start a terrain for terrainTurns turns, replacing any other, it fails if that terrain is already down
+func (battle *Battle) setTerrain(terrain string, actor string) {
+	if battle.Terrain == terrain {
+		battle.emit(BattleEvent{Kind: "no-effect", Actor: actor, Message: "But nothing happened!"})
+		return
+	}
+	messages := map[string]string{
+		terrainElectric: "An electric current ran across the battlefield!",
+		terrainGrassy:   "Grass grew to cover the battlefield!",
+		terrainMisty:    "Mist swirled around the battlefield!",
+		terrainPsychic:  "The battlefield got weird!",
+	}
+	battle.Terrain = terrain
+	battle.TerrainTurns = terrainTurns
+	battle.emit(BattleEvent{Kind: "terrain", Actor: actor, Message: messages[terrain]})
+}
+
+// grassy terrain heals every grounded pokemon a sixteenth of its hp, then the terrain runs down a turn
+func (battle *Battle) terrainEndOfTurn() {
+	if battle.Terrain == "" {
+		return
+	}
+	if battle.Terrain == terrainGrassy {
+		for _, side := range battle.Sides {
+			battler := side.Current()
+			if battler.Fainted() || !battler.Grounded() {
+				continue
+			}
+			amount := max1(battler.Stats["hp"] / 16)
+			if amount > battler.Stats["hp"]-battler.HP {
+				amount = battler.Stats["hp"] - battler.HP
+			}
+			if amount <= 0 {
+				continue
+			}
+			battler.HP += amount
+			battle.emit(BattleEvent{Kind: "heal", Target: battler.Name, Move: "grassy-terrain", Damage: amount, HP: battler.HP,
+				Message: fmt.Sprintf("%s is healed by the grassy terrain (%d/%d HP)", battler.Name, battler.HP, battler.Stats["hp"])})
+		}
+	}
+	battle.TerrainTurns--
+	if battle.TerrainTurns > 0 {
+		return
+	}
+	messages := map[string]string{
+		terrainElectric: "The electricity disappeared from the battlefield.",
+		terrainGrassy:   "The grass disappeared from the battlefield.",
+		terrainMisty:    "The mist disappeared from the battlefield.",
+		terrainPsychic:  "The weirdness disappeared from the battlefield!",
+	}
+	battle.emit(BattleEvent{Kind: "terrain", Message: messages[battle.Terrain]})
+	battle.Terrain = ""
+}
+
+// "electric terrain (3 turns left)", "" without one
+func (battle *Battle) TerrainStatus() string {
+	if battle.Terrain == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s terrain (%d turns left)", battle.Terrain, battle.TerrainTurns)
+}
+
+// the weather and terrain together, like "rain (3 turns left), grassy terrain (2 turns left)", "" with neither
+func (battle *Battle) FieldStatus() string {
+	parts := []string{}
+	for _, status := range []string{battle.WeatherStatus(), battle.TerrainStatus()} {
+		if status != "" {
+			parts = append(parts, status)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTerrainDamage(t *testing.T) {
+	thunderbolt := &BattleMove{Name: "thunderbolt", Type: "electric", Class: "special", Power: 90, Accuracy: 100, PP: 15, MaxPP: 15}
+	dragonPulse := &BattleMove{Name: "dragon-pulse", Type: "dragon", Class: "special", Power: 85, Accuracy: 100, PP: 10, MaxPP: 10}
+	pikachu := testBattler("pikachu", 50, 90, thunderbolt)
+	zapdos := testBattler("zapdos", 50, 100, thunderbolt)
+	zapdos.Types = []string{"electric", "flying"}
+	target := testBattler("rattata", 50, 70)
+
+	clear := calculateDamage(pikachu, target, thunderbolt, nil, "", "", 100)
+	electric := calculateDamage(pikachu, target, thunderbolt, nil, "", terrainElectric, 100)
+	if electric.Terrain != 1.3 || electric.Damage <= clear.Damage {
+		t.Errorf("expected electric terrain to boost thunderbolt from %d, got %d (×%g)", clear.Damage, electric.Damage, electric.Terrain)
+	}
+	if calc := calculateDamage(zapdos, target, thunderbolt, nil, "", terrainElectric, 100); calc.Terrain != 1 {
+		t.Errorf("expected a flying attacker to get no boost, got ×%g", calc.Terrain)
+	}
+	if calc := calculateDamage(pikachu, target, thunderbolt, nil, "", terrainPsychic, 100); calc.Terrain != 1 {
+		t.Errorf("expected psychic terrain to leave thunderbolt alone, got ×%g", calc.Terrain)
+	}
+	if calc := calculateDamage(pikachu, target, dragonPulse, nil, "", terrainMisty, 100); calc.Terrain != 0.5 {
+		t.Errorf("expected misty terrain to halve dragon pulse, got ×%g", calc.Terrain)
+	}
+	if calc := calculateDamage(pikachu, zapdos, dragonPulse, nil, "", terrainMisty, 100); calc.Terrain != 1 {
+		t.Errorf("expected misty terrain not to protect a flying target, got ×%g", calc.Terrain)
+	}
+}
+
+func TestGrassyTerrain(t *testing.T) {
+	grassyTerrain := &BattleMove{Name: "grassy-terrain", Type: "grass", Class: "status", PP: 10, MaxPP: 10}
+	pikachu := testBattler("pikachu", 20, 90, grassyTerrain)
+	pikachu.Stats["hp"], pikachu.HP = 64, 40
+	pidgey := testBattler("pidgey", 20, 50, grassyTerrain)
+	pidgey.Types = []string{"normal", "flying"}
+	pidgey.Stats["hp"], pidgey.HP = 64, 50
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "Falkner", Team: []*Battler{pidgey}}, false, rand.New(rand.NewSource(1)))
+
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if battle.Terrain != terrainGrassy || battle.TerrainTurns != terrainTurns-1 {
+		t.Fatalf("expected grassy terrain with %d turns left, got %q with %d", terrainTurns-1, battle.Terrain, battle.TerrainTurns)
+	}
+	if pikachu.HP != 44 || pidgey.HP != 50 {
+		t.Errorf("expected the grass to heal only pikachu, got %d and %d hp", pikachu.HP, pidgey.HP)
+	}
+	for i := 1; i < terrainTurns; i++ {
+		battle.endOfTurn()
+	}
+	if battle.Terrain != "" || pikachu.HP != 60 {
+		t.Errorf("expected the grass to heal pikachu to 60 hp and go after %d turns, got %d hp and %s", terrainTurns, pikachu.HP, battle.TerrainStatus())
+	}
+}
+
+func TestTerrainKeepsConditionsAway(t *testing.T) {
+	pikachu := testBattler("pikachu", 20, 90, tackle())
+	pidgey := testBattler("pidgey", 20, 50, tackle())
+	pidgey.Types = []string{"normal", "flying"}
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "Falkner", Team: []*Battler{pidgey}}, false, rand.New(rand.NewSource(1)))
+
+	battle.Terrain = terrainElectric
+	battle.inflict(pikachu, statusSleep, true)
+	if pikachu.Condition != "" {
+		t.Errorf("expected electric terrain to keep pikachu awake, got %s", pikachu.Condition)
+	}
+	battle.inflict(pikachu, statusParalysis, true)
+	if pikachu.Condition != statusParalysis {
+		t.Errorf("expected electric terrain to allow paralysis, got %q", pikachu.Condition)
+	}
+
+	pikachu.Condition = ""
+	battle.Terrain = terrainMisty
+	battle.inflict(pikachu, statusBurn, true)
+	battle.inflict(pidgey, statusBurn, true)
+	if pikachu.Condition != "" || pidgey.Condition != statusBurn {
+		t.Errorf("expected misty terrain to protect only pikachu, got %q and %q", pikachu.Condition, pidgey.Condition)
+	}
+}
+
+func TestPsychicTerrainBlocksPriority(t *testing.T) {
+	quickAttack := &BattleMove{Name: "quick-attack", Type: "normal", Class: "physical", Power: 40, Accuracy: 100, PP: 30, MaxPP: 30, Priority: 1}
+	rattata := testBattler("rattata", 20, 70, quickAttack)
+	pikachu := testBattler("pikachu", 20, 90, tackle())
+	pidgey := testBattler("pidgey", 20, 50, tackle())
+	pidgey.Types = []string{"normal", "flying"}
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu, pidgey}}, &BattleSide{Name: "Joey", Team: []*Battler{rattata}}, false, rand.New(rand.NewSource(1)))
+	battle.Terrain = terrainPsychic
+
+	battle.useMove(opponentSide, rattata, quickAttack)
+	if pikachu.HP != pikachu.Stats["hp"] {
+		t.Errorf("expected psychic terrain to stop quick attack, pikachu has %d hp", pikachu.HP)
+	}
+	battle.Sides[playerSide].Active = 1
+	battle.useMove(opponentSide, rattata, quickAttack)
+	if pidgey.HP == pidgey.Stats["hp"] {
+		t.Errorf("expected quick attack to hit the flying pidgey")
+	}
+}
+
+func TestTerrainAbility(t *testing.T) {
+	tapuKoko := testBattler("tapu-koko", 50, 130, tackle())
+	tapuKoko.Ability = "electric-surge"
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{testBattler("pikachu", 20, 90, tackle())}}, &BattleSide{Name: "wild tapu-koko", Team: []*Battler{tapuKoko}}, true, rand.New(rand.NewSource(1)))
+	battle.Weather, battle.WeatherTurns = weatherRain, 3
+	battle.Start()
+	if battle.Terrain != terrainElectric || battle.FieldStatus() != "rain (3 turns left), electric terrain (5 turns left)" {
+		t.Errorf("expected electric surge to start electric terrain, got %q", battle.FieldStatus())
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// a tournament is a single elimination bracket of this many trainers, the player and ai trainers
+const (
+	tournamentSize     = 8
+	tournamentTeamSize = 3
+	// prize money for winning a round is this times the round
+	tournamentRoundPrize = 1000
+)
+
+// the names ai trainers enter under
+var tournamentNames = []string{"Hana", "Rex", "Juniper", "Milo", "Sasha", "Theo", "Ivy", "Bruno", "Nadia", "Felix", "Opal", "Cruz"}
+
+// each ai trainer fights with pokemon of one type
+var tournamentThemes = []string{"normal", "fire", "water", "grass", "electric", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy"}
+
+// someone in a tournament, the player has no theme or roster
+type TournamentTrainer struct {
+	Name   string       `json:"name"`
+	Theme  string       `json:"theme,omitempty"`
+	Roster []GymPokemon `json:"roster,omitempty"`
+}
+
+// "Hana (fire)"
+func (trainer TournamentTrainer) String() string {
+	if trainer.Theme == "" {
+		return trainer.Name
+	}
+	return fmt.Sprintf("%s (%s)", trainer.Name, trainer.Theme)
+}
+
+// a tournament in progress, kept in the profile between sessions
+type Tournament struct {
+	Date string `json:"date"`
+	// the player is always the first entrant
+	Entrants []TournamentTrainer `json:"entrants"`
+	// entrants still in by index, in bracket order: the first fights the second, the third the fourth and so on
+	Remaining []int `json:"remaining"`
+	Round     int   `json:"round"`
+	// a line for every match played
+	Results []string `json:"results"`
+	Prize   int      `json:"prize"`
+}
+
+// how a finished tournament went
+type TournamentResult struct {
+	Date      string `json:"date"`
+	RoundsWon int    `json:"rounds_won"`
+	Champion  bool   `json:"champion"`
+	Prize     int    `json:"prize"`
+}
+
+// ai trainers with a theme each and a team of pokemon of that type at level, the names are species that can be used
+func (session *Session) tournamentTrainers(count int, level int, random *rand.Rand) ([]TournamentTrainer, error) {
+	index, err := session.SpeciesIndex()
+	if err != nil {
+		return nil, err
+	}
+	species := make(map[string]bool)
+	for _, resource := range index.Species {
+		species[resource.Name] = true
+	}
+
+	trainers := []TournamentTrainer{}
+	names := random.Perm(len(tournamentNames))
+	for _, i := range random.Perm(len(tournamentThemes)) {
+		if len(trainers) == count {
+			break
+		}
+		theme := tournamentThemes[i]
+		typeInfo, err := session.client.GetType(theme)
+		if err != nil {
+			return nil, err
+		}
+		// forms like megas aren't species of their own, so only default forms with the type first make the team
+		candidates := []string{}
+		for _, pokemon := range typeInfo.Pokemon {
+			if pokemon.Slot == 1 && species[pokemon.Pokemon.Name] {
+				candidates = append(candidates, pokemon.Pokemon.Name)
+			}
+		}
+		if len(candidates) < tournamentTeamSize {
+			continue
+		}
+		trainer := TournamentTrainer{Name: tournamentNames[names[len(trainers)]], Theme: theme}
+		for _, j := range random.Perm(len(candidates))[:tournamentTeamSize] {
+			trainer.Roster = append(trainer.Roster, GymPokemon{Name: candidates[j], Level: level})
+		}
+		trainers = append(trainers, trainer)
+	}
+	if len(trainers) < count {
+		return nil, fmt.Errorf("couldn't find enough pokemon for %d trainers", count)
+	}
+	return trainers, nil
+}
+
+// a bracket of the player and the trainers in a random order
+func newTournament(player string, trainers []TournamentTrainer, date string, random *rand.Rand) *Tournament {
+	tournament := &Tournament{Date: date, Entrants: append([]TournamentTrainer{{Name: player}}, trainers...), Round: 1}
+	tournament.Remaining = random.Perm(len(tournament.Entrants))
+	return tournament
+}
+
+// the index of the entrant the player faces this round, false if the player is out or the tournament is over
+func (tournament *Tournament) Opponent() (int, bool) {
+	if tournament.Over() {
+		return 0, false
+	}
+	for i, entrant := range tournament.Remaining {
+		if entrant == 0 {
+			return tournament.Remaining[i^1], true
+		}
+	}
+	return 0, false
+}
+
+// whether the player is out or someone has won
+func (tournament *Tournament) Over() bool {
+	if len(tournament.Remaining) <= 1 {
+		return true
+	}
+	for _, entrant := range tournament.Remaining {
+		if entrant == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// whether the player won the whole tournament
+func (tournament *Tournament) Champion() bool {
+	return len(tournament.Remaining) == 1 && tournament.Remaining[0] == 0
+}
+
+// finish the round: the player's match went as playerWon and the ai trainers' matches are decided by random
+func (tournament *Tournament) advance(playerWon bool, random *rand.Rand) {
+	winners := []int{}
+	for i := 0; i+1 < len(tournament.Remaining); i += 2 {
+		first, second := tournament.Remaining[i], tournament.Remaining[i+1]
+		winner, loser := first, second
+		switch {
+		case second == 0 && playerWon, first == 0 && !playerWon:
+			winner, loser = second, first
+		case first != 0 && second != 0 && random.Intn(2) == 0:
+			winner, loser = second, first
+		}
+		winners = append(winners, winner)
+		tournament.Results = append(tournament.Results, fmt.Sprintf("Round %d: %s beat %s",
+			tournament.Round, tournament.Entrants[winner], tournament.Entrants[loser]))
+	}
+	tournament.Remaining = winners
+	tournament.Round++
+}
+
+func printTournament(tournament *Tournament) {
+	fmt.Printf("Tournament started %s, round %d\n", tournament.Date, tournament.Round)
+	for _, line := range tournament.Results {
+		fmt.Println(line)
+	}
+	if opponent, ok := tournament.Opponent(); ok {
+		fmt.Println("Next up:", tournament.Entrants[opponent])
+	}
+}
+
+// the best level in the party, ai trainers fight at it
+func tournamentLevel(party []*CaughtPokemon) int {
+	level := 5
+	for _, caught := range party {
+		if caught.Level > level {
+			level = caught.Level
+		}
+	}
+	return level
+}
+
+// put a finished tournament in the profile's results
+func (session *Session) endTournament() {
+	tournament := session.profile.Tournament
+	result := TournamentResult{Date: tournament.Date, RoundsWon: tournament.Round - 1, Champion: tournament.Champion(), Prize: tournament.Prize}
+	// the round is already counted when the player was knocked out in it
+	if tournament.Over() && !result.Champion {
+		result.RoundsWon--
+	}
+	session.profile.TournamentResults = append(session.profile.TournamentResults, result)
+	session.profile.Tournament = nil
+}
+
+// tournament [start|battle|quit|results] [--difficulty d] [--explain] [--log file] - fight through a bracket of ai trainers for prizes
+func tournamentCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params, flags := parseFlags(args[1].([]string), "explain")
+	profile := session.profile
+	if len(params) == 0 {
+		if profile.Tournament == nil {
+			fmt.Println("You're not in a tournament, enter one with tournament start")
+			return nil
+		}
+		printTournament(profile.Tournament)
+		return nil
+	}
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	switch params[0] {
+	case "start":
+		if profile.Tournament != nil {
+			return fmt.Errorf("you're already in a tournament, finish it or tournament quit")
+		}
+		party := session.pokedex.PartyMembers()
+		if len(party) == 0 {
+			return fmt.Errorf("you need pokemon in your party to enter")
+		}
+		trainers, err := session.tournamentTrainers(tournamentSize-1, tournamentLevel(party), random)
+		if err != nil {
+			return err
+		}
+		profile.Tournament = newTournament(profile.Name, trainers, time.Now().Format(dateLayout), random)
+		fmt.Printf("You entered a tournament of %d trainers!\n", tournamentSize)
+		printTournament(profile.Tournament)
+		return session.Save()
+	case "quit":
+		if profile.Tournament == nil {
+			return fmt.Errorf("you're not in a tournament")
+		}
+		session.endTournament()
+		fmt.Println("You left the tournament")
+		return session.Save()
+	case "results":
+		if len(profile.TournamentResults) == 0 {
+			fmt.Println("You haven't finished a tournament yet")
+			return nil
+		}
+		for _, result := range profile.TournamentResults {
+			outcome := fmt.Sprintf("won %d rounds", result.RoundsWon)
+			if result.Champion {
+				outcome = "champion"
+			}
+			fmt.Printf("- %s: %s, $%d\n", result.Date, outcome, result.Prize)
+		}
+		return nil
+	case "battle":
+		// fought below
+	default:
+		fmt.Println("Use tournament start, battle, quit or results")
+		return nil
+	}
+
+	tournament := profile.Tournament
+	if tournament == nil {
+		return fmt.Errorf("you're not in a tournament, enter one with tournament start")
+	}
+	opponentIndex, _ := tournament.Opponent()
+	opponent := tournament.Entrants[opponentIndex]
+	ai, err := battleAIFor(flags, session.config.Game.GymDifficulty)
+	if err != nil {
+		return err
+	}
+	log, err := session.openBattleLog(flags)
+	if err != nil {
+		return err
+	}
+	if log != nil {
+		defer log.Close()
+	}
+	player, err := session.playerSide()
+	if err != nil {
+		return err
+	}
+	side, err := session.client.rosterSide(opponent.Name, opponent.Roster)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Round %d: you against %s!\n", tournament.Round, opponent)
+	battle := NewBattle(player, side, false, random)
+	_, battle.Explain = flags["explain"]
+	err = session.runBattle(battle, ai, log)
+	if err != nil {
+		return err
+	}
+	won := battle.State == battleWon
+	if won {
+		prize := tournamentRoundPrize * tournament.Round
+		tournament.Prize += prize
+		session.inventory.Money += prize
+		fmt.Printf("You won round %d and $%d!\n", tournament.Round, prize)
+	}
+	tournament.advance(won, random)
+	switch {
+	case tournament.Champion():
+		fmt.Println("You won the tournament!")
+		session.endTournament()
+	case tournament.Over():
+		fmt.Printf("You're out of the tournament in round %d\n", tournament.Round-1)
+		session.endTournament()
+	default:
+		next, _ := tournament.Opponent()
+		fmt.Println("Next up:", tournament.Entrants[next])
+	}
+	return session.finishBattle(battle, opponent.Name)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestTournamentBracket(t *testing.T) {
+	trainers := []TournamentTrainer{}
+	for i := 1; i < tournamentSize; i++ {
+		trainers = append(trainers, TournamentTrainer{Name: tournamentNames[i], Theme: tournamentThemes[i]})
+	}
+	random := rand.New(rand.NewSource(1))
+	tournament := newTournament("Ash", trainers, "2024-03-01", random)
+	if len(tournament.Remaining) != tournamentSize || tournament.Over() {
+		t.Fatalf("expected a full bracket, got %v", tournament.Remaining)
+	}
+
+	for round := 1; round <= 3; round++ {
+		opponent, ok := tournament.Opponent()
+		if !ok || opponent == 0 {
+			t.Fatalf("expected an opponent in round %d, got %d", round, opponent)
+		}
+		tournament.advance(true, random)
+	}
+	if !tournament.Champion() || !tournament.Over() || len(tournament.Results) != tournamentSize-1 {
+		t.Errorf("expected the player to win after 3 rounds and 7 matches, got %v and %v", tournament.Remaining, tournament.Results)
+	}
+	if !strings.HasPrefix(tournament.Results[len(tournament.Results)-1], "Round 3: Ash beat ") {
+		t.Errorf("unexpected final %q", tournament.Results[len(tournament.Results)-1])
+	}
+
+	session := &Session{profile: NewProfile()}
+	session.profile.Tournament = tournament
+	session.endTournament()
+	if result := session.profile.TournamentResults[0]; !result.Champion || result.RoundsWon != 3 || session.profile.Tournament != nil {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// knocked out in the second round
+	session.profile.Tournament = newTournament("Ash", trainers, "2024-03-02", random)
+	session.profile.Tournament.advance(true, random)
+	session.profile.Tournament.advance(false, random)
+	if !session.profile.Tournament.Over() || session.profile.Tournament.Champion() {
+		t.Fatalf("expected the player to be out, got %v", session.profile.Tournament.Remaining)
+	}
+	session.endTournament()
+	if result := session.profile.TournamentResults[1]; result.Champion || result.RoundsWon != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
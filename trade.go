@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// how many trades are on offer each day
+const tradeOffersPerDay = 3
+
+// the people offering trades
+var traders = []string{"Lola", "Marcel", "Ian", "Sailor Dux", "Chikuwa", "Doris", "Mimien", "Kenya", "Spot", "Cezar"}
+
+// a trade an npc offers: the player's pokemon of one species for one of theirs
+type TradeOffer struct {
+	Trader string
+	Wants  string
+	Gives  string
+}
+
+// "Lola: trade your poliwhirl for my jynx"
+func (offer TradeOffer) String() string {
+	return fmt.Sprintf("%s: trade your %s for my %s", offer.Trader, offer.Wants, offer.Gives)
+}
+
+// the trades done so far on a day, by offer number
+type TradeLog struct {
+	Date string `json:"date"`
+	Done []int  `json:"done"`
+}
+
+// whether an offer was already taken today
+func (log TradeLog) Traded(date string, offer int) bool {
+	if log.Date != date {
+		return false
+	}
+	for _, done := range log.Done {
+		if done == offer {
+			return true
+		}
+	}
+	return false
+}
+
+func (log *TradeLog) Add(date string, offer int) {
+	if log.Date != date {
+		*log = TradeLog{Date: date}
+	}
+	log.Done = append(log.Done, offer)
+}
+
+// the offers for a day, the same all day and different the next
+// traders want species the player has when they have any, and give any species
+func tradeOffers(date string, owned []string, species []string) []TradeOffer {
+	if len(species) == 0 {
+		return nil
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(date))
+	random := rand.New(rand.NewSource(int64(hash.Sum64())))
+	wanted := owned
+	if len(wanted) == 0 {
+		wanted = species
+	}
+
+	offers := []TradeOffer{}
+	for _, i := range random.Perm(len(traders))[:tradeOffersPerDay] {
+		offer := TradeOffer{Trader: traders[i], Wants: wanted[random.Intn(len(wanted))]}
+		offer.Gives = species[random.Intn(len(species))]
+		for offer.Gives == offer.Wants && len(species) > 1 {
+			offer.Gives = species[random.Intn(len(species))]
+		}
+		offers = append(offers, offer)
+	}
+	return offers
+}
+
+// today's trade offers
+func (session *Session) TradeOffers(now time.Time) ([]TradeOffer, error) {
+	index, err := session.SpeciesIndex()
+	if err != nil {
+		return nil, err
+	}
+	all := []string{}
+	for _, species := range index.Species {
+		all = append(all, species.Name)
+	}
+	owned := []string{}
+	for name := range session.pokedex.SpeciesCaught() {
+		owned = append(owned, name)
+	}
+	// sorted so the same pokedex gets the same offers
+	sort.Strings(owned)
+	return tradeOffers(now.Format(dateLayout), owned, all), nil
+}
+
+// the species a pokemon evolves into when traded for another species, false if trading doesn't evolve it
+// evolutions that need a held item are left out since pokemon don't hold items
+func tradeEvolution(link ChainLink, tradedFor string) (string, bool) {
+	for _, next := range link.Evolves_to {
+		for _, detail := range next.Evolution_details {
+			if detail.Trigger.Name != "trade" || detail.Held_item != nil {
+				continue
+			}
+			if detail.Trade_species == nil || detail.Trade_species.Name == tradedFor {
+				return next.Species.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// the player's pokemon that can go in a trade for a species, false if there are none
+func (session *Session) tradeable(species string) (*CaughtPokemon, bool) {
+	for _, caught := range session.pokedex.Pokemon {
+		if caught.SpeciesName() == species && !caught.Dead && !caught.Daycare {
+			return caught, true
+		}
+	}
+	return nil, false
+}
+
+// hand over a caught pokemon for a new one from a trader, the new one evolves if trading makes it
+func (session *Session) trade(offer TradeOffer, traded *CaughtPokemon) error {
+	client := session.client
+	pokemon, err := client.ResolvePokemon(offer.Gives)
+	if err != nil {
+		return err
+	}
+	species, err := client.GetSpecies(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	received := &CaughtPokemon{
+		Pokemon:   pokemon,
+		Form:      pokemon.FormName(),
+		Level:     traded.Level,
+		IVs:       rollIVs(),
+		Happiness: species.StartingHappiness(),
+		CaughtOn:  time.Now().Format(dateLayout),
+	}
+
+	session.pokedex.Remove(traded)
+	session.leaveTeams(traded)
+	fmt.Printf("You traded %s to %s for %s!\n", traded.DisplayName(), offer.Trader, pokemon.Name)
+	if !session.pokedex.Add(received) {
+		fmt.Println("Your party is full,", pokemon.Name, "was sent to the pc")
+	}
+	session.events.Publish(GameEvent{Kind: "trade", Caught: received})
+	err = session.Record(HistoryEvent{Kind: "trade", Pokemon: traded.Name, Outcome: pokemon.Name})
+	if err != nil {
+		return err
+	}
+
+	chain, err := client.GetEvolutionChain(species)
+	if err != nil {
+		// pokemon without a chain don't evolve
+		return nil
+	}
+	if link, ok := chain.Chain.Find(species.Name); ok {
+		if into, ok := tradeEvolution(link, traded.SpeciesName()); ok {
+			fmt.Println("What? Trading made", pokemon.Name, "evolve!")
+			return session.evolveInto(received, into)
+		}
+	}
+	return nil
+}
+
+// trade [n] [pokemon] - see today's trade offers or take one, giving the pokemon with that id or name
+func tradeCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	now := time.Now()
+	today := now.Format(dateLayout)
+	log := &session.profile.Trades
+
+	offers, err := session.TradeOffers(now)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		fmt.Println("Today's trade offers:")
+		for i, offer := range offers {
+			line := fmt.Sprintf("%d. %s", i+1, offer)
+			if log.Traded(today, i) {
+				line += " (done)"
+			}
+			fmt.Println(line)
+		}
+		fmt.Println("Take one with trade [n], new offers come tomorrow")
+		return nil
+	}
+
+	if session.profile.Nuzlocke.Active() {
+		return fmt.Errorf("trading isn't allowed during a nuzlocke run")
+	}
+	n, err := strconv.Atoi(params[0])
+	if err != nil || n < 1 || n > len(offers) {
+		return fmt.Errorf("choose an offer from 1 to %d", len(offers))
+	}
+	offer := offers[n-1]
+	if log.Traded(today, n-1) {
+		return fmt.Errorf("%s already traded with you today", offer.Trader)
+	}
+
+	var traded *CaughtPokemon
+	if len(params) > 1 {
+		traded, err = session.pokedex.Find(params[1])
+		if err != nil {
+			return err
+		}
+		if traded.SpeciesName() != offer.Wants {
+			return fmt.Errorf("%s wants a %s, not a %s", offer.Trader, offer.Wants, traded.SpeciesName())
+		}
+		if traded.Dead || traded.Daycare {
+			return fmt.Errorf("%s can't be traded right now", traded.DisplayName())
+		}
+	} else {
+		var ok bool
+		traded, ok = session.tradeable(offer.Wants)
+		if !ok {
+			return fmt.Errorf("you don't have a %s to trade", offer.Wants)
+		}
+	}
+
+	if !session.Confirm(fmt.Sprintf("Trade %s (#%d, level %d) for %s's %s?", traded.DisplayName(), traded.InstanceID, traded.Level, offer.Trader, offer.Gives)) {
+		fmt.Println("Maybe another time")
+		return nil
+	}
+	log.Add(today, n-1)
+	err = session.trade(offer, traded)
+	if err != nil {
+		return err
+	}
+	return session.Save()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTradeOffers(t *testing.T) {
+	species := []string{"bulbasaur", "charmander", "squirtle", "pidgey", "rattata", "jynx"}
+	owned := []string{"pidgey", "rattata"}
+	offers := tradeOffers("2024-03-01", owned, species)
+	if len(offers) != tradeOffersPerDay {
+		t.Fatalf("expected %d offers, got %d", tradeOffersPerDay, len(offers))
+	}
+	for _, offer := range offers {
+		if offer.Wants != "pidgey" && offer.Wants != "rattata" {
+			t.Errorf("expected offers for owned species, got %s", offer)
+		}
+		if offer.Gives == offer.Wants {
+			t.Errorf("expected a different species in return, got %s", offer)
+		}
+	}
+	if again := tradeOffers("2024-03-01", owned, species); !reflect.DeepEqual(offers, again) {
+		t.Errorf("expected the same offers all day, got %v and %v", offers, again)
+	}
+}
+
+func TestTradeEvolution(t *testing.T) {
+	var chains []ChainLink
+	err := json.Unmarshal([]byte(`[
+		{"species": {"name": "kadabra"}, "evolves_to": [{"species": {"name": "alakazam"}, "evolution_details": [{"trigger": {"name": "trade"}}]}]},
+		{"species": {"name": "karrablast"}, "evolves_to": [{"species": {"name": "escavalier"}, "evolution_details": [{"trigger": {"name": "trade"}, "trade_species": {"name": "shelmet"}}]}]},
+		{"species": {"name": "onix"}, "evolves_to": [{"species": {"name": "steelix"}, "evolution_details": [{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}]}]}
+	]`), &chains)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if into, ok := tradeEvolution(chains[0], "pidgey"); !ok || into != "alakazam" {
+		t.Errorf("expected kadabra to become alakazam, got %q", into)
+	}
+	if _, ok := tradeEvolution(chains[1], "pidgey"); ok {
+		t.Errorf("expected karrablast to need a shelmet")
+	}
+	if into, ok := tradeEvolution(chains[1], "shelmet"); !ok || into != "escavalier" {
+		t.Errorf("expected karrablast to become escavalier, got %q", into)
+	}
+	if _, ok := tradeEvolution(chains[2], "pidgey"); ok {
+		t.Errorf("expected onix to need a held item")
+	}
+}
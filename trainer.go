@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// who is playing and what they've done, kept in the save file
+type Profile struct {
+	Name      string    `json:"name"`
+	StartedAt time.Time `json:"started_at"`
+	// every ball thrown counts as an attempt
+	CatchAttempts int `json:"catch_attempts"`
+	Catches       int `json:"catches"`
+	// map and mapb pages viewed
+	PagesWalked int        `json:"pages_walked"`
+	Chain       CatchChain `json:"chain"`
+	// the last day a session started, and how many days in a row before it
+	LastPlayed string `json:"last_played"`
+	Streak     int    `json:"streak"`
+	// progress towards each achievement by id
+	Achievements map[string]*AchievementState `json:"achievements"`
+	// regions with every pokemon caught, already celebrated
+	CompletedRegions []string `json:"completed_regions"`
+	Nuzlocke         Nuzlocke `json:"nuzlocke"`
+	// gym badges in the order they were won
+	Badges  []string `json:"badges"`
+	Daycare Daycare  `json:"daycare"`
+	// npc trades taken today
+	Trades TradeLog `json:"trades"`
+	// the tournament in progress, nil outside one, and how the finished ones went
+	Tournament        *Tournament        `json:"tournament,omitempty"`
+	TournamentResults []TournamentResult `json:"tournament_results"`
+	// elo rating from pvp battles, and the players battled by name
+	Rating    int                       `json:"rating"`
+	Opponents map[string]*RatedOpponent `json:"opponents"`
+}
+
+// a profile for someone starting today
+func NewProfile() *Profile {
+	return &Profile{Name: "Trainer", StartedAt: time.Now(), Rating: startingRating}
+}
+
+// fraction of attempts that caught something, 0 before the first throw
+func (profile *Profile) SuccessRate() float64 {
+	if profile.CatchAttempts == 0 {
+		return 0
+	}
+	return float64(profile.Catches) / float64(profile.CatchAttempts)
+}
+
+// the type most of the pokedex has, ties go to the alphabetically first type, "" for an empty pokedex
+func favoriteType(pokedex *Pokedex) string {
+	counts := map[string]int{}
+	for _, caught := range pokedex.Pokemon {
+		for _, pokemonType := range caught.Types {
+			counts[pokemonType.Type.Name]++
+		}
+	}
+	types := []string{}
+	for name := range counts {
+		types = append(types, name)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	if len(types) == 0 {
+		return ""
+	}
+	return types[0]
+}
+
+// trainer [name new name] - show your trainer card, or rename yourself
+func trainerCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	profile := session.profile
+
+	if len(params) > 0 {
+		if params[0] != "name" || len(params) < 2 {
+			fmt.Println("Use trainer name [name] to change your name")
+			return nil
+		}
+		profile.Name = strings.Join(params[1:], " ")
+		fmt.Println("Your name is now", profile.Name)
+		return session.Save()
+	}
+
+	fmt.Println("Trainer:", profile.Name)
+	fmt.Println("Started:", profile.StartedAt.Format(dateLayout))
+	fmt.Println("Pokemon caught:", session.pokedex.Len())
+	fmt.Printf("Catch attempts: %d, successes: %d (%.0f%%)\n", profile.CatchAttempts, profile.Catches, 100*profile.SuccessRate())
+	if favorite := favoriteType(session.pokedex); favorite != "" {
+		fmt.Println("Favorite type:", favorite)
+	}
+	fmt.Println("Distance walked:", profile.PagesWalked, "map pages")
+	if len(profile.Badges) > 0 {
+		fmt.Printf("Badges (%d): %s\n", len(profile.Badges), strings.Join(profile.Badges, ", "))
+	}
+	if profile.Streak > 1 {
+		fmt.Println("Daily streak:", profile.Streak, "days")
+	}
+	if profile.Chain.Length > 1 {
+		fmt.Printf("Catch chain: %d %s in a row\n", profile.Chain.Length, profile.Chain.Species)
+	}
+	return nil
+}
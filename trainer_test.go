@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFavoriteType(t *testing.T) {
+	pokedex := NewPokedex()
+	for name, types := range map[string]string{
+		"squirtle": `[{"type": {"name": "water"}}]`,
+		"pelipper": `[{"type": {"name": "water"}}, {"type": {"name": "flying"}}]`,
+		"pidgey":   `[{"type": {"name": "normal"}}, {"type": {"name": "flying"}}]`,
+		"psyduck":  `[{"type": {"name": "water"}}]`,
+	} {
+		caught := &CaughtPokemon{}
+		err := json.Unmarshal([]byte(`{"name": "`+name+`", "types": `+types+`}`), &caught.Pokemon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pokedex.Add(caught)
+	}
+	if favorite := favoriteType(pokedex); favorite != "water" {
+		t.Errorf("expected water, got %v", favorite)
+	}
+	if favorite := favoriteType(NewPokedex()); favorite != "" {
+		t.Errorf("expected no favorite for an empty pokedex, got %v", favorite)
+	}
+}
+
+func TestSuccessRate(t *testing.T) {
+	profile := NewProfile()
+	if profile.SuccessRate() != 0 {
+		t.Errorf("expected 0 before any throws")
+	}
+	profile.CatchAttempts, profile.Catches = 4, 1
+	if profile.SuccessRate() != 0.25 {
+		t.Errorf("expected 0.25, got %v", profile.SuccessRate())
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type TypeInfo struct {
+	Id               int             `json:"id"`
+	Name             string          `json:"name"`
+	Names            []LocalizedName `json:"names"`
+	Damage_relations struct {
+		Double_damage_from []NamedResource `json:"double_damage_from"`
+		Double_damage_to   []NamedResource `json:"double_damage_to"`
+		Half_damage_from   []NamedResource `json:"half_damage_from"`
+		Half_damage_to     []NamedResource `json:"half_damage_to"`
+		No_damage_from     []NamedResource `json:"no_damage_from"`
+		No_damage_to       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	Pokemon []struct {
+		Slot    int           `json:"slot"`
+		Pokemon NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// how many pokemon the type command lists
+const notablePokemonCount = 10
+
+// fetch a type by name
+func (client *Client) GetType(name string) (TypeInfo, error) {
+	var typeInfo TypeInfo
+	err := client.GetJSON(client.ResourceURL("type", name), &typeInfo)
+	return typeInfo, err
+}
+
+// show a type's matchups when attacking and defending, and some pokemon of that type
+func typeCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+	if len(params) == 0 {
+		fmt.Println("Please enter a type")
+		return nil
+	}
+
+	typeInfo, err := session.client.GetType(apiName(params))
+	if err != nil {
+		return err
+	}
+	relations := typeInfo.Damage_relations
+
+	fmt.Println("Type:", englishName(typeInfo.Names, typeInfo.Name))
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "\tMATCHUP\tTYPES")
+	fmt.Fprintf(table, "Attacking\tsuper effective (2x)\t%s\n", typeNames(relations.Double_damage_to))
+	fmt.Fprintf(table, "\tnot very effective (0.5x)\t%s\n", typeNames(relations.Half_damage_to))
+	fmt.Fprintf(table, "\tno effect (0x)\t%s\n", typeNames(relations.No_damage_to))
+	fmt.Fprintf(table, "Defending\tweak to (2x)\t%s\n", typeNames(relations.Double_damage_from))
+	fmt.Fprintf(table, "\tresists (0.5x)\t%s\n", typeNames(relations.Half_damage_from))
+	fmt.Fprintf(table, "\timmune to (0x)\t%s\n", typeNames(relations.No_damage_from))
+	err = table.Flush()
+	if err != nil {
+		return err
+	}
+
+	// pokemon with this as their primary type are the most representative
+	notable := []string{}
+	for _, pokemon := range typeInfo.Pokemon {
+		if pokemon.Slot == 1 && len(notable) < notablePokemonCount {
+			notable = append(notable, pokemon.Pokemon.Name)
+		}
+	}
+	fmt.Printf("Notable pokemon (%d with this type in total):\n", len(typeInfo.Pokemon))
+	for _, name := range notable {
+		fmt.Println("-", name)
+	}
+	return nil
+}
+
+// comma separated names, or "-" for none
+func typeNames(types []NamedResource) string {
+	if len(types) == 0 {
+		return "-"
+	}
+	names := []string{}
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// walks every location area into the cache in the background so map and explore are instant
+type Warmer struct {
+	client   *Client
+	jobs     *JobManager
+	pageSize int
+	// time between network requests, so warming doesn't hammer the api
+	interval time.Duration
+
+	mutex    sync.Mutex
+	running  bool
+	pages    int
+	areas    int
+	finished time.Time
+	err      error
+}
+
+// time between warm-up requests when none is set, ticking needs a positive interval
+const defaultWarmInterval = 250 * time.Millisecond
+
+func NewWarmer(client *Client, jobs *JobManager, pageSize int, interval time.Duration) *Warmer {
+	if interval <= 0 {
+		interval = defaultWarmInterval
+	}
+	return &Warmer{
+		client:   client,
+		jobs:     jobs,
+		pageSize: pageSize,
+		interval: interval,
+	}
+}
+
+// start warming in the background, returns false if a warm-up is already running
+func (warmer *Warmer) Start() bool {
+	warmer.mutex.Lock()
+	defer warmer.mutex.Unlock()
+	if warmer.running {
+		return false
+	}
+	warmer.running = true
+	warmer.pages = 0
+	warmer.areas = 0
+	warmer.err = nil
+
+	started := warmer.jobs.Go(func(ctx context.Context) {
+		err := warmer.run(ctx)
+		warmer.mutex.Lock()
+		warmer.running = false
+		warmer.finished = time.Now()
+		warmer.err = err
+		warmer.mutex.Unlock()
+	})
+	if !started {
+		warmer.running = false
+	}
+	return started
+}
+
+func (warmer *Warmer) run(ctx context.Context) error {
+	ticker := time.NewTicker(warmer.interval)
+	defer ticker.Stop()
+
+	// only requests that actually go to the network wait for the rate limiter
+	get := func(url string, v interface{}) error {
+		if !warmer.client.Cached(url) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		body, err := warmer.client.GetContext(ctx, url)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return nil
+		}
+		_, err = decodeJSON(url, body, v)
+		return err
+	}
+
+	url := warmer.client.ListURL("location-area", 0, warmer.pageSize)
+	for url != "" {
+		var page LocationAreas
+		err := get(url, &page)
+		if err != nil {
+			return err
+		}
+		warmer.mutex.Lock()
+		warmer.pages++
+		warmer.mutex.Unlock()
+
+		for _, area := range page.Results {
+			err := get(area.Url, nil)
+			if err != nil {
+				return err
+			}
+			warmer.mutex.Lock()
+			warmer.areas++
+			warmer.mutex.Unlock()
+		}
+		url = page.Next
+	}
+	return nil
+}
+
+// one line describing what the warmer is doing or last did
+func (warmer *Warmer) Status() string {
+	warmer.mutex.Lock()
+	defer warmer.mutex.Unlock()
+	switch {
+	case warmer.running:
+		return fmt.Sprintf("Warming: %d pages, %d location areas cached so far", warmer.pages, warmer.areas)
+	case warmer.finished.IsZero():
+		return "The cache has not been warmed this session"
+	case warmer.err != nil:
+		return fmt.Sprintf("Warm-up stopped after %d location areas: %v", warmer.areas, warmer.err)
+	default:
+		return fmt.Sprintf("Warm-up finished %v ago: %d pages, %d location areas", time.Since(warmer.finished).Round(time.Second), warmer.pages, warmer.areas)
+	}
+}
+
+// warm [status] - fetch every location area into the cache in the background
+func warmCommand(args ...interface{}) error {
+	session := args[0].(*Session)
+	params := args[1].([]string)
+
+	if len(params) > 0 && params[0] == "status" {
+		fmt.Println(session.warmer.Status())
+		return nil
+	}
+	if !session.warmer.Start() {
+		fmt.Println(session.warmer.Status())
+		return nil
+	}
+	fmt.Println("Warming the cache in the background, use 'warm status' to check on it")
+	return nil
+}
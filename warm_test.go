@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWarmerWalksEveryPage(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base := server.URL + "/api/v2/location-area/"
+		if r.URL.Query().Get("offset") == "0" {
+			fmt.Fprintf(w, `{"next": "%s?offset=1&limit=1", "results": [{"name": "a", "url": "%sa/"}]}`, base, base)
+			return
+		}
+		if r.URL.Query().Get("offset") == "1" {
+			fmt.Fprintf(w, `{"next": null, "results": [{"name": "b", "url": "%sb/"}]}`, base)
+			return
+		}
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/location-area/"), "/")
+		fmt.Fprintf(w, `{"id": 1, "name": "%s"}`, name)
+	}))
+	defer server.Close()
+
+	jobs := NewJobManager()
+	defer jobs.Shutdown(time.Second)
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL + "/api/v2")
+
+	warmer := NewWarmer(client, jobs, 1, time.Millisecond)
+	if !warmer.Start() {
+		t.Fatal("expected the warm-up to start")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for strings.HasPrefix(warmer.Status(), "Warming") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if !strings.Contains(warmer.Status(), "2 pages, 2 location areas") {
+		t.Errorf("unexpected status %q", warmer.Status())
+	}
+	if !client.Cached(client.ResourceURL("location-area", "b")) {
+		t.Errorf("expected location area b to be cached")
+	}
+}
+
+func TestWarmerZeroInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"next": null, "results": []}`)
+	}))
+	defer server.Close()
+
+	jobs := NewJobManager()
+	defer jobs.Shutdown(time.Second)
+	client := NewClient(NewCache(time.Minute))
+	client.SetBaseURL(server.URL + "/api/v2")
+
+	// a warm_interval of "0s" in the config falls back to the default instead of panicking the ticker
+	warmer := NewWarmer(client, jobs, 20, 0)
+	if warmer.interval != defaultWarmInterval {
+		t.Errorf("expected the default interval, got %v", warmer.interval)
+	}
+	if !warmer.Start() {
+		t.Fatal("expected the warm-up to start")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for strings.HasPrefix(warmer.Status(), "Warming") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(warmer.Status(), "1 pages, 0 location areas") {
+		t.Errorf("unexpected status %q", warmer.Status())
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import "fmt"
+
+// the weather in a battle, "" for clear skies
+const (
+	weatherRain      = "rain"
+	weatherSun       = "sun"
+	weatherSandstorm = "sandstorm"
+)
+
+// turns weather lasts once a move or ability starts it
+const weatherTurns = 5
+
+// moves that change the weather instead of dealing damage
+var weatherMoves = map[string]string{
+	"rain-dance": weatherRain,
+	"sunny-day":  weatherSun,
+	"sandstorm":  weatherSandstorm,
+}
+
+// abilities that change the weather when their pokemon comes in
+var weatherAbilities = map[string]string{
+	"drizzle":     weatherRain,
+	"drought":     weatherSun,
+	"sand-stream": weatherSandstorm,
+}
+
+// how the weather changes the damage of a move type: rain favors water over fire and sun the other way round
+func weatherMultiplier(weather string, moveType string) float64 {
+	switch {
+	case weather == weatherRain && moveType == "water", weather == weatherSun && moveType == "fire":
+		return 1.5
+	case weather == weatherRain && moveType == "fire", weather == weatherSun && moveType == "water":
+		return 0.5
+	}
+	return 1
+}
+
+// rock, ground and steel pokemon don't mind a sandstorm
+func sandstormImmune(battler *Battler) bool {
+	for _, t := range battler.Types {
+		if t == "rock" || t == "ground" || t == "steel" {
+			return true
+		}
+	}
+	return false
+}
+
+// start a weather for weatherTurns turns, it fails if that weather is already going
+func (battle *Battle) setWeather(weather string, actor string) {
+	if battle.Weather == weather {
+		battle.emit(BattleEvent{Kind: "no-effect", Actor: actor, Message: "But nothing happened!"})
+		return
+	}
+	messages := map[string]string{
+		weatherRain:      "It started to rain!",
+		weatherSun:       "The sunlight turned harsh!",
+		weatherSandstorm: "A sandstorm kicked up!",
+	}
+	battle.Weather = weather
+	battle.WeatherTurns = weatherTurns
+	battle.emit(BattleEvent{Kind: "weather", Actor: actor, Message: messages[weather]})
+}
+
+// a battler coming in with a weather or terrain ability sets the weather or terrain
+func (battle *Battle) enter(battler *Battler) {
+	if weather, ok := weatherAbilities[battler.Ability]; ok && battle.Weather != weather {
+		battle.emit(BattleEvent{Kind: "ability", Actor: battler.Name, Move: battler.Ability,
+			Message: fmt.Sprintf("%s's %s!", battler.Name, battler.Ability)})
+		battle.setWeather(weather, battler.Name)
+	}
+	if terrain, ok := terrainAbilities[battler.Ability]; ok && battle.Terrain != terrain {
+		battle.emit(BattleEvent{Kind: "ability", Actor: battler.Name, Move: battler.Ability,
+			Message: fmt.Sprintf("%s's %s!", battler.Name, battler.Ability)})
+		battle.setTerrain(terrain, battler.Name)
+	}
+}
+
+// a sandstorm hurts everyone it can, then the weather runs down a turn
+func (battle *Battle) weatherEndOfTurn() {
+	if battle.Weather == "" {
+		return
+	}
+	if battle.Weather == weatherSandstorm {
+		for _, side := range battle.Sides {
+			battler := side.Current()
+			if battler.Fainted() || sandstormImmune(battler) {
+				continue
+			}
+			battle.emit(BattleEvent{Kind: "weather", Target: battler.Name, Message: fmt.Sprintf("%s is buffeted by the sandstorm!", battler.Name)})
+			battle.hurt(side, battler, max1(battler.Stats["hp"]/16), weatherSandstorm)
+			if battle.Over() {
+				return
+			}
+		}
+	}
+	battle.WeatherTurns--
+	if battle.WeatherTurns > 0 {
+		return
+	}
+	messages := map[string]string{
+		weatherRain:      "The rain stopped.",
+		weatherSun:       "The harsh sunlight faded.",
+		weatherSandstorm: "The sandstorm subsided.",
+	}
+	battle.emit(BattleEvent{Kind: "weather", Message: messages[battle.Weather]})
+	battle.Weather = ""
+}
+
+// "rain (3 turns left)", "" for clear skies
+func (battle *Battle) WeatherStatus() string {
+	if battle.Weather == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d turns left)", battle.Weather, battle.WeatherTurns)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWeatherDamage(t *testing.T) {
+	watergun := &BattleMove{Name: "water-gun", Type: "water", Class: "special", Power: 40, Accuracy: 100, PP: 25, MaxPP: 25}
+	squirtle := testBattler("squirtle", 20, 40, watergun)
+	target := testBattler("rattata", 20, 40)
+
+	clear := calculateDamage(squirtle, target, watergun, nil, "", "", 100)
+	rain := calculateDamage(squirtle, target, watergun, nil, weatherRain, "", 100)
+	sun := calculateDamage(squirtle, target, watergun, nil, weatherSun, "", 100)
+	if rain.Damage != clear.Damage*3/2 || sun.Damage != clear.Damage/2 {
+		t.Errorf("expected rain to boost and sun to weaken water gun from %d, got %d and %d", clear.Damage, rain.Damage, sun.Damage)
+	}
+}
+
+func TestSandstorm(t *testing.T) {
+	sandstorm := &BattleMove{Name: "sandstorm", Type: "rock", Class: "status", PP: 10, MaxPP: 10}
+	pikachu := testBattler("pikachu", 20, 90, sandstorm)
+	pikachu.Stats["hp"], pikachu.HP = 64, 64
+	geodude := testBattler("geodude", 20, 20, sandstorm)
+	geodude.Types = []string{"rock", "ground"}
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{pikachu}}, &BattleSide{Name: "Brock", Team: []*Battler{geodude}}, false, rand.New(rand.NewSource(1)))
+
+	battle.Step([2]BattleAction{{Kind: "move"}, {Kind: "move"}})
+	if battle.Weather != weatherSandstorm || battle.WeatherTurns != weatherTurns-1 {
+		t.Fatalf("expected a sandstorm with %d turns left, got %q with %d", weatherTurns-1, battle.Weather, battle.WeatherTurns)
+	}
+	if pikachu.HP != 60 || geodude.HP != geodude.Stats["hp"] {
+		t.Errorf("expected the sandstorm to hurt only pikachu, got %d and %d hp", pikachu.HP, geodude.HP)
+	}
+	for i := 1; i < weatherTurns; i++ {
+		battle.endOfTurn()
+	}
+	if battle.Weather != "" {
+		t.Errorf("expected the sandstorm to subside after %d turns, got %s", weatherTurns, battle.WeatherStatus())
+	}
+}
+
+func TestWeatherAbility(t *testing.T) {
+	kyogre := testBattler("kyogre", 50, 90, tackle())
+	kyogre.Ability = "drizzle"
+	battle := NewBattle(&BattleSide{Name: "Ash", Team: []*Battler{testBattler("pikachu", 20, 90, tackle())}}, &BattleSide{Name: "wild kyogre", Team: []*Battler{kyogre}}, true, rand.New(rand.NewSource(1)))
+	battle.Start()
+	if battle.Weather != weatherRain || battle.WeatherStatus() != "rain (5 turns left)" {
+		t.Errorf("expected drizzle to start rain, got %q", battle.WeatherStatus())
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// a milestone is every this many species caught
+	speciesMilestone = 50
+	// deliveries waiting past this many are dropped rather than holding up the game
+	webhookQueueSize = 64
+	webhookAttempts  = 3
+	webhookTimeout   = 10 * time.Second
+)
+
+// a url notable events are posted to, from the webhooks list in the config
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// "json" posts the event as it is, "slack" and "discord" post a chat message, json when empty
+	Format string `json:"format"`
+	// which of "catch", "shiny" and "milestone" to post, all of them when empty
+	Events []string `json:"events"`
+}
+
+// whether the webhook wants events of kind
+func (hook WebhookConfig) Wants(kind string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, event := range hook.Events {
+		if strings.ToLower(event) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// what a webhook is told about, the json format posts it as it is
+type WebhookEvent struct {
+	// "catch", "shiny" or "milestone"
+	Kind    string    `json:"kind"`
+	Time    time.Time `json:"time"`
+	Player  string    `json:"player"`
+	Pokemon string    `json:"pokemon,omitempty"`
+	Level   int       `json:"level,omitempty"`
+	// species caught so far
+	Species int    `json:"species"`
+	Message string `json:"message"`
+}
+
+// the body posted for event in the webhook's format
+func (hook WebhookConfig) Payload(event WebhookEvent) ([]byte, error) {
+	switch strings.ToLower(hook.Format) {
+	case "", "json":
+		return json.Marshal(event)
+	case "slack":
+		return json.Marshal(map[string]string{"text": event.Message})
+	case "discord":
+		return json.Marshal(map[string]string{"content": event.Message})
+	}
+	return nil, fmt.Errorf("unknown webhook format %q, use json, slack or discord", hook.Format)
+}
+
+type webhookDelivery struct {
+	hook  WebhookConfig
+	event WebhookEvent
+}
+
+// posts events to the configured webhooks from a queue in the background, so a slow or broken webhook
+// never holds up the game, retrying failed posts with a growing wait between tries
+type Webhooks struct {
+	hooks  []WebhookConfig
+	client *http.Client
+	// the wait before the first retry, doubled for every one after
+	backoff time.Duration
+	queue   chan webhookDelivery
+	done    chan struct{}
+	mutex   sync.Mutex
+	closed  bool
+	// species caught as of the last event, a milestone is passing a multiple of speciesMilestone
+	species int
+}
+
+// start delivering to hooks, species is how many species the player has caught so far
+// returns nil when there are no webhooks, and a nil Webhooks sends nothing
+func NewWebhooks(hooks []WebhookConfig, client *http.Client, species int) *Webhooks {
+	if len(hooks) == 0 {
+		return nil
+	}
+	webhooks := &Webhooks{hooks: hooks, client: client, backoff: time.Second, species: species,
+		queue: make(chan webhookDelivery, webhookQueueSize), done: make(chan struct{})}
+	go webhooks.run()
+	return webhooks
+}
+
+func (webhooks *Webhooks) run() {
+	defer close(webhooks.done)
+	for delivery := range webhooks.queue {
+		err := webhooks.deliver(delivery)
+		if err != nil {
+			log.Printf("webhook %s: %v", delivery.hook.URL, err)
+		}
+	}
+}
+
+// post a delivery, trying again after network errors and server errors
+func (webhooks *Webhooks) deliver(delivery webhookDelivery) error {
+	body, err := delivery.hook.Payload(delivery.event)
+	if err != nil {
+		return err
+	}
+	wait := webhooks.backoff
+	for attempt := 1; ; attempt++ {
+		var response *http.Response
+		response, err = webhooks.client.Post(delivery.hook.URL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			response.Body.Close()
+			if response.StatusCode < 300 {
+				return nil
+			}
+			err = fmt.Errorf("%s", response.Status)
+			// the webhook turned the post down, sending it again won't change that
+			if response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests {
+				return err
+			}
+		}
+		if attempt == webhookAttempts {
+			return fmt.Errorf("giving up after %d tries: %w", attempt, err)
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// queue event for every webhook that wants it
+func (webhooks *Webhooks) Send(event WebhookEvent) {
+	if webhooks == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	webhooks.mutex.Lock()
+	defer webhooks.mutex.Unlock()
+	if webhooks.closed {
+		return
+	}
+	for _, hook := range webhooks.hooks {
+		if !hook.Wants(event.Kind) {
+			continue
+		}
+		select {
+		case webhooks.queue <- webhookDelivery{hook: hook, event: event}:
+		default:
+			log.Printf("webhook %s: queue full, dropped a %s event", hook.URL, event.Kind)
+		}
+	}
+}
+
+// stop taking events and wait up to timeout for the queued ones to be delivered
+// returns false if some were still waiting when the timeout passed
+func (webhooks *Webhooks) Close(timeout time.Duration) bool {
+	if webhooks == nil {
+		return true
+	}
+	webhooks.mutex.Lock()
+	if !webhooks.closed {
+		webhooks.closed = true
+		close(webhooks.queue)
+	}
+	webhooks.mutex.Unlock()
+	select {
+	case <-webhooks.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// turn catches into webhook events: every catch, shinies on their own, and a milestone whenever a new species
+// takes the count past a multiple of speciesMilestone
+func (session *Session) notifyWebhooks(event GameEvent) {
+	webhooks := session.webhooks
+	if webhooks == nil || event.Caught == nil {
+		return
+	}
+	if event.Kind != "catch" && event.Kind != "evolve" && event.Kind != "hatch" && event.Kind != "trade" {
+		return
+	}
+	caught := event.Caught
+	species := len(session.pokedex.SpeciesCaught())
+	player := session.profile.Name
+	if event.Kind == "catch" {
+		webhooks.Send(WebhookEvent{Kind: "catch", Player: player, Pokemon: caught.Name, Level: caught.Level, Species: species,
+			Message: fmt.Sprintf("%s caught %s (level %d)", player, caught.Name, caught.Level)})
+		if caught.Shiny {
+			webhooks.Send(WebhookEvent{Kind: "shiny", Player: player, Pokemon: caught.Name, Level: caught.Level, Species: species,
+				Message: fmt.Sprintf("%s %s caught a shiny %s! %s", shinyMarker, player, caught.Name, shinyMarker)})
+		}
+	}
+	if species/speciesMilestone > webhooks.species/speciesMilestone {
+		webhooks.Send(WebhookEvent{Kind: "milestone", Player: player, Pokemon: caught.Name, Species: species,
+			Message: fmt.Sprintf("%s has caught %d species!", player, species/speciesMilestone*speciesMilestone)})
+	}
+	webhooks.species = species
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhooks(t *testing.T) {
+	var mutex sync.Mutex
+	received := map[string][]string{}
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		// the json hook fails once and gets the post again
+		if r.URL.Path == "/json" && failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received[r.URL.Path] = append(received[r.URL.Path], string(body))
+	}))
+	defer server.Close()
+
+	webhooks := NewWebhooks([]WebhookConfig{
+		{URL: server.URL + "/json"},
+		{URL: server.URL + "/slack", Format: "slack", Events: []string{"shiny", "milestone"}},
+		{URL: server.URL + "/discord", Format: "discord", Events: []string{"milestone"}},
+	}, server.Client(), speciesMilestone-2)
+	webhooks.backoff = time.Millisecond
+
+	pokedex := NewPokedex()
+	for i := 0; i < speciesMilestone-2; i++ {
+		pokedex.Add(&CaughtPokemon{Pokemon: Pokemon{Name: "filler", Species: NamedResource{Name: string(rune('A' + i))}}})
+	}
+	session := &Session{pokedex: pokedex, profile: &Profile{Name: "Ash"}, webhooks: webhooks}
+	for _, caught := range []*CaughtPokemon{
+		{Pokemon: Pokemon{Name: "pikachu", Species: NamedResource{Name: "pikachu"}}, Level: 5, Shiny: true},
+		{Pokemon: Pokemon{Name: "pikachu", Species: NamedResource{Name: "pikachu"}}, Level: 6},
+		{Pokemon: Pokemon{Name: "eevee", Species: NamedResource{Name: "eevee"}}, Level: 7},
+	} {
+		pokedex.Add(caught)
+		session.notifyWebhooks(GameEvent{Kind: "catch", Caught: caught})
+	}
+	if !webhooks.Close(time.Second) {
+		t.Fatal("expected every delivery to finish")
+	}
+	webhooks.Send(WebhookEvent{Kind: "catch"})
+
+	// 3 catches, a shiny and the milestone eevee makes
+	if len(received["/json"]) != 5 {
+		t.Fatalf("expected 5 json posts, got %v", received["/json"])
+	}
+	var first WebhookEvent
+	if err := json.Unmarshal([]byte(received["/json"][0]), &first); err != nil || first.Kind != "catch" || first.Pokemon != "pikachu" || first.Species != speciesMilestone-1 {
+		t.Errorf("unexpected first event %+v", first)
+	}
+	if len(received["/slack"]) != 2 || received["/slack"][0] != `{"text":"✨ Ash caught a shiny pikachu! ✨"}` {
+		t.Errorf("unexpected slack posts %v", received["/slack"])
+	}
+	if len(received["/discord"]) != 1 || received["/discord"][0] != `{"content":"Ash has caught 50 species!"}` {
+		t.Errorf("unexpected discord posts %v", received["/discord"])
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	webhooks := &Webhooks{client: server.Client(), backoff: time.Millisecond}
+
+	err := webhooks.deliver(webhookDelivery{hook: WebhookConfig{URL: server.URL + "/broken"}, event: WebhookEvent{Kind: "catch"}})
+	if err == nil || posts != webhookAttempts {
+		t.Errorf("expected %d tries and an error, got %d and %v", webhookAttempts, posts, err)
+	}
+	posts = 0
+	err = webhooks.deliver(webhookDelivery{hook: WebhookConfig{URL: server.URL + "/gone"}, event: WebhookEvent{Kind: "catch"}})
+	if err == nil || posts != 1 {
+		t.Errorf("expected a client error not to be retried, got %d tries", posts)
+	}
+	if _, err := (WebhookConfig{Format: "irc"}).Payload(WebhookEvent{}); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}